@@ -0,0 +1,84 @@
+// Package acquire hands the tracks a run could not find in Plex to download or purchase workflows: an HTTP
+// endpoint that receives them as JSON, or a local command that reads them on stdin. Lidarr is an Acquirer
+// too, built by the app around its release group queue.
+package acquire
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+// PayloadVersion is the Payload.Version sent by this release. It changes only when fields are removed or
+// change meaning; new fields may be added without a bump.
+const PayloadVersion = 1
+
+// Acquirer receives a run's missing tracks.
+type Acquirer interface {
+	// Name identifies the provider in output, e.g. "webhook https://example.com/hook".
+	Name() string
+	// Acquire hands p to the provider. It is not called in dry-run mode.
+	Acquire(ctx context.Context, p Payload) error
+}
+
+// SelfReporting is an Acquirer that prints its own results (Lidarr lists every album it requests). The
+// caller prints no summary line for it.
+type SelfReporting interface {
+	Acquirer
+	// Preview prints what Acquire would do. It is called instead of Acquire in dry-run mode.
+	Preview(ctx context.Context, p Payload)
+}
+
+// Payload is the JSON document sent to every provider.
+type Payload struct {
+	Version int     `json:"version"`
+	Tracks  []Track `json:"tracks"`
+}
+
+// Track is one missing source track. Identifiers are omitted when the source has none.
+type Track struct {
+	Artist                    string     `json:"artist"`
+	Title                     string     `json:"title"`
+	Album                     string     `json:"album,omitempty"`
+	ISRC                      string     `json:"isrc,omitempty"`
+	MusicBrainzRecordingID    string     `json:"musicbrainz_recording_id,omitempty"`
+	MusicBrainzReleaseGroupID string     `json:"musicbrainz_release_group_id,omitempty"`
+	MusicBrainzArtistIDs      []string   `json:"musicbrainz_artist_ids,omitempty"`
+	SpotifyAlbumID            string     `json:"spotify_album_id,omitempty"`
+	AppleMusicAlbumID         string     `json:"apple_music_album_id,omitempty"`
+	Playlists                 []Playlist `json:"playlists"` // source playlists missing the track, in sync order
+}
+
+// Playlist is a source playlist that is missing a track.
+type Playlist struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// NewTrack converts a source track. Playlists is left for the caller.
+func NewTrack(t track.Track) Track {
+	return Track{
+		Artist:                    strings.TrimSpace(t.Artist),
+		Title:                     strings.TrimSpace(t.Name),
+		Album:                     strings.TrimSpace(t.Album),
+		ISRC:                      strings.TrimSpace(t.ISRC),
+		MusicBrainzRecordingID:    strings.TrimSpace(t.MusicBrainzID),
+		MusicBrainzReleaseGroupID: strings.TrimSpace(t.MusicBrainzReleaseGroupID),
+		MusicBrainzArtistIDs:      t.MusicBrainzArtistIDs(),
+		SpotifyAlbumID:            track.SpotifyAlbumID(t.SpotifyAlbumURI),
+		AppleMusicAlbumID:         strings.TrimSpace(t.AppleMusicAlbumID),
+	}
+}
+
+// Key identifies t across playlists: its recording MBID, else its ISRC, else artist and title.
+func (t Track) Key() string {
+	switch {
+	case t.MusicBrainzRecordingID != "":
+		return "mbid:" + t.MusicBrainzRecordingID
+	case t.ISRC != "":
+		return "isrc:" + strings.ToUpper(t.ISRC)
+	default:
+		return "name:" + strings.ToLower(t.Artist) + "\x00" + strings.ToLower(t.Title)
+	}
+}
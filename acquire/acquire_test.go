@@ -0,0 +1,139 @@
+package acquire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+var testPayload = Payload{Version: PayloadVersion, Tracks: []Track{{
+	Artist: "Band", Title: "Song", ISRC: "USABC1234567", MusicBrainzReleaseGroupID: "rg-1",
+	Playlists: []Playlist{{ID: "pl-1", Name: "One"}},
+}}}
+
+func TestNewTrack(t *testing.T) {
+	t.Parallel()
+	got := NewTrack(track.Track{
+		Artist: " Band ", Name: "Song", Album: "Debut", ISRC: "USABC1234567", MusicBrainzID: "rec-1",
+		MusicBrainzArtists: []track.ArtistCredit{{Name: "Band", ID: "mb-band"}}, SpotifyAlbumURI: "https://open.spotify.com/album/abc", AppleMusicAlbumID: "42",
+	})
+	want := Track{
+		Artist: "Band", Title: "Song", Album: "Debut", ISRC: "USABC1234567", MusicBrainzRecordingID: "rec-1",
+		MusicBrainzArtistIDs: []string{"mb-band"}, SpotifyAlbumID: "abc", AppleMusicAlbumID: "42",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTrack = %+v, want %+v", got, want)
+	}
+	if got.Key() != "mbid:rec-1" {
+		t.Errorf("Key = %q", got.Key())
+	}
+	if k := (Track{Artist: "Band", Title: "Song", ISRC: "usabc1234567"}).Key(); k != "isrc:USABC1234567" {
+		t.Errorf("ISRC key = %q", k)
+	}
+	if a, b := (Track{Artist: "Band", Title: "Song"}).Key(), (Track{Artist: "BAND", Title: "song"}).Key(); a != b {
+		t.Errorf("name keys differ: %q vs %q", a, b)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+	var got Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer tok" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s %v", r.Method, r.Header)
+		}
+		if r.URL.Query().Get("fail") != "" {
+			http.Error(w, "queue full", http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	w, err := NewWebhook(srv.URL+"/hook", "tok", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Acquire(context.Background(), testPayload); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, testPayload) {
+		t.Errorf("received %+v, want %+v", got, testPayload)
+	}
+
+	failing, _ := NewWebhook(srv.URL+"/hook?fail=1&key=secret", "tok", time.Second)
+	if strings.Contains(failing.Name(), "secret") {
+		t.Errorf("Name leaks the query: %q", failing.Name())
+	}
+	if err := failing.Acquire(context.Background(), testPayload); err == nil || !strings.Contains(err.Error(), "queue full") {
+		t.Errorf("error = %v, want the response body", err)
+	}
+	if _, err := NewWebhook("ftp://example.com", "", time.Second); err == nil {
+		t.Error("expected error for a non-HTTP URL")
+	}
+}
+
+func TestWebhook_errorRedactsURL(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	addr := srv.Listener.Addr().String()
+	srv.Close() // nothing listens any more, so the POST fails before a response
+
+	w, err := NewWebhook("http://plexify:hunter2@"+addr+"/hook?key=secret", "", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Acquire(context.Background(), testPayload)
+	if err == nil {
+		t.Fatal("expected a connection error")
+	}
+	if msg := err.Error(); strings.Contains(msg, "hunter2") || strings.Contains(msg, "secret") || !strings.Contains(msg, addr+"/hook") {
+		t.Errorf("error = %q, want the redacted URL", msg)
+	}
+}
+
+func TestExec(t *testing.T) {
+	t.Parallel()
+	if _, err := os.Stat("/bin/cp"); err != nil {
+		t.Skip("needs /bin/cp")
+	}
+	out := filepath.Join(t.TempDir(), "payload.json")
+	e, err := NewExec("/bin/cp /dev/stdin "+out, time.Second*5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Acquire(context.Background(), testPayload); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Payload
+	if err := json.Unmarshal(data, &got); err != nil || !reflect.DeepEqual(got, testPayload) {
+		t.Errorf("stdin = %s (%v)", data, err)
+	}
+
+	var stderr bytes.Buffer
+	failing, _ := NewExec("/bin/cp", time.Second*5)
+	failing.Stderr = &stderr
+	if err := failing.Acquire(context.Background(), testPayload); err == nil {
+		t.Error("expected error for a non-zero exit status")
+	}
+	if _, err := NewExec("  ", time.Second); err == nil {
+		t.Error("expected error for an empty command")
+	}
+}
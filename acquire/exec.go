@@ -0,0 +1,63 @@
+package acquire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Exec runs a command with the payload as JSON on stdin. A zero exit status is success; the command's
+// output goes to Stdout and Stderr.
+type Exec struct {
+	argv    []string
+	timeout time.Duration
+
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// NewExec returns a provider for command, split on whitespace and run without a shell (wrap it in a
+// script for pipes or quoting).
+func NewExec(command string, timeout time.Duration) (*Exec, error) {
+	argv := strings.Fields(command)
+	if len(argv) == 0 {
+		return nil, errors.New("empty command")
+	}
+	return &Exec{argv: argv, timeout: timeout, Stdout: os.Stdout, Stderr: os.Stderr}, nil
+}
+
+// Name implements Acquirer.
+func (e *Exec) Name() string {
+	return "exec " + e.argv[0]
+}
+
+// Acquire implements Acquirer.
+func (e *Exec) Acquire(ctx context.Context, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, e.argv[0], e.argv[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = e.Stdout
+	cmd.Stderr = e.Stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s: %w", e.argv[0], ctx.Err())
+		}
+		return fmt.Errorf("%s: %w", e.argv[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package acquire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Webhook POSTs the payload as JSON to a URL. Any 2xx response is success.
+type Webhook struct {
+	url   string
+	token string
+	http  *http.Client
+}
+
+// NewWebhook returns a provider for rawURL. token, when set, is sent as a bearer token.
+func NewWebhook(rawURL, token string, timeout time.Duration) (*Webhook, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q", rawURL)
+	}
+	return &Webhook{url: u.String(), token: token, http: &http.Client{Timeout: timeout}}, nil
+}
+
+// Name implements Acquirer. The URL's query and credentials are left out, since they may hold secrets.
+func (w *Webhook) Name() string {
+	u, err := url.Parse(w.url)
+	if err != nil {
+		return "webhook"
+	}
+	u.User, u.RawQuery = nil, ""
+	return "webhook " + u.String()
+}
+
+// Acquire implements Acquirer.
+func (w *Webhook) Acquire(ctx context.Context, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "plexify")
+	if w.token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.token)
+	}
+	resp, err := w.http.Do(req)
+	if err != nil {
+		// *url.Error repeats the full URL, query and userinfo included; report the redacted Name instead.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%s: %w", w.Name(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(snippet)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,121 @@
+// Package aliases loads artist alias tables: groups of names (renames, stylizations, legal names) that
+// refer to the same act, from a user-maintained file or an import of the MusicBrainz artist dump.
+// Lookups are in memory; nothing here touches the network.
+package aliases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Artist is one alias group: the names are interchangeable when searching and scoring.
+type Artist struct {
+	Name    string   `json:"name"`
+	MBID    string   `json:"mbid,omitempty"` // MusicBrainz artist id (set by Import)
+	Aliases []string `json:"aliases"`
+}
+
+type fileDoc struct {
+	Artists []Artist `json:"artists"`
+}
+
+// Set indexes alias groups by normalized name. A nil *Set has no aliases.
+type Set struct {
+	groups [][]string
+	byName map[string][]int // Key(name) → indexes into groups
+}
+
+// Key folds an artist name for alias lookup: lowercase with runs of whitespace collapsed.
+func Key(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Load reads and merges the alias files at paths (empty entries are ignored). A missing file is an error:
+// alias files are written by hand or by Import, never created implicitly.
+func Load(paths ...string) (*Set, error) {
+	s := &Set{byName: make(map[string][]int)}
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		raw, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("artist aliases file %s does not exist", path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read artist aliases file: %w", err)
+		}
+		var doc fileDoc
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil, fmt.Errorf("decode artist aliases file %s: %w", path, err)
+		}
+		for i, a := range doc.Artists {
+			if strings.TrimSpace(a.Name) == "" {
+				return nil, fmt.Errorf("artist aliases file %s: entry %d: name is empty", path, i+1)
+			}
+			s.Add(a)
+		}
+	}
+	return s, nil
+}
+
+// Add registers one alias group. Groups with fewer than two distinct names are ignored.
+func (s *Set) Add(a Artist) {
+	var names []string
+	seen := map[string]bool{}
+	for _, n := range append([]string{a.Name}, a.Aliases...) {
+		n = strings.TrimSpace(n)
+		k := Key(n)
+		if k == "" || seen[k] {
+			continue
+		}
+		seen[k] = true
+		names = append(names, n)
+	}
+	if len(names) < 2 {
+		return
+	}
+	if s.byName == nil {
+		s.byName = make(map[string][]int)
+	}
+	gi := len(s.groups)
+	s.groups = append(s.groups, names)
+	for k := range seen {
+		s.byName[k] = append(s.byName[k], gi)
+	}
+}
+
+// Len returns the number of alias groups.
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.groups)
+}
+
+// Names returns the other names of every group containing name, in file order, without name itself.
+func (s *Set) Names(name string) []string {
+	if s == nil {
+		return nil
+	}
+	k := Key(name)
+	groups := s.byName[k]
+	if len(groups) == 0 {
+		return nil
+	}
+	seen := map[string]bool{k: true}
+	var out []string
+	for _, gi := range groups {
+		for _, n := range s.groups[gi] {
+			if nk := Key(n); !seen[nk] {
+				seen[nk] = true
+				out = append(out, n)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,85 @@
+package aliases
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad_mergesFilesAndNames(t *testing.T) {
+	dir := t.TempDir()
+	user := filepath.Join(dir, "user.json")
+	mb := filepath.Join(dir, "mb.json")
+	if err := os.WriteFile(user, []byte(`{"artists":[{"name":"Diana Gordon","aliases":["Wynter Gordon"]}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mb, []byte(`{"artists":[
+		{"name":"P!nk","mbid":"f4d5cc07","aliases":["Pink","P!NK"]},
+		{"name":"Wynter Gordon","aliases":["Wynter"]}
+	]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(user, "", mb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", s.Len())
+	}
+	if got, want := s.Names("  wynter   GORDON "), []string{"Diana Gordon", "Wynter"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names(wynter gordon) = %q, want %q", got, want)
+	}
+	if got, want := s.Names("pink"), []string{"P!nk"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names(pink) = %q, want %q", got, want)
+	}
+	if got := s.Names("Someone Else"); got != nil {
+		t.Errorf("Names(unknown) = %q", got)
+	}
+
+	var nilSet *Set
+	if nilSet.Len() != 0 || nilSet.Names("Pink") != nil {
+		t.Error("nil Set should have no aliases")
+	}
+}
+
+func TestLoad_errors(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "nope.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte(`{"artists":[{"aliases":["X"]}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "entry 1") {
+		t.Errorf("Load(nameless entry) error = %v", err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	dump := `{"id":"a1","name":"P!nk","sort-name":"P!nk","aliases":[{"name":"Pink","type":"Search hint"},{"name":"p!nk"}]}
+{"id":"a2","name":"Nobody","aliases":[]}
+{"id":"a3","name":"Diana Gordon","aliases":[{"name":"Wynter Gordon","ended":true}]}
+`
+	var out bytes.Buffer
+	st, err := Import(strings.NewReader(dump), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Artists != 3 || st.Written != 2 {
+		t.Errorf("stats = %+v, want 3 read, 2 written", st)
+	}
+	path := filepath.Join(t.TempDir(), "mb.json")
+	if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("imported file does not load: %v\n%s", err, out.String())
+	}
+	if got := s.Names("Wynter Gordon"); !reflect.DeepEqual(got, []string{"Diana Gordon"}) {
+		t.Errorf("Names(Wynter Gordon) = %q", got)
+	}
+}
@@ -0,0 +1,74 @@
+package aliases
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// mbArtist is the subset of one record in the MusicBrainz JSON artist dump (mbdump/artist: one JSON
+// object per line, as returned by /ws/2/artist?inc=aliases).
+type mbArtist struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Aliases []struct {
+		Name string `json:"name"`
+	} `json:"aliases"`
+}
+
+// ImportStats summarizes an Import run.
+type ImportStats struct {
+	Artists int // dump records read
+	Written int // alias groups written (artists with at least one alias differing from the name)
+}
+
+// Import converts a MusicBrainz JSON artist dump read from r into an alias file written to w. Only
+// artists with an alias other than their own name are kept; ended aliases (old names) are kept too,
+// since those are what older catalogs still use.
+func Import(r io.Reader, w io.Writer) (ImportStats, error) {
+	var st ImportStats
+	dec := json.NewDecoder(bufio.NewReaderSize(r, 1<<20))
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString("{\"artists\": [\n"); err != nil {
+		return st, err
+	}
+	for {
+		var rec mbArtist
+		err := dec.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return st, fmt.Errorf("decode artist record %d: %w", st.Artists+1, err)
+		}
+		st.Artists++
+
+		a := Artist{Name: rec.Name, MBID: rec.ID}
+		for _, al := range rec.Aliases {
+			if Key(al.Name) != "" && Key(al.Name) != Key(rec.Name) {
+				a.Aliases = append(a.Aliases, al.Name)
+			}
+		}
+		if Key(a.Name) == "" || len(a.Aliases) == 0 {
+			continue
+		}
+		line, err := json.Marshal(a)
+		if err != nil {
+			return st, err
+		}
+		if st.Written > 0 {
+			if _, err := bw.WriteString(",\n"); err != nil {
+				return st, err
+			}
+		}
+		if _, err := bw.Write(line); err != nil {
+			return st, err
+		}
+		st.Written++
+	}
+	if _, err := bw.WriteString("\n]}\n"); err != nil {
+		return st, err
+	}
+	return st, bw.Flush()
+}
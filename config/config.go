@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -15,6 +16,49 @@ type Config struct {
 	MusicSocial MusicSocialConfig
 	Plex        PlexConfig
 	Lidarr      LidarrConfig
+	MusicBrainz MusicBrainzConfig
+	Webhook     WebhookConfig
+	Acquire     AcquireConfig
+}
+
+// AcquireConfig configures the providers that receive each run's missing tracks besides Lidarr, for other
+// downloaders or manual purchase workflows.
+type AcquireConfig struct {
+	WebhookURL   string // PLEXIFY_ACQUIRE_WEBHOOK_URL: POST the missing tracks as JSON here
+	WebhookToken string // PLEXIFY_ACQUIRE_WEBHOOK_TOKEN: sent as a bearer token when set
+	// Exec is a command run with the missing tracks as JSON on stdin (PLEXIFY_ACQUIRE_EXEC). It is split
+	// on whitespace and run without a shell.
+	Exec           string
+	TimeoutSeconds int // PLEXIFY_ACQUIRE_TIMEOUT_SECONDS: limit for each provider call
+}
+
+// Enabled reports whether any acquisition provider is configured.
+func (a AcquireConfig) Enabled() bool {
+	return a.WebhookURL != "" || a.Exec != ""
+}
+
+// WebhookConfig configures "plexify serve", which re-syncs playlists when Lidarr reports an import.
+type WebhookConfig struct {
+	Listen string // PLEXIFY_WEBHOOK_LISTEN: address to listen on, e.g. ":8787"
+	// Username and Password, when Password is set, must match the HTTP basic auth Lidarr sends
+	// (PLEXIFY_WEBHOOK_USERNAME, PLEXIFY_WEBHOOK_PASSWORD).
+	Username string
+	Password string
+	// AllowUnauthenticated lets "plexify serve" start without a Password, accepting any request
+	// (PLEXIFY_WEBHOOK_ALLOW_UNAUTHENTICATED or -insecure-no-auth).
+	AllowUnauthenticated bool
+	// DelaySeconds is how long to wait after an import before re-syncing, giving Plex time to scan and
+	// letting imports that arrive together share one sync (PLEXIFY_WEBHOOK_DELAY_SECONDS).
+	DelaySeconds int
+}
+
+// MusicBrainzConfig holds the MusicBrainz web service used to find release groups for missing tracks
+// that only have a recording MBID or ISRC.
+type MusicBrainzConfig struct {
+	URL string // MUSICBRAINZ_URL: musicbrainz.org or a compatible mirror
+	// MaxRequestsPerSecond caps MusicBrainz requests (MUSICBRAINZ_MAX_REQUESTS_PER_SECOND). Default 1, the
+	// public server's limit; 0 = unlimited for local mirrors.
+	MaxRequestsPerSecond float64
 }
 
 // MusicSocialConfig holds HTTP API configuration for music-social.com (or a compatible host via MUSIC_SOCIAL_URL).
@@ -27,21 +71,105 @@ type MusicSocialConfig struct {
 
 // PlexConfig holds Plex server configuration
 type PlexConfig struct {
-	URL              string
-	Token            string
+	URL   string
+	Token string
+	// LibrarySectionID is the first numeric entry of PLEX_LIBRARY_SECTION_ID (0 when sections are given by
+	// name or left to auto-selection).
 	LibrarySectionID int
-	ServerID         string
+	// LibrarySections lists the PLEX_LIBRARY_SECTION_ID entries in priority order: section IDs or section
+	// names, resolved against /library/sections at startup.
+	LibrarySections []string
+	ServerID        string
 	// InsecureSkipVerify disables TLS certificate verification for Plex HTTPS (default true for typical LAN/self-signed setups). Set PLEX_VERIFY_TLS=true for strict verification.
 	InsecureSkipVerify    bool
 	MatchConcurrency      int  // parallel Plex track lookups (1 = sequential, max 32)
 	DryRun                bool // if true, do not create/update/clear/add playlist items on Plex
 	SkipFullLibrarySearch bool // if true, do not call /library/sections/{id}/all as last resort (PLEXIFY_FAST_SEARCH)
+	SkipAlbumFallback     bool // if true, do not look tracks up on their source album before /all (PLEXIFY_SKIP_ALBUM_FALLBACK)
 	// ExactMatchesOnly uses only the first Plex search strategy (raw title/artist) and skips full-library scan (PLEXIFY_EXACT_MATCHES_ONLY).
 	ExactMatchesOnly bool
 	// MaxRequestsPerSecond caps average Plex HTTP rate (~1/rps minimum spacing between request starts). Default 4; 0 = unlimited (PLEX_MAX_REQUESTS_PER_SECOND).
 	MaxRequestsPerSecond float64
 	// MatchConfidencePercent is the minimum combined title/artist match score (0–100) required to accept a Plex track. Default 80 (PLEXIFY_MATCH_CONFIDENCE_PERCENT).
 	MatchConfidencePercent int
+	// OverridesFile is the JSON manual match overrides file consulted before searching (PLEXIFY_OVERRIDES_FILE). Empty disables overrides.
+	OverridesFile string
+	// VersionMismatchPenaltyPercent is subtracted from a candidate's score per mismatched version qualifier such as live, remix or edit.
+	// Default 10 (PLEXIFY_VERSION_MISMATCH_PENALTY_PERCENT); 0 disables the penalty.
+	VersionMismatchPenaltyPercent int
+	// VersionPreferences orders tie-breaks between equally scored versions (PLEXIFY_VERSION_PREFERENCES). Empty disables them.
+	VersionPreferences []string
+	// QualityPreferences orders tie-breaks between equally good copies of the same track by audio quality
+	// and location, after VersionPreferences (PLEXIFY_QUALITY_PREFERENCES). Empty disables them.
+	QualityPreferences []string
+	// SimilarityAlgorithm selects the string similarity used when scoring candidates (PLEXIFY_SIMILARITY_ALGORITHM).
+	// One of heuristic (default), jaro-winkler, token-set or levenshtein.
+	SimilarityAlgorithm string
+	// Explain selects source tracks ("Artist - Title", a title fragment, source id, ISRC or MBID) whose match
+	// trace is printed; the run is limited to those tracks and forced to dry-run (PLEXIFY_EXPLAIN).
+	Explain string
+	// ExplainJSONFile, when set, receives a JSON dump of every processed track's match trace (PLEXIFY_EXPLAIN_JSON).
+	ExplainJSONFile string
+	// AutoAcceptPercent and AutoRejectPercent bound the review band: title/artist matches scoring at least
+	// AutoAcceptPercent are kept, candidates below AutoRejectPercent are dropped, and anything in between is
+	// reviewed (PLEXIFY_AUTO_ACCEPT_PERCENT, PLEXIFY_AUTO_REJECT_PERCENT). Both default to MatchConfidencePercent,
+	// which leaves the band empty.
+	AutoAcceptPercent int
+	AutoRejectPercent int
+	// Review prompts on the terminal for each match in the review band (PLEXIFY_REVIEW).
+	Review bool
+	// ReviewDefault decides review-band matches when nobody is prompted: accept or reject (PLEXIFY_REVIEW_DEFAULT).
+	ReviewDefault string
+	// ReviewSaveOverrides appends accepted review choices to OverridesFile (PLEXIFY_REVIEW_SAVE_OVERRIDES).
+	ReviewSaveOverrides bool
+	// SearchStrategies selects and orders the indexed Plex search strategies by name (PLEXIFY_SEARCH_STRATEGIES);
+	// entries prefixed with "!" disable a strategy instead. Names are checked by the Plex client. Empty runs all.
+	SearchStrategies []string
+	// SearchPhases selects and orders the indexed /search phases: combined and/or title-artist (PLEXIFY_SEARCH_PHASES).
+	SearchPhases []string
+	// MaxHTTPCallsPerTrack caps Plex requests spent matching one source track; 0 = unlimited (PLEXIFY_MAX_HTTP_CALLS_PER_TRACK).
+	MaxHTTPCallsPerTrack int
+	// NormalizationRulesFile is the JSON file of user-defined title/artist/album normalization rules
+	// (PLEXIFY_NORMALIZATION_RULES). Empty uses the built-in cleanups only.
+	NormalizationRulesFile string
+	// ArtistAliasesFiles are JSON artist alias tables (hand-written or imported from a MusicBrainz dump),
+	// merged in order (PLEXIFY_ARTIST_ALIASES, comma-separated). Empty disables aliases.
+	ArtistAliasesFiles []string
+	// HomeUsers maps source playlists or their owners to Plex Home users whose accounts receive those
+	// playlists (PLEXIFY_HOME_USERS). Unmapped playlists are written with Token.
+	HomeUsers []HomeUserMapping
+	// TVURL is the plex.tv account API used to switch to Home users (PLEX_TV_URL).
+	TVURL string
+	// ClientIdentifier identifies plexify to plex.tv (PLEXIFY_CLIENT_IDENTIFIER, written by "plexify login").
+	ClientIdentifier string
+}
+
+// HomeUserMapping is one PLEXIFY_HOME_USERS entry: "source=Plex user" or "source=Plex user:PIN".
+type HomeUserMapping struct {
+	Source string // music-social playlist ID or playlist owner username
+	User   string // Plex Home user title, username or UUID
+	PIN    string // required by plex.tv for PIN-protected users
+}
+
+// HomeUserFor returns the Home user mapping for a source playlist: an entry naming playlistID wins over
+// one naming the playlist's owner. Sources are compared ignoring case.
+func (p PlexConfig) HomeUserFor(playlistID, owner string) (HomeUserMapping, bool) {
+	for _, key := range []string{playlistID, owner} {
+		if strings.TrimSpace(key) == "" {
+			continue
+		}
+		for _, m := range p.HomeUsers {
+			if strings.EqualFold(m.Source, strings.TrimSpace(key)) {
+				return m, true
+			}
+		}
+	}
+	return HomeUserMapping{}, false
+}
+
+// ReviewBandEnabled is true when AutoRejectPercent is below AutoAcceptPercent, i.e. some matches need a review decision.
+func (p PlexConfig) ReviewBandEnabled() bool {
+	return p.AutoRejectPercent < p.AutoAcceptPercent
 }
 
 // LidarrConfig holds Lidarr API settings for auto-adding missing MusicBrainz release groups. Both URL and Token must be set to enable; see LidarrEnabled.
@@ -49,6 +177,112 @@ type LidarrConfig struct {
 	URL                string
 	Token              string
 	InsecureSkipVerify bool // LIDARR_INSECURE_SKIP_VERIFY: skip TLS verify for HTTPS (e.g. self-signed)
+	// AddProfile picks the root folder and profiles for artists Plexify adds (LIDARR_ROOT_FOLDER,
+	// LIDARR_QUALITY_PROFILE, LIDARR_METADATA_PROFILE). Empty fields use Lidarr's defaults.
+	AddProfile LidarrAddProfile
+	// PlaylistAddProfiles overrides AddProfile per source playlist ID (LIDARR_PLAYLIST_PROFILES).
+	PlaylistAddProfiles map[string]LidarrAddProfile
+	// ResolveReleaseGroups looks up release groups on MusicBrainz for missing tracks that only have a
+	// recording MBID or ISRC (LIDARR_RESOLVE_RELEASE_GROUPS, default off).
+	ResolveReleaseGroups bool
+	// Tags labels artists Plexify adds or re-monitors with Tag and the source playlist's name (LIDARR_TAGS,
+	// default on).
+	Tags bool
+	// Tag is the Lidarr tag shared by everything Plexify touches (LIDARR_TAG, default "plexify").
+	Tag string
+	// AddArtists adds the primary credited artist of missing tracks without a release group id
+	// (LIDARR_ADD_ARTISTS).
+	AddArtists bool
+	// ArtistMonitor is the Lidarr monitor option for artists added that way (LIDARR_ARTIST_MONITOR).
+	ArtistMonitor string
+	// RescanPlex asks Plex to scan the folder of albums Lidarr has imported but Plex has not matched yet,
+	// then matches those tracks again (LIDARR_RESCAN_PLEX, default on).
+	RescanPlex bool
+	// RescanTimeoutSeconds bounds the wait for those scans (LIDARR_RESCAN_TIMEOUT_SECONDS); 0 matches again
+	// without waiting.
+	RescanTimeoutSeconds int
+	// PlexPathMap translates Lidarr paths to the paths Plex sees (LIDARR_PLEX_PATH_MAP); see PlexPath.
+	PlexPathMap []PathMapping
+	// MinPlaylists is how many of the run's playlists must be missing a release group (or artist) before
+	// it is requested (LIDARR_MIN_PLAYLISTS, default 1).
+	MinPlaylists int
+	// PlaylistAllowlist lists source playlist IDs whose missing albums are requested whatever MinPlaylists
+	// says (LIDARR_PLAYLIST_ALLOWLIST).
+	PlaylistAllowlist []string
+	// MaxAddsPerRun caps new albums added per run, most-wanted first (LIDARR_MAX_ADDS_PER_RUN); 0 = no cap.
+	MaxAddsPerRun int
+	// SkipCompilations leaves out new albums Lidarr's lookup marks as compilations or soundtracks
+	// (LIDARR_SKIP_COMPILATIONS).
+	SkipCompilations bool
+	// StateFile records the release groups Plexify added to Lidarr or re-monitored (LIDARR_STATE_FILE).
+	// Empty disables the record.
+	StateFile string
+	// Unmonitor turns monitoring off, after the run, for albums recorded in StateFile that no source
+	// playlist references any more (LIDARR_UNMONITOR). Files are never deleted.
+	Unmonitor bool
+}
+
+// PathMapping rewrites paths under From to the same paths under To.
+type PathMapping struct {
+	From string
+	To   string
+}
+
+// PlexPath translates a Lidarr path with the longest matching PlexPathMap prefix. Prefixes match whole
+// path components; paths under no prefix are returned unchanged.
+func (l LidarrConfig) PlexPath(p string) string {
+	best, bestLen := -1, -1
+	for i, m := range l.PlexPathMap {
+		from := strings.TrimRight(m.From, "/")
+		if (p == from || strings.HasPrefix(p, from+"/")) && len(from) > bestLen {
+			best, bestLen = i, len(from)
+		}
+	}
+	if best < 0 {
+		return p
+	}
+	return strings.TrimRight(l.PlexPathMap[best].To, "/") + p[bestLen:]
+}
+
+// Lidarr monitor options for artists added by LIDARR_ADD_ARTISTS.
+const (
+	LidarrMonitorNone   = "none"
+	LidarrMonitorFuture = "future"
+	LidarrMonitorLatest = "latest"
+	LidarrMonitorAll    = "all"
+)
+
+// LidarrAddProfile names where Lidarr files a new artist: each field is a name or numeric id, and the root
+// folder may also be given by path.
+type LidarrAddProfile struct {
+	RootFolder      string
+	QualityProfile  string
+	MetadataProfile string
+}
+
+// AddProfileFor returns the add profile for a source playlist: its LIDARR_PLAYLIST_PROFILES fields over
+// the global AddProfile.
+func (l LidarrConfig) AddProfileFor(playlistID string) LidarrAddProfile {
+	p := l.AddProfile
+	o, ok := l.PlaylistAddProfiles[playlistID]
+	if !ok {
+		return p
+	}
+	if o.RootFolder != "" {
+		p.RootFolder = o.RootFolder
+	}
+	if o.QualityProfile != "" {
+		p.QualityProfile = o.QualityProfile
+	}
+	if o.MetadataProfile != "" {
+		p.MetadataProfile = o.MetadataProfile
+	}
+	return p
+}
+
+// Allowlisted reports whether a source playlist is in LIDARR_PLAYLIST_ALLOWLIST.
+func (l LidarrConfig) Allowlisted(playlistID string) bool {
+	return slices.Contains(l.PlaylistAllowlist, playlistID)
 }
 
 // LidarrEnabled is true when Lidarr integration should run (both URL and non-empty API token are set).
@@ -70,6 +304,9 @@ func Load() (*Config, error) {
 	if err := applyMatchConfidencePercentFromEnv(config); err != nil {
 		return nil, err
 	}
+	if err := applyLidarrPlaylistProfilesFromEnv(config); err != nil {
+		return nil, err
+	}
 
 	if err := config.validate(); err != nil {
 		return nil, err
@@ -88,6 +325,9 @@ func LoadWithOverrides(overrides map[string]string) (*Config, error) {
 	if err := applyMatchConfidencePercentFromEnv(config); err != nil {
 		return nil, err
 	}
+	if err := applyLidarrPlaylistProfilesFromEnv(config); err != nil {
+		return nil, err
+	}
 	config.applyOverrides(overrides)
 
 	if err := config.validate(); err != nil {
@@ -117,18 +357,118 @@ func (c *Config) initializeDefaults() {
 		ExactMatchesOnly:       false,
 		MaxRequestsPerSecond:   4,
 		MatchConfidencePercent: DefaultMatchConfidencePercent,
+
+		VersionMismatchPenaltyPercent: DefaultVersionMismatchPenaltyPercent,
+		VersionPreferences:            DefaultVersionPreferences,
+		SimilarityAlgorithm:           SimilarityHeuristic,
+		AutoAcceptPercent:             -1,
+		AutoRejectPercent:             -1,
+		ReviewDefault:                 ReviewDefaultReject,
+		TVURL:                         DefaultPlexTVURL,
 	}
 
 	c.Lidarr = LidarrConfig{
 		URL:                "",
 		Token:              "",
+		ArtistMonitor:      LidarrMonitorFuture,
 		InsecureSkipVerify: false,
+
+		ResolveReleaseGroups: false,
+		Tags:                 true,
+		Tag:                  DefaultLidarrTag,
+		RescanPlex:           true,
+		RescanTimeoutSeconds: DefaultRescanTimeoutSeconds,
+		MinPlaylists:         1,
+	}
+
+	c.MusicBrainz = MusicBrainzConfig{
+		URL:                  DefaultMusicBrainzURL,
+		MaxRequestsPerSecond: 1,
+	}
+
+	c.Webhook = WebhookConfig{
+		Listen:       DefaultWebhookListen,
+		DelaySeconds: DefaultWebhookDelaySeconds,
+	}
+
+	c.Acquire = AcquireConfig{
+		TimeoutSeconds: DefaultAcquireTimeoutSeconds,
 	}
 }
 
 // DefaultMatchConfidencePercent is the default minimum match score (whole percent) when PLEXIFY_MATCH_CONFIDENCE_PERCENT is unset.
 const DefaultMatchConfidencePercent = 80
 
+// DefaultVersionMismatchPenaltyPercent is the default per-qualifier score penalty when PLEXIFY_VERSION_MISMATCH_PENALTY_PERCENT is unset.
+const DefaultVersionMismatchPenaltyPercent = 10
+
+// Version preferences accepted in PLEXIFY_VERSION_PREFERENCES.
+const (
+	// VersionPreferenceNonLive prefers studio recordings over live ones unless the source title asks for live.
+	VersionPreferenceNonLive = "non-live"
+	// VersionPreferenceOriginalAlbum prefers tracks from original albums over compilations and Various Artists releases.
+	VersionPreferenceOriginalAlbum = "original-album"
+)
+
+// Quality preferences accepted in PLEXIFY_QUALITY_PREFERENCES.
+const (
+	// QualityPreferenceLossless prefers lossless audio (FLAC, ALAC, WAV, …) over lossy.
+	QualityPreferenceLossless = "lossless"
+	// QualityPreferenceHighestBitrate prefers the higher bitrate, then bit depth.
+	QualityPreferenceHighestBitrate = "highest-bitrate"
+	// QualityPreferenceNonCompilation prefers original albums over compilations, whatever the source album is.
+	QualityPreferenceNonCompilation = "non-compilation"
+	// QualityPreferenceFolderPrefix starts a "folder:/path" entry preferring files under that library path.
+	QualityPreferenceFolderPrefix = "folder:"
+)
+
+// String similarity algorithms accepted in PLEXIFY_SIMILARITY_ALGORITHM.
+const (
+	SimilarityHeuristic   = "heuristic"
+	SimilarityJaroWinkler = "jaro-winkler"
+	SimilarityTokenSet    = "token-set"
+	SimilarityLevenshtein = "levenshtein"
+)
+
+// Search phases accepted in PLEXIFY_SEARCH_PHASES.
+const (
+	// SearchPhaseCombined sends one "title artist" query per strategy.
+	SearchPhaseCombined = "combined"
+	// SearchPhaseTitleArtist queries by title, then by artist.
+	SearchPhaseTitleArtist = "title-artist"
+)
+
+// Review-band defaults accepted in PLEXIFY_REVIEW_DEFAULT.
+const (
+	ReviewDefaultAccept = "accept"
+	ReviewDefaultReject = "reject"
+)
+
+// DefaultVersionPreferences is the tie-break order used when PLEXIFY_VERSION_PREFERENCES is unset.
+var DefaultVersionPreferences = []string{VersionPreferenceNonLive, VersionPreferenceOriginalAlbum}
+
+// DefaultPlexTVURL is the default PLEX_TV_URL, the plex.tv account API used by "plexify login" and to
+// switch to Plex Home users.
+const DefaultPlexTVURL = "https://plex.tv"
+
+// DefaultLidarrTag is the default LIDARR_TAG.
+const DefaultLidarrTag = "plexify"
+
+// DefaultRescanTimeoutSeconds is the default LIDARR_RESCAN_TIMEOUT_SECONDS.
+const DefaultRescanTimeoutSeconds = 120
+
+// DefaultWebhookListen is the default PLEXIFY_WEBHOOK_LISTEN.
+const DefaultWebhookListen = ":8787"
+
+// DefaultWebhookDelaySeconds is the default PLEXIFY_WEBHOOK_DELAY_SECONDS.
+const DefaultWebhookDelaySeconds = 30
+
+// DefaultAcquireTimeoutSeconds is the default PLEXIFY_ACQUIRE_TIMEOUT_SECONDS.
+const DefaultAcquireTimeoutSeconds = 60
+
+// DefaultMusicBrainzURL is the default MUSICBRAINZ_URL.
+const DefaultMusicBrainzURL = "https://musicbrainz.org"
+
 // DefaultMusicSocialBaseURL is the default MUSIC_SOCIAL_URL (https://music-social.com). Override for a self-hosted or other compatible API base.
 const DefaultMusicSocialBaseURL = "https://music-social.com"
 
@@ -153,9 +493,7 @@ func (c *Config) loadFromOSEnv() {
 		c.Plex.Token = value
 	}
 	if value := os.Getenv("PLEX_LIBRARY_SECTION_ID"); value != "" {
-		if sectionID, err := parseLibrarySectionID(value); err == nil {
-			c.Plex.LibrarySectionID = sectionID
-		}
+		c.Plex.setLibrarySections(value)
 	}
 	if value := os.Getenv("PLEX_SERVER_ID"); value != "" {
 		c.Plex.ServerID = value
@@ -176,7 +514,259 @@ func (c *Config) loadFromOSEnv() {
 	if f, ok := parseFloatEnv("PLEX_MAX_REQUESTS_PER_SECOND"); ok {
 		c.Plex.MaxRequestsPerSecond = f
 	}
+	c.loadMatchingFromEnv()
+	c.loadPlexHomeFromEnv()
 	c.loadLidarrFromEnv()
+	c.loadWebhookFromEnv()
+	c.loadAcquireFromEnv()
+}
+
+// loadMatchingFromEnv applies optional settings that tune how source tracks are matched to Plex.
+func (c *Config) loadMatchingFromEnv() {
+	if value := os.Getenv("PLEXIFY_OVERRIDES_FILE"); value != "" {
+		c.Plex.OverridesFile = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("PLEXIFY_VERSION_MISMATCH_PENALTY_PERCENT"); value != "" {
+		if p, err := ParseMatchConfidencePercent(value); err == nil {
+			c.Plex.VersionMismatchPenaltyPercent = p
+		}
+	}
+	if value := os.Getenv("PLEXIFY_VERSION_PREFERENCES"); value != "" {
+		c.Plex.VersionPreferences = parseVersionPreferences(value)
+	}
+	if value := os.Getenv("PLEXIFY_QUALITY_PREFERENCES"); value != "" {
+		c.Plex.QualityPreferences = parseQualityPreferences(value)
+	}
+	if value := os.Getenv("PLEXIFY_SIMILARITY_ALGORITHM"); value != "" {
+		c.Plex.SimilarityAlgorithm = strings.ToLower(strings.TrimSpace(value))
+	}
+	if value := os.Getenv("PLEXIFY_EXPLAIN"); value != "" {
+		c.Plex.Explain = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("PLEXIFY_EXPLAIN_JSON"); value != "" {
+		c.Plex.ExplainJSONFile = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("PLEXIFY_AUTO_ACCEPT_PERCENT"); value != "" {
+		if p, err := ParseMatchConfidencePercent(value); err == nil {
+			c.Plex.AutoAcceptPercent = p
+		}
+	}
+	if value := os.Getenv("PLEXIFY_AUTO_REJECT_PERCENT"); value != "" {
+		if p, err := ParseMatchConfidencePercent(value); err == nil {
+			c.Plex.AutoRejectPercent = p
+		}
+	}
+	if parseBoolEnv("PLEXIFY_REVIEW") {
+		c.Plex.Review = true
+	}
+	if value := os.Getenv("PLEXIFY_REVIEW_DEFAULT"); value != "" {
+		c.Plex.ReviewDefault = strings.ToLower(strings.TrimSpace(value))
+	}
+	if parseBoolEnv("PLEXIFY_REVIEW_SAVE_OVERRIDES") {
+		c.Plex.ReviewSaveOverrides = true
+	}
+	if parseBoolEnv("PLEXIFY_SKIP_ALBUM_FALLBACK") {
+		c.Plex.SkipAlbumFallback = true
+	}
+	if value := os.Getenv("PLEXIFY_SEARCH_STRATEGIES"); value != "" {
+		c.Plex.SearchStrategies = parseNonEmptyList(value)
+	}
+	if value := os.Getenv("PLEXIFY_SEARCH_PHASES"); value != "" {
+		c.Plex.SearchPhases = parseNonEmptyList(strings.ToLower(value))
+	}
+	if n, ok := parseIntEnv("PLEXIFY_MAX_HTTP_CALLS_PER_TRACK"); ok {
+		c.Plex.MaxHTTPCallsPerTrack = n
+	}
+	if value := os.Getenv("PLEXIFY_NORMALIZATION_RULES"); value != "" {
+		c.Plex.NormalizationRulesFile = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("PLEXIFY_ARTIST_ALIASES"); value != "" {
+		c.Plex.ArtistAliasesFiles = parseNonEmptyList(value)
+	}
+}
+
+// parseNonEmptyList parses a comma-separated list, dropping empty entries.
+func parseNonEmptyList(value string) []string {
+	var out []string
+	for _, p := range parseCommaSeparatedList(value) {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// parseVersionPreferences parses a comma-separated preference list; "none" yields an empty (disabled) list.
+func parseVersionPreferences(value string) []string {
+	if strings.EqualFold(strings.TrimSpace(value), "none") {
+		return []string{}
+	}
+	prefs := parseNonEmptyList(strings.ToLower(value))
+	if prefs == nil {
+		prefs = []string{}
+	}
+	return prefs
+}
+
+// parseQualityPreferences parses a comma-separated preference list. Keywords are lowercased; folder paths
+// keep their case.
+func parseQualityPreferences(value string) []string {
+	var prefs []string
+	for _, p := range parseNonEmptyList(value) {
+		if len(p) >= len(QualityPreferenceFolderPrefix) && strings.EqualFold(p[:len(QualityPreferenceFolderPrefix)], QualityPreferenceFolderPrefix) {
+			prefs = append(prefs, QualityPreferenceFolderPrefix+strings.TrimSpace(p[len(QualityPreferenceFolderPrefix):]))
+			continue
+		}
+		prefs = append(prefs, strings.ToLower(p))
+	}
+	return prefs
+}
+
+func validateQualityPreferences(prefs []string) error {
+	for _, p := range prefs {
+		switch {
+		case p == QualityPreferenceLossless, p == QualityPreferenceHighestBitrate, p == QualityPreferenceNonCompilation:
+		case strings.HasPrefix(p, QualityPreferenceFolderPrefix) && p != QualityPreferenceFolderPrefix:
+		default:
+			return fmt.Errorf("invalid PLEXIFY_QUALITY_PREFERENCES entry %q (want %s, %s, %s or %s/path)", p,
+				QualityPreferenceLossless, QualityPreferenceHighestBitrate, QualityPreferenceNonCompilation, QualityPreferenceFolderPrefix)
+		}
+	}
+	return nil
+}
+
+func validateSimilarityAlgorithm(name string) error {
+	switch name {
+	case "", SimilarityHeuristic, SimilarityJaroWinkler, SimilarityTokenSet, SimilarityLevenshtein:
+		return nil
+	}
+	return fmt.Errorf("invalid PLEXIFY_SIMILARITY_ALGORITHM %q (want %s, %s, %s or %s)", name,
+		SimilarityHeuristic, SimilarityJaroWinkler, SimilarityTokenSet, SimilarityLevenshtein)
+}
+
+func validateVersionPreferences(prefs []string) error {
+	for _, p := range prefs {
+		switch p {
+		case VersionPreferenceNonLive, VersionPreferenceOriginalAlbum:
+		default:
+			return fmt.Errorf("invalid PLEXIFY_VERSION_PREFERENCES entry %q (want %s, %s or none)", p, VersionPreferenceNonLive, VersionPreferenceOriginalAlbum)
+		}
+	}
+	return nil
+}
+
+// loadPlexHomeFromEnv applies the plex.tv settings used to write playlists into Plex Home users' accounts.
+func (c *Config) loadPlexHomeFromEnv() {
+	if value := os.Getenv("PLEXIFY_HOME_USERS"); value != "" {
+		c.Plex.HomeUsers = parseHomeUsers(value)
+	}
+	if value := os.Getenv("PLEX_TV_URL"); value != "" {
+		c.Plex.TVURL = value
+	}
+	if value := os.Getenv("PLEXIFY_CLIENT_IDENTIFIER"); value != "" {
+		c.Plex.ClientIdentifier = strings.TrimSpace(value)
+	}
+}
+
+// parseHomeUsers splits PLEXIFY_HOME_USERS ("source=user[:PIN]", comma-separated). A trailing ":digits"
+// is the PIN, so user names may themselves contain colons. Malformed entries are kept with an empty User
+// for validate to report.
+func parseHomeUsers(value string) []HomeUserMapping {
+	var out []HomeUserMapping
+	for _, entry := range parseCommaSeparatedList(value) {
+		source, user, ok := strings.Cut(entry, "=")
+		if !ok {
+			out = append(out, HomeUserMapping{Source: entry})
+			continue
+		}
+		m := HomeUserMapping{Source: strings.TrimSpace(source), User: strings.TrimSpace(user)}
+		if i := strings.LastIndex(m.User, ":"); i >= 0 && isAllDigits(m.User[i+1:]) {
+			m.PIN = m.User[i+1:]
+			m.User = strings.TrimSpace(m.User[:i])
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func (c *Config) loadWebhookFromEnv() {
+	if value := os.Getenv("PLEXIFY_WEBHOOK_LISTEN"); value != "" {
+		c.Webhook.Listen = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("PLEXIFY_WEBHOOK_USERNAME"); value != "" {
+		c.Webhook.Username = value
+	}
+	if value := os.Getenv("PLEXIFY_WEBHOOK_PASSWORD"); value != "" {
+		c.Webhook.Password = value
+	}
+	if parseBoolEnv("PLEXIFY_WEBHOOK_ALLOW_UNAUTHENTICATED") {
+		c.Webhook.AllowUnauthenticated = true
+	}
+	if n, ok := parseIntEnv("PLEXIFY_WEBHOOK_DELAY_SECONDS"); ok {
+		c.Webhook.DelaySeconds = n
+	}
+}
+
+func (c *Config) loadAcquireFromEnv() {
+	if value := os.Getenv("PLEXIFY_ACQUIRE_WEBHOOK_URL"); value != "" {
+		c.Acquire.WebhookURL = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("PLEXIFY_ACQUIRE_WEBHOOK_TOKEN"); value != "" {
+		c.Acquire.WebhookToken = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("PLEXIFY_ACQUIRE_EXEC"); value != "" {
+		c.Acquire.Exec = strings.TrimSpace(value)
+	}
+	if n, ok := parseIntEnv("PLEXIFY_ACQUIRE_TIMEOUT_SECONDS"); ok {
+		c.Acquire.TimeoutSeconds = n
+	}
+}
+
+// parsePathMappings splits LIDARR_PLEX_PATH_MAP ("lidarr path=plex path", comma-separated). Malformed
+// entries are kept with an empty To for validate to report.
+func parsePathMappings(value string) []PathMapping {
+	var out []PathMapping
+	for _, entry := range parseCommaSeparatedList(value) {
+		from, to, _ := strings.Cut(entry, "=")
+		out = append(out, PathMapping{From: strings.TrimSpace(from), To: strings.TrimSpace(to)})
+	}
+	return out
+}
+
+func isAllDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLidarrTagLabel(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func validateHomeUsers(mappings []HomeUserMapping) error {
+	seen := make(map[string]bool, len(mappings))
+	for _, m := range mappings {
+		if m.Source == "" || m.User == "" {
+			return fmt.Errorf("invalid PLEXIFY_HOME_USERS entry %q (want source=Plex user or source=Plex user:PIN)", m.Source+"="+m.User)
+		}
+		key := strings.ToLower(m.Source)
+		if seen[key] {
+			return fmt.Errorf("PLEXIFY_HOME_USERS maps %q more than once", m.Source)
+		}
+		seen[key] = true
+	}
+	return nil
 }
 
 func (c *Config) loadLidarrFromEnv() {
@@ -189,6 +779,95 @@ func (c *Config) loadLidarrFromEnv() {
 	if v, ok := os.LookupEnv("LIDARR_INSECURE_SKIP_VERIFY"); ok {
 		c.Lidarr.InsecureSkipVerify = isTruthy(v)
 	}
+	if value := os.Getenv("LIDARR_ROOT_FOLDER"); value != "" {
+		c.Lidarr.AddProfile.RootFolder = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("LIDARR_QUALITY_PROFILE"); value != "" {
+		c.Lidarr.AddProfile.QualityProfile = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("LIDARR_METADATA_PROFILE"); value != "" {
+		c.Lidarr.AddProfile.MetadataProfile = strings.TrimSpace(value)
+	}
+	if v, ok := os.LookupEnv("LIDARR_RESOLVE_RELEASE_GROUPS"); ok && strings.TrimSpace(v) != "" {
+		c.Lidarr.ResolveReleaseGroups = isTruthy(v)
+	}
+	if v, ok := os.LookupEnv("LIDARR_TAGS"); ok && strings.TrimSpace(v) != "" {
+		c.Lidarr.Tags = isTruthy(v)
+	}
+	if value := os.Getenv("LIDARR_TAG"); value != "" {
+		c.Lidarr.Tag = strings.TrimSpace(value)
+	}
+	if value := os.Getenv("MUSICBRAINZ_URL"); value != "" {
+		c.MusicBrainz.URL = value
+	}
+	if f, ok := parseFloatEnv("MUSICBRAINZ_MAX_REQUESTS_PER_SECOND"); ok {
+		c.MusicBrainz.MaxRequestsPerSecond = f
+	}
+	if parseBoolEnv("LIDARR_ADD_ARTISTS") {
+		c.Lidarr.AddArtists = true
+	}
+	if value := os.Getenv("LIDARR_ARTIST_MONITOR"); value != "" {
+		c.Lidarr.ArtistMonitor = strings.ToLower(strings.TrimSpace(value))
+	}
+	if v, ok := os.LookupEnv("LIDARR_RESCAN_PLEX"); ok && strings.TrimSpace(v) != "" {
+		c.Lidarr.RescanPlex = isTruthy(v)
+	}
+	if n, ok := parseIntEnv("LIDARR_RESCAN_TIMEOUT_SECONDS"); ok {
+		c.Lidarr.RescanTimeoutSeconds = n
+	}
+	if value := os.Getenv("LIDARR_PLEX_PATH_MAP"); value != "" {
+		c.Lidarr.PlexPathMap = parsePathMappings(value)
+	}
+	if n, ok := parseIntEnv("LIDARR_MIN_PLAYLISTS"); ok {
+		c.Lidarr.MinPlaylists = n
+	}
+	if value := os.Getenv("LIDARR_PLAYLIST_ALLOWLIST"); value != "" {
+		c.Lidarr.PlaylistAllowlist = parseCommaSeparatedList(value)
+	}
+	if n, ok := parseIntEnv("LIDARR_MAX_ADDS_PER_RUN"); ok {
+		c.Lidarr.MaxAddsPerRun = n
+	}
+	if parseBoolEnv("LIDARR_SKIP_COMPILATIONS") {
+		c.Lidarr.SkipCompilations = true
+	}
+	if value := os.Getenv("LIDARR_STATE_FILE"); value != "" {
+		c.Lidarr.StateFile = strings.TrimSpace(value)
+	}
+	if parseBoolEnv("LIDARR_UNMONITOR") {
+		c.Lidarr.Unmonitor = true
+	}
+}
+
+// parseLidarrPlaylistProfiles parses LIDARR_PLAYLIST_PROFILES: comma-separated
+// "playlistID=root:/music/lossless;quality:Lossless;metadata:Standard" entries, any field optional.
+func parseLidarrPlaylistProfiles(value string) (map[string]LidarrAddProfile, error) {
+	out := make(map[string]LidarrAddProfile)
+	for _, entry := range parseCommaSeparatedList(value) {
+		id, fields, ok := strings.Cut(entry, "=")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid LIDARR_PLAYLIST_PROFILES entry %q (want playlistID=quality:NAME;root:PATH;metadata:NAME)", entry)
+		}
+		var p LidarrAddProfile
+		for _, field := range strings.Split(fields, ";") {
+			key, val, _ := strings.Cut(field, ":")
+			val = strings.TrimSpace(val)
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "":
+				continue
+			case "root":
+				p.RootFolder = val
+			case "quality":
+				p.QualityProfile = val
+			case "metadata":
+				p.MetadataProfile = val
+			default:
+				return nil, fmt.Errorf("invalid LIDARR_PLAYLIST_PROFILES field %q for playlist %s (want root, quality or metadata)", key, id)
+			}
+		}
+		out[id] = p
+	}
+	return out, nil
 }
 
 func (c *Config) loadFromEnvFile() {
@@ -216,9 +895,7 @@ func (c *Config) loadFromEnvFile() {
 		c.Plex.Token = value
 	}
 	if value := os.Getenv("PLEX_LIBRARY_SECTION_ID"); value != "" {
-		if sectionID, err := parseLibrarySectionID(value); err == nil {
-			c.Plex.LibrarySectionID = sectionID
-		}
+		c.Plex.setLibrarySections(value)
 	}
 	if value := os.Getenv("PLEX_SERVER_ID"); value != "" {
 		c.Plex.ServerID = value
@@ -239,7 +916,24 @@ func (c *Config) loadFromEnvFile() {
 	if f, ok := parseFloatEnv("PLEX_MAX_REQUESTS_PER_SECOND"); ok {
 		c.Plex.MaxRequestsPerSecond = f
 	}
+	c.loadMatchingFromEnv()
+	c.loadPlexHomeFromEnv()
 	c.loadLidarrFromEnv()
+	c.loadWebhookFromEnv()
+	c.loadAcquireFromEnv()
+}
+
+// EnvValue returns the trimmed value of key from the OS environment, falling back to the .env file in the
+// working directory. Subcommands use it to read a single setting without validating the full sync config.
+func EnvValue(key string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	env, err := godotenv.Read()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(env[key])
 }
 
 // loadPlexTLSFromEnv applies PLEX_INSECURE_SKIP_VERIFY and PLEX_VERIFY_TLS (VERIFY wins when truthy).
@@ -303,6 +997,22 @@ func parseCommaSeparatedList(input string) []string {
 	return items
 }
 
+// setLibrarySections parses a comma-separated PLEX_LIBRARY_SECTION_ID list of section IDs or names.
+// Placeholder values from env.template are dropped.
+func (p *PlexConfig) setLibrarySections(value string) {
+	p.LibrarySectionID = 0
+	p.LibrarySections = nil
+	for _, entry := range parseNonEmptyList(value) {
+		if entry == "0" || entry == "your_music_library_section_id" {
+			continue
+		}
+		p.LibrarySections = append(p.LibrarySections, entry)
+		if id, err := parseLibrarySectionID(entry); err == nil && p.LibrarySectionID == 0 {
+			p.LibrarySectionID = id
+		}
+	}
+}
+
 func parseLibrarySectionID(value string) (int, error) {
 	if value == "0" || value == "your_music_library_section_id" {
 		return 0, nil
@@ -326,6 +1036,11 @@ func NormalizeLidarrBaseURL(raw string) (string, error) {
 	return normalizeHTTPBaseURL(raw)
 }
 
+// NormalizePlexTVURL trims space and trailing slashes and validates PLEX_TV_URL (http or https with host).
+func NormalizePlexTVURL(raw string) (string, error) {
+	return normalizeHTTPBaseURL(raw)
+}
+
 func normalizeHTTPBaseURL(raw string) (string, error) {
 	s := strings.TrimSpace(raw)
 	s = strings.TrimRight(s, "/")
@@ -371,6 +1086,53 @@ func (c *Config) validate() error {
 		c.Lidarr.URL = norm
 		c.Lidarr.Token = lidarrToken
 	}
+	if c.LidarrEnabled() && c.Lidarr.ResolveReleaseGroups {
+		mb, err := normalizeHTTPBaseURL(c.MusicBrainz.URL)
+		if err != nil {
+			return fmt.Errorf("invalid MUSICBRAINZ_URL: %w", err)
+		}
+		c.MusicBrainz.URL = mb
+	}
+	if c.MusicBrainz.MaxRequestsPerSecond < 0 {
+		c.MusicBrainz.MaxRequestsPerSecond = 0
+	}
+	if c.Lidarr.Tags && strings.TrimSpace(c.Lidarr.Tag) != "" && !isLidarrTagLabel(c.Lidarr.Tag) {
+		return fmt.Errorf("invalid LIDARR_TAG %q (use lowercase letters, digits and hyphens)", c.Lidarr.Tag)
+	}
+	for _, m := range c.Lidarr.PlexPathMap {
+		if m.From == "" || m.To == "" {
+			return fmt.Errorf("invalid LIDARR_PLEX_PATH_MAP entry %q (want lidarr path=plex path)", m.From+"="+m.To)
+		}
+	}
+	if c.Lidarr.RescanTimeoutSeconds < 0 {
+		c.Lidarr.RescanTimeoutSeconds = 0
+	}
+	if c.Lidarr.Unmonitor && strings.TrimSpace(c.Lidarr.StateFile) == "" {
+		return fmt.Errorf("LIDARR_UNMONITOR needs LIDARR_STATE_FILE: it records the albums Plexify requested")
+	}
+	if c.Webhook.DelaySeconds < 0 {
+		c.Webhook.DelaySeconds = 0
+	}
+	if u := c.Acquire.WebhookURL; u != "" {
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("invalid PLEXIFY_ACQUIRE_WEBHOOK_URL %q (want an http or https URL)", u)
+		}
+	}
+	if c.Acquire.TimeoutSeconds <= 0 {
+		c.Acquire.TimeoutSeconds = DefaultAcquireTimeoutSeconds
+	}
+	if c.Lidarr.MinPlaylists < 1 {
+		c.Lidarr.MinPlaylists = 1
+	}
+	if c.Lidarr.MaxAddsPerRun < 0 {
+		c.Lidarr.MaxAddsPerRun = 0
+	}
+	switch c.Lidarr.ArtistMonitor {
+	case "", LidarrMonitorNone, LidarrMonitorFuture, LidarrMonitorLatest, LidarrMonitorAll:
+	default:
+		return fmt.Errorf("invalid LIDARR_ARTIST_MONITOR %q (want %s, %s, %s or %s)", c.Lidarr.ArtistMonitor,
+			LidarrMonitorNone, LidarrMonitorFuture, LidarrMonitorLatest, LidarrMonitorAll)
+	}
 
 	if c.Plex.URL == "" {
 		missingFields = append(missingFields, "PLEX_URL")
@@ -378,9 +1140,7 @@ func (c *Config) validate() error {
 	if c.Plex.Token == "" {
 		missingFields = append(missingFields, "PLEX_TOKEN")
 	}
-	if c.Plex.LibrarySectionID == 0 {
-		missingFields = append(missingFields, "PLEX_LIBRARY_SECTION_ID")
-	}
+	// PLEX_LIBRARY_SECTION_ID may be empty: the server's only music library is picked at startup.
 
 	if c.MusicSocial.Username == "" && len(c.MusicSocial.PlaylistIDs) == 0 {
 		missingFields = append(missingFields, "MUSIC_SOCIAL_USERNAME or MUSIC_SOCIAL_PLAYLIST_ID")
@@ -389,15 +1149,75 @@ func (c *Config) validate() error {
 	if len(missingFields) > 0 {
 		return fmt.Errorf("missing required configuration values:\n%s\n\nSet these values via environment variables, .env file, or CLI flags", strings.Join(missingFields, "\n"))
 	}
+	for _, entry := range c.Plex.LibrarySections {
+		if id, err := strconv.Atoi(entry); err == nil && id <= 0 {
+			return fmt.Errorf("invalid PLEX_LIBRARY_SECTION_ID entry %q (want a positive section ID or a section name)", entry)
+		}
+	}
 
 	if err := validateMatchConfidencePercent(c.Plex.MatchConfidencePercent); err != nil {
 		return err
 	}
+	if err := validateVersionPreferences(c.Plex.VersionPreferences); err != nil {
+		return err
+	}
+	if err := validateQualityPreferences(c.Plex.QualityPreferences); err != nil {
+		return err
+	}
+	if err := validateSimilarityAlgorithm(c.Plex.SimilarityAlgorithm); err != nil {
+		return err
+	}
+	if err := c.validateReviewBand(); err != nil {
+		return err
+	}
+	for _, p := range c.Plex.SearchPhases {
+		if p != SearchPhaseCombined && p != SearchPhaseTitleArtist {
+			return fmt.Errorf("invalid PLEXIFY_SEARCH_PHASES entry %q (want %s and/or %s)", p, SearchPhaseCombined, SearchPhaseTitleArtist)
+		}
+	}
+	if err := validateHomeUsers(c.Plex.HomeUsers); err != nil {
+		return err
+	}
+	if len(c.Plex.HomeUsers) > 0 {
+		tv, err := NormalizePlexTVURL(c.Plex.TVURL)
+		if err != nil {
+			return fmt.Errorf("invalid PLEX_TV_URL: %w", err)
+		}
+		c.Plex.TVURL = tv
+	}
+	if c.Plex.MaxHTTPCallsPerTrack < 0 {
+		return fmt.Errorf("PLEXIFY_MAX_HTTP_CALLS_PER_TRACK must be 0 (unlimited) or more, got %d", c.Plex.MaxHTTPCallsPerTrack)
+	}
 
 	c.normalizePlexRuntime()
 	return nil
 }
 
+// validateReviewBand fills unset review thresholds from MatchConfidencePercent and checks the band settings.
+func (c *Config) validateReviewBand() error {
+	if c.Plex.AutoAcceptPercent < 0 {
+		c.Plex.AutoAcceptPercent = c.Plex.MatchConfidencePercent
+	}
+	if c.Plex.AutoRejectPercent < 0 {
+		c.Plex.AutoRejectPercent = c.Plex.MatchConfidencePercent
+	}
+	if c.Plex.AutoRejectPercent > c.Plex.AutoAcceptPercent {
+		return fmt.Errorf("PLEXIFY_AUTO_REJECT_PERCENT (%d) must not be above PLEXIFY_AUTO_ACCEPT_PERCENT (%d)",
+			c.Plex.AutoRejectPercent, c.Plex.AutoAcceptPercent)
+	}
+	switch c.Plex.ReviewDefault {
+	case "":
+		c.Plex.ReviewDefault = ReviewDefaultReject
+	case ReviewDefaultAccept, ReviewDefaultReject:
+	default:
+		return fmt.Errorf("invalid PLEXIFY_REVIEW_DEFAULT %q (want %s or %s)", c.Plex.ReviewDefault, ReviewDefaultAccept, ReviewDefaultReject)
+	}
+	if c.Plex.ReviewSaveOverrides && strings.TrimSpace(c.Plex.OverridesFile) == "" {
+		return fmt.Errorf("PLEXIFY_REVIEW_SAVE_OVERRIDES needs PLEXIFY_OVERRIDES_FILE")
+	}
+	return nil
+}
+
 func (c *Config) normalizePlexRuntime() {
 	if c.Plex.MatchConcurrency < 1 {
 		c.Plex.MatchConcurrency = 1
@@ -411,6 +1231,10 @@ func (c *Config) normalizePlexRuntime() {
 	if c.Plex.MaxRequestsPerSecond > 10000 {
 		c.Plex.MaxRequestsPerSecond = 10000
 	}
+	// Explaining a match is diagnostic; never modify playlists built from a filtered track list.
+	if c.Plex.Explain != "" {
+		c.Plex.DryRun = true
+	}
 }
 
 func (c *Config) applyOverrides(overrides map[string]string) {
@@ -433,9 +1257,7 @@ func (c *Config) applyOverrides(overrides map[string]string) {
 		case "PLEX_TOKEN":
 			c.Plex.Token = value
 		case "PLEX_LIBRARY_SECTION_ID":
-			if sectionID, err := parseLibrarySectionID(value); err == nil {
-				c.Plex.LibrarySectionID = sectionID
-			}
+			c.Plex.setLibrarySections(value)
 		case "PLEX_SERVER_ID":
 			c.Plex.ServerID = value
 		case "PLEXIFY_DRY_RUN", "DRY_RUN":
@@ -462,12 +1284,106 @@ func (c *Config) applyOverrides(overrides map[string]string) {
 			if p, err := ParseMatchConfidencePercent(value); err == nil {
 				c.Plex.MatchConfidencePercent = p
 			}
+		case "PLEXIFY_OVERRIDES_FILE":
+			c.Plex.OverridesFile = strings.TrimSpace(value)
+		case "PLEXIFY_VERSION_MISMATCH_PENALTY_PERCENT":
+			if p, err := ParseMatchConfidencePercent(value); err == nil {
+				c.Plex.VersionMismatchPenaltyPercent = p
+			}
+		case "PLEXIFY_VERSION_PREFERENCES":
+			c.Plex.VersionPreferences = parseVersionPreferences(value)
+		case "PLEXIFY_QUALITY_PREFERENCES":
+			c.Plex.QualityPreferences = parseQualityPreferences(value)
+		case "PLEXIFY_SIMILARITY_ALGORITHM":
+			c.Plex.SimilarityAlgorithm = strings.ToLower(strings.TrimSpace(value))
+		case "PLEXIFY_EXPLAIN":
+			c.Plex.Explain = strings.TrimSpace(value)
+		case "PLEXIFY_EXPLAIN_JSON":
+			c.Plex.ExplainJSONFile = strings.TrimSpace(value)
+		case "PLEXIFY_AUTO_ACCEPT_PERCENT":
+			if p, err := ParseMatchConfidencePercent(value); err == nil {
+				c.Plex.AutoAcceptPercent = p
+			}
+		case "PLEXIFY_AUTO_REJECT_PERCENT":
+			if p, err := ParseMatchConfidencePercent(value); err == nil {
+				c.Plex.AutoRejectPercent = p
+			}
+		case "PLEXIFY_REVIEW":
+			if isTruthy(value) {
+				c.Plex.Review = true
+			}
+		case "PLEXIFY_REVIEW_DEFAULT":
+			c.Plex.ReviewDefault = strings.ToLower(strings.TrimSpace(value))
+		case "PLEXIFY_REVIEW_SAVE_OVERRIDES":
+			if isTruthy(value) {
+				c.Plex.ReviewSaveOverrides = true
+			}
+		case "PLEXIFY_SKIP_ALBUM_FALLBACK":
+			if isTruthy(value) {
+				c.Plex.SkipAlbumFallback = true
+			}
+		case "PLEXIFY_SEARCH_STRATEGIES":
+			c.Plex.SearchStrategies = parseNonEmptyList(value)
+		case "PLEXIFY_SEARCH_PHASES":
+			c.Plex.SearchPhases = parseNonEmptyList(strings.ToLower(value))
+		case "PLEXIFY_MAX_HTTP_CALLS_PER_TRACK":
+			if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				c.Plex.MaxHTTPCallsPerTrack = n
+			}
+		case "PLEXIFY_NORMALIZATION_RULES":
+			c.Plex.NormalizationRulesFile = strings.TrimSpace(value)
+		case "PLEXIFY_ARTIST_ALIASES":
+			c.Plex.ArtistAliasesFiles = parseNonEmptyList(value)
+		case "PLEXIFY_HOME_USERS":
+			c.Plex.HomeUsers = parseHomeUsers(value)
 		case "LIDARR_URL":
 			c.Lidarr.URL = value
 		case "LIDARR_TOKEN":
 			c.Lidarr.Token = value
+		case "LIDARR_ROOT_FOLDER":
+			c.Lidarr.AddProfile.RootFolder = strings.TrimSpace(value)
+		case "LIDARR_QUALITY_PROFILE":
+			c.Lidarr.AddProfile.QualityProfile = strings.TrimSpace(value)
+		case "LIDARR_METADATA_PROFILE":
+			c.Lidarr.AddProfile.MetadataProfile = strings.TrimSpace(value)
+		case "LIDARR_RESOLVE_RELEASE_GROUPS":
+			c.Lidarr.ResolveReleaseGroups = isTruthy(value)
+		case "LIDARR_TAGS":
+			c.Lidarr.Tags = isTruthy(value)
+		case "LIDARR_TAG":
+			c.Lidarr.Tag = strings.TrimSpace(value)
+		case "MUSICBRAINZ_URL":
+			c.MusicBrainz.URL = value
+		case "LIDARR_ADD_ARTISTS":
+			c.Lidarr.AddArtists = isTruthy(value)
+		case "LIDARR_ARTIST_MONITOR":
+			c.Lidarr.ArtistMonitor = strings.ToLower(strings.TrimSpace(value))
 		case "LIDARR_INSECURE_SKIP_VERIFY":
 			c.Lidarr.InsecureSkipVerify = isTruthy(value)
+		case "LIDARR_RESCAN_PLEX":
+			c.Lidarr.RescanPlex = isTruthy(value)
+		case "LIDARR_PLEX_PATH_MAP":
+			c.Lidarr.PlexPathMap = parsePathMappings(value)
+		case "LIDARR_MIN_PLAYLISTS":
+			if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				c.Lidarr.MinPlaylists = n
+			}
+		case "LIDARR_PLAYLIST_ALLOWLIST":
+			c.Lidarr.PlaylistAllowlist = parseCommaSeparatedList(value)
+		case "LIDARR_MAX_ADDS_PER_RUN":
+			if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+				c.Lidarr.MaxAddsPerRun = n
+			}
+		case "LIDARR_SKIP_COMPILATIONS":
+			c.Lidarr.SkipCompilations = isTruthy(value)
+		case "LIDARR_STATE_FILE":
+			c.Lidarr.StateFile = strings.TrimSpace(value)
+		case "LIDARR_UNMONITOR":
+			c.Lidarr.Unmonitor = isTruthy(value)
+		case "PLEXIFY_ACQUIRE_WEBHOOK_URL":
+			c.Acquire.WebhookURL = strings.TrimSpace(value)
+		case "PLEXIFY_ACQUIRE_EXEC":
+			c.Acquire.Exec = strings.TrimSpace(value)
 		}
 	}
 	c.applyPlexTLSOverrides(overrides)
@@ -500,6 +1416,19 @@ func applyMatchConfidencePercentFromEnv(c *Config) error {
 	return nil
 }
 
+func applyLidarrPlaylistProfilesFromEnv(c *Config) error {
+	v := strings.TrimSpace(os.Getenv("LIDARR_PLAYLIST_PROFILES"))
+	if v == "" {
+		return nil
+	}
+	profiles, err := parseLidarrPlaylistProfiles(v)
+	if err != nil {
+		return err
+	}
+	c.Lidarr.PlaylistAddProfiles = profiles
+	return nil
+}
+
 // ParseMatchConfidencePercent parses an integer 0–100, with optional trailing "%".
 func ParseMatchConfidencePercent(raw string) (int, error) {
 	s := strings.TrimSpace(raw)
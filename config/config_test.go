@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -77,8 +78,8 @@ func TestConfigValidation(t *testing.T) {
 	cfg.Plex.Token = "test_token"
 	cfg.Plex.LibrarySectionID = 0
 	err = cfg.validate()
-	if err == nil {
-		t.Error("Expected validation error for missing LibrarySectionID")
+	if err != nil {
+		t.Errorf("Expected missing LibrarySectionID to be allowed (auto-selected at startup): %v", err)
 	}
 }
 
@@ -398,6 +399,40 @@ func TestLidarrValidationBothOrNeither(t *testing.T) {
 	}
 }
 
+func TestLidarrUnmonitorNeedsStateFile(t *testing.T) {
+	t.Setenv("LIDARR_STATE_FILE", " /config/lidarr-requested.json ")
+	cfg := &Config{
+		MusicSocial: MusicSocialConfig{BaseURL: "https://music.example.com", Username: "u"},
+		Plex:        PlexConfig{URL: "http://p:32400", Token: "t", LibrarySectionID: 1},
+		Lidarr:      LidarrConfig{URL: "http://lidarr:8686", Token: "k", ArtistMonitor: LidarrMonitorFuture, Unmonitor: true},
+	}
+	if err := cfg.validate(); err == nil {
+		t.Fatal("expected error for LIDARR_UNMONITOR without LIDARR_STATE_FILE")
+	}
+	cfg.loadLidarrFromEnv()
+	if cfg.Lidarr.StateFile != "/config/lidarr-requested.json" {
+		t.Errorf("StateFile: %q", cfg.Lidarr.StateFile)
+	}
+	if err := cfg.validate(); err != nil {
+		t.Fatalf("expected ok with a state file: %v", err)
+	}
+}
+
+func TestLidarrArtistMonitorValidation(t *testing.T) {
+	cfg := &Config{
+		MusicSocial: MusicSocialConfig{BaseURL: "https://music.example.com", Username: "u"},
+		Plex:        PlexConfig{URL: "http://p:32400", Token: "t", LibrarySectionID: 1},
+		Lidarr:      LidarrConfig{URL: "http://lidarr:8686", Token: "k", ArtistMonitor: "missing"},
+	}
+	if err := cfg.validate(); err == nil {
+		t.Fatal("expected error for unsupported LIDARR_ARTIST_MONITOR")
+	}
+	cfg.Lidarr.ArtistMonitor = LidarrMonitorLatest
+	if err := cfg.validate(); err != nil {
+		t.Fatalf("expected ok: %v", err)
+	}
+}
+
 func TestLoadLidarrInsecureFromEnv(t *testing.T) {
 	t.Setenv("LIDARR_INSECURE_SKIP_VERIFY", "true")
 	cfg := &Config{}
@@ -407,3 +442,313 @@ func TestLoadLidarrInsecureFromEnv(t *testing.T) {
 		t.Error("expected InsecureSkipVerify true")
 	}
 }
+
+func TestLoadWebhookFromEnv(t *testing.T) {
+	cfg := &Config{}
+	cfg.initializeDefaults()
+	cfg.loadWebhookFromEnv()
+	if cfg.Webhook.AllowUnauthenticated {
+		t.Error("unauthenticated webhooks must be opt-in")
+	}
+	t.Setenv("PLEXIFY_WEBHOOK_ALLOW_UNAUTHENTICATED", "true")
+	cfg.loadWebhookFromEnv()
+	if !cfg.Webhook.AllowUnauthenticated {
+		t.Error("expected AllowUnauthenticated from PLEXIFY_WEBHOOK_ALLOW_UNAUTHENTICATED")
+	}
+}
+
+func TestLoadAcquireFromEnv(t *testing.T) {
+	t.Setenv("PLEXIFY_ACQUIRE_WEBHOOK_URL", " https://hooks.example/missing ")
+	t.Setenv("PLEXIFY_ACQUIRE_EXEC", "/usr/local/bin/buy-tracks --dry")
+	t.Setenv("PLEXIFY_ACQUIRE_TIMEOUT_SECONDS", "-5")
+	cfg := &Config{}
+	cfg.initializeDefaults()
+	cfg.Plex.URL, cfg.Plex.Token, cfg.MusicSocial.Username = "http://plex:32400", "t", "u"
+	cfg.loadAcquireFromEnv()
+	if !cfg.Acquire.Enabled() || cfg.Acquire.WebhookURL != "https://hooks.example/missing" || cfg.Acquire.Exec != "/usr/local/bin/buy-tracks --dry" {
+		t.Errorf("Acquire: %+v", cfg.Acquire)
+	}
+	if err := cfg.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Acquire.TimeoutSeconds != DefaultAcquireTimeoutSeconds {
+		t.Errorf("TimeoutSeconds: %d", cfg.Acquire.TimeoutSeconds)
+	}
+	cfg.Acquire.WebhookURL = "hooks.example/missing"
+	if err := cfg.validate(); err == nil {
+		t.Error("expected error for a webhook URL without scheme")
+	}
+}
+
+func TestLoadMatchingFromEnv(t *testing.T) {
+	t.Setenv("PLEXIFY_OVERRIDES_FILE", " overrides.json ")
+	t.Setenv("PLEXIFY_VERSION_MISMATCH_PENALTY_PERCENT", "15%")
+	t.Setenv("PLEXIFY_VERSION_PREFERENCES", "Original-Album, non-live,")
+	t.Setenv("PLEXIFY_SIMILARITY_ALGORITHM", " Jaro-Winkler ")
+	t.Setenv("PLEXIFY_EXPLAIN", " Kino - Gruppa Krovi ")
+	cfg := &Config{}
+	cfg.initializeDefaults()
+	cfg.loadMatchingFromEnv()
+	if cfg.Plex.OverridesFile != "overrides.json" {
+		t.Errorf("OverridesFile: %q", cfg.Plex.OverridesFile)
+	}
+	if cfg.Plex.VersionMismatchPenaltyPercent != 15 {
+		t.Errorf("VersionMismatchPenaltyPercent: %d", cfg.Plex.VersionMismatchPenaltyPercent)
+	}
+	if want := []string{VersionPreferenceOriginalAlbum, VersionPreferenceNonLive}; !reflect.DeepEqual(cfg.Plex.VersionPreferences, want) {
+		t.Errorf("VersionPreferences: %v, want %v", cfg.Plex.VersionPreferences, want)
+	}
+	if cfg.Plex.SimilarityAlgorithm != SimilarityJaroWinkler {
+		t.Errorf("SimilarityAlgorithm: %q", cfg.Plex.SimilarityAlgorithm)
+	}
+	if cfg.Plex.Explain != "Kino - Gruppa Krovi" {
+		t.Errorf("Explain: %q", cfg.Plex.Explain)
+	}
+	cfg.normalizePlexRuntime()
+	if !cfg.Plex.DryRun {
+		t.Error("Explain should force DryRun")
+	}
+	if err := validateVersionPreferences(cfg.Plex.VersionPreferences); err != nil {
+		t.Errorf("validateVersionPreferences: %v", err)
+	}
+	if err := validateVersionPreferences([]string{"studio"}); err == nil {
+		t.Error("expected error for unknown version preference")
+	}
+	if err := validateSimilarityAlgorithm("fuzzy"); err == nil {
+		t.Error("expected error for unknown similarity algorithm")
+	}
+
+	cfg.applyOverrides(map[string]string{"PLEXIFY_OVERRIDES_FILE": "other.json", "PLEXIFY_VERSION_PREFERENCES": "none", "PLEXIFY_EXPLAIN_JSON": "trace.json"})
+	if cfg.Plex.OverridesFile != "other.json" {
+		t.Errorf("OverridesFile after override: %q", cfg.Plex.OverridesFile)
+	}
+	if cfg.Plex.ExplainJSONFile != "trace.json" {
+		t.Errorf("ExplainJSONFile after override: %q", cfg.Plex.ExplainJSONFile)
+	}
+	if cfg.Plex.VersionPreferences == nil || len(cfg.Plex.VersionPreferences) != 0 {
+		t.Errorf("VersionPreferences after none: %#v", cfg.Plex.VersionPreferences)
+	}
+}
+
+func TestValidateReviewBand(t *testing.T) {
+	t.Setenv("PLEXIFY_AUTO_ACCEPT_PERCENT", "90")
+	t.Setenv("PLEXIFY_AUTO_REJECT_PERCENT", "60%")
+	t.Setenv("PLEXIFY_REVIEW", "yes")
+	t.Setenv("PLEXIFY_REVIEW_DEFAULT", " Accept ")
+	cfg := &Config{}
+	cfg.initializeDefaults()
+	cfg.loadMatchingFromEnv()
+	if err := cfg.validateReviewBand(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Plex.AutoAcceptPercent != 90 || cfg.Plex.AutoRejectPercent != 60 || !cfg.Plex.Review || cfg.Plex.ReviewDefault != ReviewDefaultAccept {
+		t.Errorf("review settings: %+v", cfg.Plex)
+	}
+	if !cfg.Plex.ReviewBandEnabled() {
+		t.Error("expected review band to be enabled")
+	}
+
+	unset := &Config{}
+	unset.initializeDefaults()
+	unset.Plex.MatchConfidencePercent = 75
+	if err := unset.validateReviewBand(); err != nil {
+		t.Fatal(err)
+	}
+	if unset.Plex.AutoAcceptPercent != 75 || unset.Plex.AutoRejectPercent != 75 || unset.Plex.ReviewBandEnabled() {
+		t.Errorf("unset thresholds should follow match confidence: %+v", unset.Plex)
+	}
+
+	inverted := &Config{}
+	inverted.initializeDefaults()
+	inverted.applyOverrides(map[string]string{"PLEXIFY_AUTO_ACCEPT_PERCENT": "50", "PLEXIFY_AUTO_REJECT_PERCENT": "70"})
+	if err := inverted.validateReviewBand(); err == nil {
+		t.Error("expected error when reject threshold is above accept threshold")
+	}
+
+	save := &Config{}
+	save.initializeDefaults()
+	save.applyOverrides(map[string]string{"PLEXIFY_REVIEW_SAVE_OVERRIDES": "true"})
+	if err := save.validateReviewBand(); err == nil {
+		t.Error("expected error when saving review choices without an overrides file")
+	}
+
+	bad := &Config{}
+	bad.initializeDefaults()
+	bad.Plex.ReviewDefault = "maybe"
+	if err := bad.validateReviewBand(); err == nil {
+		t.Error("expected error for unknown review default")
+	}
+}
+
+func TestLoadSearchPipelineFromEnv(t *testing.T) {
+	t.Setenv("PLEXIFY_SEARCH_STRATEGIES", "exact title/artist, featuring-removed,, accent normalization")
+	t.Setenv("PLEXIFY_SEARCH_PHASES", "Title-Artist")
+	t.Setenv("PLEXIFY_MAX_HTTP_CALLS_PER_TRACK", "12")
+	cfg := &Config{}
+	cfg.initializeDefaults()
+	cfg.loadMatchingFromEnv()
+	if want := []string{"exact title/artist", "featuring-removed", "accent normalization"}; !reflect.DeepEqual(cfg.Plex.SearchStrategies, want) {
+		t.Errorf("SearchStrategies: %q, want %q", cfg.Plex.SearchStrategies, want)
+	}
+	if want := []string{SearchPhaseTitleArtist}; !reflect.DeepEqual(cfg.Plex.SearchPhases, want) {
+		t.Errorf("SearchPhases: %q, want %q", cfg.Plex.SearchPhases, want)
+	}
+	if cfg.Plex.MaxHTTPCallsPerTrack != 12 {
+		t.Errorf("MaxHTTPCallsPerTrack: %d", cfg.Plex.MaxHTTPCallsPerTrack)
+	}
+
+	cfg.applyOverrides(map[string]string{"PLEXIFY_SEARCH_PHASES": "combined,title-artist", "PLEXIFY_MAX_HTTP_CALLS_PER_TRACK": "0"})
+	if want := []string{SearchPhaseCombined, SearchPhaseTitleArtist}; !reflect.DeepEqual(cfg.Plex.SearchPhases, want) {
+		t.Errorf("SearchPhases after override: %q", cfg.Plex.SearchPhases)
+	}
+	if cfg.Plex.MaxHTTPCallsPerTrack != 0 {
+		t.Errorf("MaxHTTPCallsPerTrack after override: %d", cfg.Plex.MaxHTTPCallsPerTrack)
+	}
+}
+
+func TestValidateSearchPipeline(t *testing.T) {
+	base := func() *Config {
+		return &Config{
+			MusicSocial: MusicSocialConfig{BaseURL: "https://music.example.com", Username: "u"},
+			Plex:        PlexConfig{URL: "http://p:32400", Token: "t", LibrarySectionID: 1, MatchConfidencePercent: 80},
+		}
+	}
+	cfg := base()
+	cfg.Plex.SearchPhases = []string{"combined", "artist-only"}
+	if err := cfg.validate(); err == nil {
+		t.Error("expected error for unknown search phase")
+	}
+	cfg = base()
+	cfg.Plex.MaxHTTPCallsPerTrack = -1
+	if err := cfg.validate(); err == nil {
+		t.Error("expected error for negative HTTP call budget")
+	}
+	cfg = base()
+	cfg.Plex.SearchPhases = []string{SearchPhaseTitleArtist}
+	cfg.Plex.MaxHTTPCallsPerTrack = 20
+	if err := cfg.validate(); err != nil {
+		t.Errorf("expected ok: %v", err)
+	}
+}
+
+func TestQualityPreferences(t *testing.T) {
+	got := parseQualityPreferences(" Lossless, FOLDER:/Music/Albums ,highest-bitrate")
+	want := []string{QualityPreferenceLossless, "folder:/Music/Albums", QualityPreferenceHighestBitrate}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("parseQualityPreferences = %q, want %q", got, want)
+	}
+	if err := validateQualityPreferences(got); err != nil {
+		t.Errorf("expected ok: %v", err)
+	}
+	for _, bad := range [][]string{{"smallest"}, {QualityPreferenceFolderPrefix}} {
+		if err := validateQualityPreferences(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestLibrarySectionsList(t *testing.T) {
+	var p PlexConfig
+	p.setLibrarySections("Music (Hi-Res), 3,,Soundtracks")
+	if p.LibrarySectionID != 3 || strings.Join(p.LibrarySections, "|") != "Music (Hi-Res)|3|Soundtracks" {
+		t.Errorf("got id %d, sections %q", p.LibrarySectionID, p.LibrarySections)
+	}
+	p.setLibrarySections("your_music_library_section_id")
+	if p.LibrarySectionID != 0 || len(p.LibrarySections) != 0 {
+		t.Errorf("placeholder: got id %d, sections %q", p.LibrarySectionID, p.LibrarySections)
+	}
+
+	cfg := &Config{
+		MusicSocial: MusicSocialConfig{BaseURL: "https://music.example.com", Username: "u"},
+		Plex:        PlexConfig{URL: "http://p:32400", Token: "t", LibrarySections: []string{"Music"}, MatchConfidencePercent: 80},
+	}
+	if err := cfg.validate(); err != nil {
+		t.Errorf("section by name only: %v", err)
+	}
+	cfg.Plex.LibrarySections = []string{"Music", "-2"}
+	if err := cfg.validate(); err == nil {
+		t.Error("expected error for negative section ID")
+	}
+}
+
+func TestHomeUsers(t *testing.T) {
+	got := parseHomeUsers("kid_a=Emma:1234, pl123 = Jo: Smith ,broken")
+	want := []HomeUserMapping{
+		{Source: "kid_a", User: "Emma", PIN: "1234"},
+		{Source: "pl123", User: "Jo: Smith"},
+		{Source: "broken"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseHomeUsers = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if err := validateHomeUsers(got); err == nil {
+		t.Error("expected error for entry without a user")
+	}
+	if err := validateHomeUsers(got[:2]); err != nil {
+		t.Errorf("expected ok: %v", err)
+	}
+	if err := validateHomeUsers([]HomeUserMapping{{Source: "a", User: "x"}, {Source: "A", User: "y"}}); err == nil {
+		t.Error("expected error for duplicate source")
+	}
+
+	p := PlexConfig{HomeUsers: got[:2]}
+	if m, ok := p.HomeUserFor("PL123", "kid_a"); !ok || m.User != "Jo: Smith" {
+		t.Errorf("playlist ID should win over owner, got %+v %v", m, ok)
+	}
+	if m, ok := p.HomeUserFor("other", "KID_A"); !ok || m.User != "Emma" {
+		t.Errorf("owner lookup: got %+v %v", m, ok)
+	}
+	if _, ok := p.HomeUserFor("other", "nobody"); ok {
+		t.Error("unexpected mapping")
+	}
+}
+
+func TestLidarrPlaylistProfiles(t *testing.T) {
+	got, err := parseLidarrPlaylistProfiles("pl_jazz=root:/music/lossless;quality:Lossless, pl_pop = metadata: 3 ;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["pl_jazz"] != (LidarrAddProfile{RootFolder: "/music/lossless", QualityProfile: "Lossless"}) ||
+		got["pl_pop"] != (LidarrAddProfile{MetadataProfile: "3"}) {
+		t.Errorf("parseLidarrPlaylistProfiles = %+v", got)
+	}
+	for _, bad := range []string{"pl_jazz", "=quality:Lossless", "pl_jazz=tags:x"} {
+		if _, err := parseLidarrPlaylistProfiles(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+
+	l := LidarrConfig{AddProfile: LidarrAddProfile{RootFolder: "/music", QualityProfile: "Standard"}, PlaylistAddProfiles: got}
+	if p := l.AddProfileFor("pl_jazz"); p != (LidarrAddProfile{RootFolder: "/music/lossless", QualityProfile: "Lossless"}) {
+		t.Errorf("pl_jazz = %+v", p)
+	}
+	if p := l.AddProfileFor("pl_pop"); p != (LidarrAddProfile{RootFolder: "/music", QualityProfile: "Standard", MetadataProfile: "3"}) {
+		t.Errorf("pl_pop = %+v", p)
+	}
+	if p := l.AddProfileFor("other"); p != l.AddProfile {
+		t.Errorf("other = %+v", p)
+	}
+}
+
+func TestLidarrPlexPath(t *testing.T) {
+	l := LidarrConfig{PlexPathMap: parsePathMappings("/music=/data/music, /music/lossless/=/flac")}
+	for in, want := range map[string]string{
+		"/music/A/Album":          "/data/music/A/Album",
+		"/music/lossless/A/Album": "/flac/A/Album",
+		"/music":                  "/data/music",
+		"/musical/A":              "/musical/A",
+		"/other/A":                "/other/A",
+	} {
+		if got := l.PlexPath(in); got != want {
+			t.Errorf("PlexPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := parsePathMappings("/music"); len(got) != 1 || got[0].To != "" {
+		t.Errorf("malformed entry parsed as %+v", got)
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grrywlsn/plexify/internal/fileutil"
+	"github.com/joho/godotenv"
+)
+
+// EnvFileValue is EnvValue for the env file at path instead of .env in the working directory: the trimmed
+// OS environment value of key, else its value in that file ("" when the file is missing or lacks it).
+func EnvFileValue(path, key string) string {
+	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+		return v
+	}
+	env, err := godotenv.Read(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(env[key])
+}
+
+// UpdateEnvFile sets values in the .env-style file at path, creating it (mode 0600) when missing. Existing
+// KEY= lines are rewritten in place, so comments, ordering and other settings survive; keys not yet in the
+// file are appended in sorted order.
+func UpdateEnvFile(path string, values map[string]string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	mode := os.FileMode(0o600)
+	if fi, statErr := os.Stat(path); statErr == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	var lines []string
+	if len(raw) > 0 {
+		lines = strings.Split(strings.TrimRight(string(raw), "\n"), "\n")
+	}
+	done := make(map[string]bool, len(values))
+	for i, line := range lines {
+		key := envLineKey(line)
+		v, ok := values[key]
+		if !ok || done[key] {
+			continue
+		}
+		lines[i] = key + "=" + formatEnvValue(v)
+		done[key] = true
+	}
+	var missing []string
+	for k := range values {
+		if !done[k] {
+			missing = append(missing, k)
+		}
+	}
+	sort.Strings(missing)
+	for _, k := range missing {
+		lines = append(lines, k+"="+formatEnvValue(values[k]))
+	}
+
+	if err := fileutil.WriteFileAtomic(path, []byte(strings.Join(lines, "\n")+"\n"), mode); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// envLineKey returns the key assigned by a KEY=value (or export KEY=value) line, or "" for comments and
+// other lines.
+func envLineKey(line string) string {
+	s := strings.TrimSpace(line)
+	if s == "" || strings.HasPrefix(s, "#") {
+		return ""
+	}
+	s = strings.TrimPrefix(s, "export ")
+	k, _, ok := strings.Cut(s, "=")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(k)
+}
+
+// formatEnvValue quotes values that godotenv would otherwise split or strip.
+func formatEnvValue(v string) string {
+	if v == "" || strings.ContainsAny(v, " \t#\"'\\$\n") {
+		return strconv.Quote(v)
+	}
+	return v
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joho/godotenv"
+)
+
+func TestUpdateEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	orig := "# Plex\nPLEX_URL=http://old:32400\nPLEX_TOKEN=old\n\nMUSIC_SOCIAL_USERNAME=me\n"
+	if err := os.WriteFile(path, []byte(orig), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	err := UpdateEnvFile(path, map[string]string{
+		"PLEX_URL":       "https://10-0-0-2.abc.plex.direct:32400",
+		"PLEX_TOKEN":     "new-token",
+		"PLEX_SERVER_ID": "machine 1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(path)
+	want := "# Plex\nPLEX_URL=https://10-0-0-2.abc.plex.direct:32400\nPLEX_TOKEN=new-token\n\nMUSIC_SOCIAL_USERNAME=me\nPLEX_SERVER_ID=\"machine 1\"\n"
+	if string(raw) != want {
+		t.Errorf("file =\n%s\nwant\n%s", raw, want)
+	}
+	env, err := godotenv.Read(path)
+	if err != nil || env["PLEX_SERVER_ID"] != "machine 1" || env["MUSIC_SOCIAL_USERNAME"] != "me" {
+		t.Errorf("godotenv read %v, %v", env, err)
+	}
+	if fi, _ := os.Stat(path); fi.Mode().Perm() != 0o640 {
+		t.Errorf("mode = %v, want the original 0640", fi.Mode().Perm())
+	}
+}
+
+func TestUpdateEnvFile_creates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := UpdateEnvFile(path, map[string]string{"PLEX_TOKEN": "t"}); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("stat = %v, %v; want a 0600 file", fi, err)
+	}
+}
+
+func TestEnvFileValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plexify.env")
+	if err := os.WriteFile(path, []byte("PLEXIFY_CLIENT_IDENTIFIER= plexify-abc \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := EnvFileValue(path, "PLEXIFY_CLIENT_IDENTIFIER"); got != "plexify-abc" {
+		t.Errorf("from file = %q", got)
+	}
+	if got := EnvFileValue(filepath.Join(t.TempDir(), "missing.env"), "PLEXIFY_CLIENT_IDENTIFIER"); got != "" {
+		t.Errorf("missing file = %q", got)
+	}
+	t.Setenv("PLEXIFY_CLIENT_IDENTIFIER", "from-env")
+	if got := EnvFileValue(path, "PLEXIFY_CLIENT_IDENTIFIER"); got != "from-env" {
+		t.Errorf("environment should win, got %q", got)
+	}
+}
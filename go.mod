@@ -3,12 +3,8 @@ module github.com/grrywlsn/plexify
 go 1.25.0
 
 require (
-	github.com/LukeHagar/plexgo v0.24.2
 	github.com/joho/godotenv v1.5.1
 	golang.org/x/term v0.41.0
 )
 
-require (
-	github.com/ericlagergren/decimal v0.0.0-20221120152707-495c53812d05 // indirect
-	golang.org/x/sys v0.42.0 // indirect
-)
+require golang.org/x/sys v0.42.0 // indirect
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/grrywlsn/plexify/acquire"
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/plex"
+)
+
+// newAcquirers builds the PLEXIFY_ACQUIRE_* providers, in the order they are called.
+func newAcquirers(cfg config.AcquireConfig) ([]acquire.Acquirer, error) {
+	timeout := time.Duration(cfg.TimeoutSeconds) * time.Second
+	var out []acquire.Acquirer
+	if cfg.WebhookURL != "" {
+		w, err := acquire.NewWebhook(cfg.WebhookURL, cfg.WebhookToken, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("PLEXIFY_ACQUIRE_WEBHOOK_URL: %w", err)
+		}
+		out = append(out, w)
+	}
+	if cfg.Exec != "" {
+		e, err := acquire.NewExec(cfg.Exec, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("PLEXIFY_ACQUIRE_EXEC: %w", err)
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// missingTracks collects the run's missing tracks for the acquisition providers, once per track, with
+// every playlist missing it.
+type missingTracks struct {
+	tracks []acquire.Track
+	byKey  map[string]int
+}
+
+// queueMissingTracks records a playlist's missing tracks for acquireMissing.
+func (app *Application) queueMissingTracks(meta PlaylistMeta, results []plex.MatchResult) {
+	if len(app.acquirers) == 0 {
+		return
+	}
+	m := &app.missing
+	if m.byKey == nil {
+		m.byKey = make(map[string]int)
+	}
+	pl := acquire.Playlist{ID: meta.ID, Name: meta.Name}
+	for _, r := range results {
+		if r.PlexTrack != nil || r.MatchType == plex.MatchTypeSkipped {
+			continue
+		}
+		t := acquire.NewTrack(r.SourceTrack)
+		key := t.Key()
+		i, ok := m.byKey[key]
+		if !ok {
+			i = len(m.tracks)
+			m.byKey[key] = i
+			m.tracks = append(m.tracks, t)
+		}
+		if ps := m.tracks[i].Playlists; len(ps) == 0 || ps[len(ps)-1].ID != meta.ID {
+			m.tracks[i].Playlists = append(ps, pl)
+		}
+	}
+}
+
+// acquireMissing sends the run's missing tracks to each provider, Lidarr first. A failing provider does not
+// stop the others or the run. Partial runs (webhook re-syncs) skip it: the full run already sent what the
+// re-synced playlists miss, and LIDARR_MIN_PLAYLISTS counts need every playlist.
+func (app *Application) acquireMissing(ctx context.Context) {
+	if len(app.acquirers) == 0 || len(app.missing.tracks) == 0 || app.partialRun {
+		return
+	}
+	payload := acquire.Payload{Version: acquire.PayloadVersion, Tracks: app.missing.tracks}
+	separated := false
+	printf := func(format string, args ...any) {
+		if !separated {
+			fmt.Println()
+			separated = true
+		}
+		fmt.Printf(format, args...)
+	}
+	for _, a := range app.acquirers {
+		self, isSelf := a.(acquire.SelfReporting)
+		switch {
+		case app.config.Plex.DryRun && isSelf:
+			self.Preview(ctx, payload)
+		case app.config.Plex.DryRun:
+			printf("Acquire (dry-run): would send %d missing track(s) to %s\n", len(payload.Tracks), a.Name())
+		default:
+			if err := a.Acquire(ctx, payload); err != nil {
+				slog.Error("acquire: provider failed", "provider", a.Name(), "err", err)
+				printf("❌ Acquire: %s failed: %v\n", a.Name(), err)
+			} else if !isSelf {
+				printf("✅ Acquire: sent %d missing track(s) to %s\n", len(payload.Tracks), a.Name())
+			}
+		}
+	}
+}
@@ -2,15 +2,22 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/grrywlsn/plexify/acquire"
+	"github.com/grrywlsn/plexify/aliases"
 	"github.com/grrywlsn/plexify/config"
 	"github.com/grrywlsn/plexify/internal/cliutil"
 	"github.com/grrywlsn/plexify/lidarr"
+	"github.com/grrywlsn/plexify/musicbrainz"
 	"github.com/grrywlsn/plexify/musicsocial"
+	"github.com/grrywlsn/plexify/normrules"
+	"github.com/grrywlsn/plexify/overrides"
 	"github.com/grrywlsn/plexify/plex"
 	"github.com/grrywlsn/plexify/track"
 )
@@ -27,6 +34,7 @@ type PlaylistMeta struct {
 	Description string
 	ArtworkURL  string // Unused for source API (no cover URL); kept for Plex poster hook
 	PageURL     string // Canonical source playlist URL (e.g. on music-social.com) for Plex description attribution
+	Owner       string // Source username owning the playlist, matched against PLEXIFY_HOME_USERS
 }
 
 // Application holds clients and config for a single run (no persisted state between invocations).
@@ -36,6 +44,17 @@ type Application struct {
 	musicSocial *musicsocial.Client
 	plexClient  *plex.Client
 	lidarr      *lidarr.Client
+	homeUsers   *homeUsers          // nil unless PLEXIFY_HOME_USERS is set
+	lidarrQueue lidarrQueue         // missing albums and artists collected for Lidarr across the run
+	partialRun  bool                // only some playlists are synced (webhook re-sync); skips acquisition and LIDARR_UNMONITOR
+	acquirers   []acquire.Acquirer  // Lidarr and the PLEXIFY_ACQUIRE_* providers; empty when none is configured
+	missing     missingTracks       // missing tracks collected for acquirers across the run
+	musicBrainz *musicbrainz.Client // nil unless Lidarr is enabled with LIDARR_RESOLVE_RELEASE_GROUPS
+	// prematched holds results the webhook re-sync already matched while picking playlists, by source
+	// track id, so processPlaylist does not search Plex for those tracks again.
+	prematched map[string]plex.MatchResult
+
+	explained []explainedTrack // traced results for PLEXIFY_EXPLAIN_JSON
 }
 
 // NewApplication creates a new application instance. debug enables verbose Plex search logging.
@@ -50,30 +69,101 @@ func NewApplication(cfg *config.Config, debug bool) (*Application, error) {
 	if cfg.Plex.ExactMatchesOnly {
 		slog.Info("Plex track matching: exact-matches-only (raw title/artist strategy; no title normalizations or full-library scan)")
 	}
+	if alg := cfg.Plex.SimilarityAlgorithm; alg != "" && alg != config.SimilarityHeuristic {
+		slog.Info("Plex track matching: similarity algorithm", "algorithm", alg)
+	}
+	// Traces also supply the alternative candidates listed by the interactive review.
+	if cfg.Plex.Explain != "" || cfg.Plex.ExplainJSONFile != "" || (cfg.Plex.Review && cfg.Plex.ReviewBandEnabled()) {
+		plexClient.SetTraceMatches(true)
+	}
+	if err := plexClient.SetSearchStrategies(cfg.Plex.SearchStrategies); err != nil {
+		return nil, fmt.Errorf("PLEXIFY_SEARCH_STRATEGIES: %w", err)
+	}
+	if len(cfg.Plex.SearchStrategies) > 0 || len(cfg.Plex.SearchPhases) > 0 || cfg.Plex.MaxHTTPCallsPerTrack > 0 {
+		slog.Info("Plex track matching: custom search pipeline", "strategies", cfg.Plex.SearchStrategies,
+			"phases", cfg.Plex.SearchPhases, "max_http_calls_per_track", cfg.Plex.MaxHTTPCallsPerTrack)
+	}
+	if cfg.Plex.ReviewBandEnabled() {
+		slog.Info("Plex track matching: review band", "auto_accept_percent", cfg.Plex.AutoAcceptPercent,
+			"auto_reject_percent", cfg.Plex.AutoRejectPercent, "interactive", cfg.Plex.Review, "default", cfg.Plex.ReviewDefault)
+	}
+	if path := cfg.Plex.OverridesFile; path != "" {
+		set, err := overrides.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("load overrides: %w", err)
+		}
+		plexClient.SetOverrides(set)
+		slog.Info("Plex track matching: manual overrides loaded", "file", path, "entries", set.Len())
+	}
+	if path := cfg.Plex.NormalizationRulesFile; path != "" {
+		set, err := normrules.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("load normalization rules: %w", err)
+		}
+		plexClient.SetNormalizationRules(set)
+		slog.Info("Plex track matching: normalization rules loaded", "file", path, "rules", set.Len())
+	}
+	if paths := cfg.Plex.ArtistAliasesFiles; len(paths) > 0 {
+		set, err := aliases.Load(paths...)
+		if err != nil {
+			return nil, fmt.Errorf("load artist aliases: %w", err)
+		}
+		plexClient.SetArtistAliases(set)
+		slog.Info("Plex track matching: artist aliases loaded", "files", paths, "groups", set.Len())
+	}
 
 	var lclient *lidarr.Client
+	var mbClient *musicbrainz.Client
 	if cfg.LidarrEnabled() {
 		c, err := lidarr.NewClient(&cfg.Lidarr)
 		if err != nil {
 			return nil, fmt.Errorf("lidarr client: %w", err)
 		}
 		lclient = c
+		if cfg.Lidarr.ResolveReleaseGroups {
+			mb, err := musicbrainz.NewClient(cfg.MusicBrainz.URL, cfg.MusicBrainz.MaxRequestsPerSecond)
+			if err != nil {
+				return nil, fmt.Errorf("musicbrainz client: %w", err)
+			}
+			mbClient = mb
+		}
 	}
 
-	return &Application{
+	acquirers, err := newAcquirers(cfg.Acquire)
+	if err != nil {
+		return nil, err
+	}
+
+	var home *homeUsers
+	if len(cfg.Plex.HomeUsers) > 0 {
+		h, err := newHomeUsers(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("plex.tv client: %w", err)
+		}
+		home = h
+		slog.Info("Plex playlists: syncing into Plex Home users", "mappings", len(cfg.Plex.HomeUsers))
+	}
+
+	app := &Application{
 		config:      cfg,
 		debug:       debug,
 		musicSocial: ms,
 		plexClient:  plexClient,
 		lidarr:      lclient,
-	}, nil
+		homeUsers:   home,
+		musicBrainz: mbClient,
+		acquirers:   acquirers,
+	}
+	if lclient != nil {
+		app.acquirers = append([]acquire.Acquirer{lidarrAcquirer{app}}, acquirers...)
+	}
+	return app, nil
 }
 
 // Run executes the main application logic
 func (app *Application) Run(ctx context.Context) error {
-	if err := app.discoverServerID(ctx); err != nil {
-		slog.Warn("failed to auto-discover Plex server ID", "err", err)
-		slog.Warn("using configured server ID; set PLEX_SERVER_ID if sync fails")
+	if err := app.prepare(ctx); err != nil {
+		return err
 	}
 
 	playlistMetas, err := app.getPlaylistMetadata()
@@ -88,6 +178,18 @@ func (app *Application) Run(ctx context.Context) error {
 	return app.processPlaylists(ctx, playlistMetas)
 }
 
+// prepare checks the Plex server, library sections and Lidarr add settings before a sync.
+func (app *Application) prepare(ctx context.Context) error {
+	if err := app.discoverServerID(ctx); err != nil {
+		slog.Warn("failed to auto-discover Plex server ID", "err", err)
+		slog.Warn("using configured server ID; set PLEX_SERVER_ID if sync fails")
+	}
+	if err := app.resolveLibrarySections(ctx); err != nil {
+		return err
+	}
+	return app.resolveLidarrAddSettings(ctx)
+}
+
 func (app *Application) discoverServerID(ctx context.Context) error {
 	if app.config.Plex.ServerID != "" {
 		return nil
@@ -105,6 +207,54 @@ func (app *Application) discoverServerID(ctx context.Context) error {
 	return nil
 }
 
+// resolveLibrarySections checks PLEX_LIBRARY_SECTION_ID against the server's library sections (or picks the
+// only music library when it is unset) so a wrong section fails the run instead of matching nothing.
+func (app *Application) resolveLibrarySections(ctx context.Context) error {
+	if err := app.plexClient.ResolveLibrarySections(ctx); err != nil {
+		return fmt.Errorf("PLEX_LIBRARY_SECTION_ID: %w", err)
+	}
+	sections := app.plexClient.LibrarySections()
+	labels := make([]string, len(sections))
+	for i, sec := range sections {
+		labels[i] = fmt.Sprintf("%s (%d)", sec.Label(), sec.ID)
+	}
+	if len(sections) > 1 {
+		fmt.Printf("📚 Searching %d Plex music libraries in order: %s\n", len(sections), strings.Join(labels, ", "))
+	} else {
+		fmt.Printf("📚 Plex music library: %s\n", labels[0])
+	}
+	return nil
+}
+
+// resolveLidarrAddSettings checks the configured Lidarr root folder and profiles, globally and for each
+// LIDARR_PLAYLIST_PROFILES entry, so a misspelt name fails the run before any matching. An unreachable
+// Lidarr only warns: adding missing albums is best-effort and retries the lookup later.
+func (app *Application) resolveLidarrAddSettings(ctx context.Context) error {
+	if app.lidarr == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(app.config.Lidarr.PlaylistAddProfiles))
+	for id := range app.config.Lidarr.PlaylistAddProfiles {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range append([]string{""}, ids...) {
+		s, err := app.lidarr.ResolveAddSettings(ctx, app.config.Lidarr.AddProfileFor(id))
+		if errors.Is(err, lidarr.ErrAddSettingNotFound) {
+			if id != "" {
+				return fmt.Errorf("LIDARR_PLAYLIST_PROFILES %s: %w", id, err)
+			}
+			return fmt.Errorf("Lidarr: %w", err)
+		}
+		if err != nil {
+			slog.Warn("lidarr: could not check root folder and profiles", "err", err)
+			return nil
+		}
+		slog.Info("lidarr: settings for added artists", "playlist", id, "settings", s.String())
+	}
+	return nil
+}
+
 func playlistPageURL(app *Application, summaryURL, playlistID string) string {
 	if summaryURL != "" {
 		return summaryURL
@@ -131,6 +281,7 @@ func (app *Application) getPlaylistMetadata() ([]PlaylistMeta, error) {
 					Name:        pl.Title,
 					Description: pl.Description,
 					PageURL:     playlistPageURL(app, pl.URL, pl.ID),
+					Owner:       app.config.MusicSocial.Username,
 				})
 			}
 		}
@@ -156,6 +307,7 @@ func (app *Application) getPlaylistMetadata() ([]PlaylistMeta, error) {
 				Name:        pl.Title,
 				Description: pl.Description,
 				PageURL:     playlistPageURL(app, "", playlistID),
+				Owner:       pl.Owner,
 			})
 			addedCount++
 		}
@@ -197,6 +349,7 @@ func (app *Application) processPlaylists(ctx context.Context, playlistMetas []Pl
 	for playlistIndex, meta := range playlistMetas {
 		if err := app.processPlaylist(ctx, meta, playlistIndex+1, len(playlistMetas)); err != nil {
 			slog.Info(fmt.Sprintf("failed to process playlist %s: %v", meta.ID, err))
+			app.lidarrQueue.failed++
 			continue
 		}
 
@@ -205,12 +358,20 @@ func (app *Application) processPlaylists(ctx context.Context, playlistMetas []Pl
 			fmt.Println()
 		}
 	}
+	app.acquireMissing(ctx)
+	app.unmonitorUnwantedAlbums(ctx)
+
+	if err := app.writeExplainJSON(); err != nil {
+		slog.Error("failed to write match traces", "err", err)
+	}
+	app.displaySearchStats()
 
 	fmt.Println("\n🎉 All playlists processed!")
 	return nil
 }
 
 func (app *Application) processPlaylist(ctx context.Context, meta PlaylistMeta, index, total int) error {
+	ctx = app.lidarrContext(ctx, meta)
 	fmt.Printf("📋 Playlist %d/%d: %s\n", index, total, meta.ID)
 	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
 
@@ -225,24 +386,74 @@ func (app *Application) processPlaylist(ctx context.Context, meta PlaylistMeta,
 	}
 
 	songs := pl.Tracks
+	if sel := app.config.Plex.Explain; sel != "" {
+		songs = filterExplainTracks(sel, songs)
+		if len(songs) == 0 {
+			fmt.Printf("No tracks in this playlist match explain selector %q\n", sel)
+			return nil
+		}
+	}
 	app.displaySongs(songs)
 
+	if app.config.Plex.Explain != "" {
+		// Only matching is explained; the playlist diff of a filtered track list would be meaningless.
+		matchResults := app.plexClient.MatchSourceTracks(ctx, songs)
+		app.recordExplained(meta, matchResults)
+		for _, r := range matchResults {
+			plex.FprintMatchTrace(os.Stdout, r)
+		}
+		return nil
+	}
+
+	// Switch accounts before matching so an unknown Home user fails fast; matching itself keeps PLEX_TOKEN.
+	syncCtx, err := app.playlistUserContext(ctx, meta)
+	if err != nil {
+		return err
+	}
+
 	if app.debug {
 		fmt.Println("\n" + cliutil.RepeatChar("=", cliutil.SectionWidth))
 		fmt.Println("MATCHING SONGS TO PLEX LIBRARY")
 		fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
 	}
 
-	matchResults, playlist, diffView, err := app.plexClient.MatchPlaylist(ctx, songs, meta.Name, meta.Description, meta.PageURL, meta.ArtworkURL)
+	matchResults := app.matchSourceTracks(ctx, songs)
+	app.rescanImportedAlbums(ctx, matchResults)
+	app.reviewMatches(ctx, matchResults)
+	app.fillMissingReleaseGroups(ctx, matchResults)
+
+	playlist, diffView, err := app.plexClient.SyncMatchedPlaylist(syncCtx, matchResults, meta.Name, meta.Description, meta.PageURL, meta.ArtworkURL)
 	if err != nil {
-		return fmt.Errorf("failed to match songs to Plex: %w", err)
+		return fmt.Errorf("failed to sync Plex playlist: %w", err)
 	}
 
+	app.recordExplained(meta, matchResults)
 	app.displayMatchingResults(ctx, matchResults, songs, playlist, diffView)
+	app.queueLidarrRequests(meta, matchResults)
+	app.queueMissingTracks(meta, matchResults)
 
 	return nil
 }
 
+// displaySearchStats prints how often each search strategy ran and found the match, to help tune
+// PLEXIFY_SEARCH_STRATEGIES and PLEXIFY_SEARCH_PHASES.
+func (app *Application) displaySearchStats() {
+	stats := app.plexClient.SearchStats()
+	if len(stats.Strategies) == 0 && stats.BudgetExhausted == 0 {
+		return
+	}
+	fmt.Println("\n" + cliutil.RepeatChar("=", cliutil.SectionWidth))
+	fmt.Println("SEARCH STRATEGY STATS")
+	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
+	fmt.Printf("%-32s %-24s %6s %6s %8s\n", "Strategy", "Phase", "Runs", "Hits", "Hit rate")
+	for _, st := range stats.Strategies {
+		fmt.Printf("%-32s %-24s %6d %6d %7.1f%%\n", st.Strategy, st.Phase, st.Runs, st.Hits, float64(st.Hits)/float64(st.Runs)*100)
+	}
+	if stats.BudgetExhausted > 0 {
+		fmt.Printf("⏹️  %d search(es) stopped at PLEXIFY_MAX_HTTP_CALLS_PER_TRACK=%d\n", stats.BudgetExhausted, app.config.Plex.MaxHTTPCallsPerTrack)
+	}
+}
+
 func (app *Application) displaySongs(songs []track.Track) {
 	fmt.Printf("Songs in playlist (%d total):\n", len(songs))
 	fmt.Println(strings.Repeat("-", 60))
@@ -255,63 +466,139 @@ func (app *Application) displaySongs(songs []track.Track) {
 	fmt.Printf("Successfully fetched %d songs from source playlist\n", len(songs))
 }
 
+// matchCounts tallies match results by outcome for the SUMMARY section.
+type matchCounts struct {
+	titleMatches int
+	overridden   int
+	skipped      int
+	noMatches    int
+}
+
+func (m matchCounts) matched() int {
+	return m.titleMatches + m.overridden
+}
+
 func (app *Application) displayMatchingResults(ctx context.Context, matchResults []plex.MatchResult, songs []track.Track, playlist *plex.PlexPlaylist, diffView plex.PlaylistDiffView) {
-	var titleMatches, noMatches int
-	var missingTracks []plex.MatchResult
+	var counts matchCounts
+	var missingTracks, overriddenTracks []plex.MatchResult
 
 	for _, result := range matchResults {
-		if result.PlexTrack != nil {
-			switch result.MatchType {
-			case plex.MatchTypeTitleArtist:
-				titleMatches++
-			}
-		} else {
-			noMatches++
+		switch {
+		case result.MatchType == plex.MatchTypeSkipped:
+			counts.skipped++
+			overriddenTracks = append(overriddenTracks, result)
+		case result.PlexTrack == nil:
+			counts.noMatches++
 			missingTracks = append(missingTracks, result)
+		case result.MatchType == plex.MatchTypeOverride:
+			counts.overridden++
+			overriddenTracks = append(overriddenTracks, result)
+		case result.MatchType == plex.MatchTypeTitleArtist:
+			counts.titleMatches++
 		}
 	}
 
+	multiSection := len(app.plexClient.LibrarySections()) > 1
 	if app.debug {
 		fmt.Println("\n" + cliutil.RepeatChar("=", cliutil.SectionWidth))
 		fmt.Println("MATCHING RESULTS")
 		fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
 
 		for i, result := range matchResults {
-			status := "❌ No match"
-			if result.PlexTrack != nil {
-				switch result.MatchType {
-				case plex.MatchTypeTitleArtist:
-					status = "🔍 Title/Artist match"
-				}
-			}
-
-			fmt.Printf("%3d. %s - %s: %s", i+1, result.SourceTrack.Artist, result.SourceTrack.Name, status)
+			fmt.Printf("%3d. %s - %s: %s", i+1, result.SourceTrack.Artist, result.SourceTrack.Name, matchStatusLabel(result))
 			if result.PlexTrack != nil {
 				fmt.Printf(" (Plex: %s - %s)", result.PlexTrack.DisplayArtist(), result.PlexTrack.Title)
+				if multiSection {
+					if label := result.PlexTrack.SectionLabel(); label != "" {
+						fmt.Printf(" [%s]", label)
+					}
+				}
 			}
 			fmt.Println()
 		}
 	}
 
-	app.displaySummary(songs, titleMatches, noMatches, playlist, diffView)
+	app.displaySummary(songs, counts, overriddenTracks, playlist, diffView)
+	if multiSection {
+		displaySectionCounts(matchResults)
+	}
 
 	if len(missingTracks) > 0 {
 		app.displayMissingTracksSummary(ctx, missingTracks)
 	}
 }
 
-func (app *Application) displaySummary(songs []track.Track, titleMatches, noMatches int, playlist *plex.PlexPlaylist, diffView plex.PlaylistDiffView) {
+// displaySectionCounts prints how many matches came from each library section, in first-seen order.
+func displaySectionCounts(matchResults []plex.MatchResult) {
+	var order []string
+	counts := make(map[string]int)
+	for _, r := range matchResults {
+		if r.PlexTrack == nil {
+			continue
+		}
+		label := r.PlexTrack.SectionLabel()
+		if label == "" {
+			label = "(unknown section)"
+		}
+		if counts[label] == 0 {
+			order = append(order, label)
+		}
+		counts[label]++
+	}
+	if len(order) == 0 {
+		return
+	}
+	fmt.Println("\nMatches by library section:")
+	for _, label := range order {
+		fmt.Printf("  📚 %s: %d\n", label, counts[label])
+	}
+}
+
+func matchStatusLabel(result plex.MatchResult) string {
+	switch {
+	case result.MatchType == plex.MatchTypeSkipped:
+		return "⏭️  Skipped (override)"
+	case result.PlexTrack == nil:
+		return "❌ No match"
+	case result.MatchType == plex.MatchTypeOverride:
+		return "📌 Override"
+	case result.MatchType == plex.MatchTypeTitleArtist:
+		return "🔍 Title/Artist match"
+	case result.MatchType == plex.MatchTypeReview:
+		return "🧐 Held for review"
+	default:
+		return "❌ No match"
+	}
+}
+
+func (app *Application) displaySummary(songs []track.Track, counts matchCounts, overriddenTracks []plex.MatchResult, playlist *plex.PlexPlaylist, diffView plex.PlaylistDiffView) {
 	fmt.Println("\n" + cliutil.RepeatChar("=", cliutil.SectionWidth))
 	fmt.Println("SUMMARY")
 	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
 	fmt.Printf("Total songs: %d\n", len(songs))
 	if len(songs) > 0 {
-		fmt.Printf("Title/Artist matches: %d (%.1f%%)\n", titleMatches, float64(titleMatches)/float64(len(songs))*100)
-		fmt.Printf("No matches: %d (%.1f%%)\n", noMatches, float64(noMatches)/float64(len(songs))*100)
+		fmt.Printf("Title/Artist matches: %d (%.1f%%)\n", counts.titleMatches, float64(counts.titleMatches)/float64(len(songs))*100)
+		if counts.overridden > 0 || counts.skipped > 0 {
+			fmt.Printf("Overridden: %d (%.1f%%)\n", counts.overridden, float64(counts.overridden)/float64(len(songs))*100)
+			fmt.Printf("Skipped by override: %d (%.1f%%)\n", counts.skipped, float64(counts.skipped)/float64(len(songs))*100)
+		}
+		fmt.Printf("No matches: %d (%.1f%%)\n", counts.noMatches, float64(counts.noMatches)/float64(len(songs))*100)
 	}
 
-	if titleMatches > 0 {
-		fmt.Printf("\n✅ Found %d matched tracks in Plex library\n", titleMatches)
+	if len(overriddenTracks) > 0 {
+		fmt.Println("\nOverridden tracks:")
+		for _, result := range overriddenTracks {
+			st := result.SourceTrack
+			if result.PlexTrack != nil {
+				fmt.Printf("  📌 %s - %s → %s - %s (ratingKey %s)\n", st.Artist, st.Name, result.PlexTrack.DisplayArtist(), result.PlexTrack.Title, result.PlexTrack.ID)
+			} else {
+				fmt.Printf("  ⏭️  %s - %s (skipped)\n", st.Artist, st.Name)
+			}
+		}
+	}
+
+	if matched := counts.matched(); matched > 0 {
+		fmt.Printf("\n✅ Found %d matched tracks in Plex library\n", matched)
 		if app.config.Plex.DryRun {
 			fmt.Println("ℹ️  Dry-run: playlist on Plex was not modified.")
 		} else if playlist != nil {
@@ -327,6 +614,8 @@ func (app *Application) displaySummary(songs []track.Track, titleMatches, noMatc
 }
 
 func (app *Application) displayMissingTracksSummary(ctx context.Context, missingTracks []plex.MatchResult) {
+	statuses := app.lidarrStatuses(ctx, missingTracks)
+
 	fmt.Println("\n" + cliutil.RepeatChar("=", cliutil.SectionWidth))
 	fmt.Println("MISSING TRACKS SUMMARY")
 	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
@@ -348,70 +637,42 @@ func (app *Application) displayMissingTracksSummary(ctx context.Context, missing
 		} else if h := track.HarmonyAddToMusicBrainzURL(st.SpotifyAlbumURI, st.AppleMusicAlbumID); h != "" {
 			fmt.Printf("     Add to MusicBrainz: %s\n", h)
 		}
+		if s, ok := statuses[strings.TrimSpace(st.MusicBrainzReleaseGroupID)]; ok {
+			fmt.Printf("     Lidarr: %s\n", s.Describe())
+		}
 		if i < len(missingTracks)-1 {
 			fmt.Println()
 		}
 	}
-
-	app.addMissingReleaseGroupsToLidarr(ctx, missingTracks)
 }
 
-func (app *Application) addMissingReleaseGroupsToLidarr(ctx context.Context, missing []plex.MatchResult) {
-	if app.lidarr == nil || !app.config.LidarrEnabled() {
-		return
-	}
+type missingArtist struct {
+	id   string // MusicBrainz artist id
+	name string // source artist name, for output
+}
 
+// missingArtists returns the primary credited artist of each missing track that has no release group id,
+// deduplicated. Featured artists are skipped so a guest spot does not add a whole discography.
+func missingArtists(missing []plex.MatchResult) []missingArtist {
 	seen := make(map[string]struct{})
-	var groupIDs []string
+	var out []missingArtist
 	for _, m := range missing {
-		rg := strings.TrimSpace(m.SourceTrack.MusicBrainzReleaseGroupID)
-		if rg == "" {
+		st := m.SourceTrack
+		primary, ok := st.PrimaryMusicBrainzArtist()
+		if strings.TrimSpace(st.MusicBrainzReleaseGroupID) != "" || !ok {
 			continue
 		}
-		if _, ok := seen[rg]; ok {
+		if _, ok := seen[primary.ID]; ok {
 			continue
 		}
-		seen[rg] = struct{}{}
-		groupIDs = append(groupIDs, rg)
-	}
-	if len(groupIDs) == 0 {
-		return
-	}
-
-	if app.config.Plex.DryRun {
-		fmt.Println()
-		fmt.Println("Lidarr (dry-run): would request add for these MusicBrainz release group id(s):")
-		for _, id := range groupIDs {
-			fmt.Printf("  - %s\n", id)
-		}
-		return
-	}
-
-	fmt.Println()
-	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
-	fmt.Println("LIDARR: ADD MISSING RELEASE GROUPS")
-	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
-	for _, id := range groupIDs {
-		res, err := app.lidarr.AddReleaseGroupIfMissing(ctx, id)
-		if err != nil {
-			slog.Error("lidarr: add release group", "release_group", id, "err", err)
-			if we, ok := lidarr.AsWriteError(err); ok {
-				fmt.Printf("❌ %s\n", we.UserMessage())
-			} else {
-				fmt.Printf("❌ Lidarr: could not add release group %s: %v\n", id, err)
-			}
-			continue
-		}
-		if res.AlreadyPresent {
-			if res.EnsuredMonitored {
-				fmt.Printf("ℹ️  Lidarr: release group %s already in library; ensured album, releases, and artist are monitored\n", id)
-			} else {
-				fmt.Printf("ℹ️  Lidarr: release group %s already in library\n", id)
-			}
-		} else if res.Added {
-			fmt.Printf("✅ Lidarr: added release group %s (search for release started)\n", id)
+		seen[primary.ID] = struct{}{}
+		name := primary.Name
+		if name == "" {
+			name = st.Artist
 		}
+		out = append(out, missingArtist{id: primary.ID, name: name})
 	}
+	return out
 }
 
 // PrintNoPlaylistsMessage writes the standard help when no playlists are configured.
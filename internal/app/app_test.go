@@ -1,10 +1,24 @@
 package app
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/grrywlsn/plexify/acquire"
 	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/lidarr"
+	"github.com/grrywlsn/plexify/musicbrainz"
+	"github.com/grrywlsn/plexify/overrides"
 	"github.com/grrywlsn/plexify/plex"
 	"github.com/grrywlsn/plexify/track"
 )
@@ -210,3 +224,349 @@ func TestFilterExcludedPlaylists_EmptyInput(t *testing.T) {
 		t.Errorf("Expected 0 playlists, got %d", len(result))
 	}
 }
+
+func TestExplainSelectorMatches(t *testing.T) {
+	song := track.Track{ID: "pl1:3", Name: "Gruppa Krovi", Artist: "Kino", ISRC: "RUA1D8800001"}
+	for _, sel := range []string{"Kino - Gruppa Krovi", "kino – gruppa krovi", "krovi", "pl1:3", "rua1d8800001"} {
+		if !explainSelectorMatches(sel, song) {
+			t.Errorf("selector %q should match", sel)
+		}
+	}
+	for _, sel := range []string{"", "Other - Gruppa Krovi", "zvezda"} {
+		if explainSelectorMatches(sel, song) {
+			t.Errorf("selector %q should not match", sel)
+		}
+	}
+}
+
+func TestClassifyReviewBand(t *testing.T) {
+	p := config.PlexConfig{AutoAcceptPercent: 90, AutoRejectPercent: 60}
+	match := func(kind plex.MatchKind, score float64) plex.MatchResult {
+		// Confidence deliberately disagrees with Score: the band follows the search score.
+		return plex.MatchResult{PlexTrack: &plex.PlexTrack{ID: "1"}, MatchType: kind, Confidence: 1 - score, Score: score}
+	}
+	tests := []struct {
+		r    plex.MatchResult
+		want reviewBand
+	}{
+		{match(plex.MatchTypeTitleArtist, 0.95), bandAccept},
+		{match(plex.MatchTypeTitleArtist, 0.90), bandAccept},
+		{match(plex.MatchTypeTitleArtist, 0.75), bandReview},
+		{match(plex.MatchTypeTitleArtist, 0.60), bandReview},
+		{match(plex.MatchTypeTitleArtist, 0.55), bandReject},
+		{match(plex.MatchTypeReview, 0.95), bandReview},
+		{match(plex.MatchTypeReview, 0.65), bandReview},
+		{match(plex.MatchTypeReview, 0.55), bandReject},
+		{match(plex.MatchTypeOverride, 0.10), bandAccept},
+		{plex.MatchResult{MatchType: plex.MatchTypeNone}, bandAccept},
+	}
+	for _, tt := range tests {
+		if got := classifyReviewBand(p, tt.r); got != tt.want {
+			t.Errorf("classifyReviewBand(%s, %.2f) = %d, want %d", tt.r.MatchType, tt.r.Score, got, tt.want)
+		}
+	}
+	if got := classifyReviewBand(config.PlexConfig{AutoAcceptPercent: 80, AutoRejectPercent: 80}, match(plex.MatchTypeTitleArtist, 0.5)); got != bandAccept {
+		t.Errorf("without a band every match is kept, got %d", got)
+	}
+
+	held := []plex.MatchResult{match(plex.MatchTypeReview, 0.7)}
+	applyReviewDefault(config.ReviewDefaultAccept, held, []int{0})
+	if held[0].MatchType != plex.MatchTypeTitleArtist || held[0].PlexTrack == nil {
+		t.Errorf("accepted held match = %+v", held[0])
+	}
+}
+
+type fakeReviewSearcher struct {
+	queries []string
+	tracks  []plex.PlexTrack
+}
+
+func (f *fakeReviewSearcher) SearchTracks(_ context.Context, query string) ([]plex.PlexTrack, error) {
+	f.queries = append(f.queries, query)
+	return f.tracks, nil
+}
+
+func (f *fakeReviewSearcher) TitleArtistConfidence(track.Track, *plex.PlexTrack) float64 {
+	return 0.5
+}
+
+func TestReviewerRun(t *testing.T) {
+	trace := &plex.MatchTrace{Steps: []plex.TraceStep{{Candidates: []plex.TraceCandidate{
+		{RatingKey: "1", Title: "Song", Artist: "Band", Scores: plex.TraceScores{Total: 0.7}},
+		{RatingKey: "2", Title: "Song (Remastered)", Artist: "Band", Scores: plex.TraceScores{Total: 0.65}},
+	}}}}
+	inBand := func(name string) plex.MatchResult {
+		return plex.MatchResult{
+			SourceTrack: track.Track{Name: name, Artist: "Band", ISRC: "USXXX" + name},
+			PlexTrack:   &plex.PlexTrack{ID: "1", Title: "Song", Artist: "Band"},
+			MatchType:   plex.MatchTypeTitleArtist,
+			Confidence:  0.7,
+			Trace:       trace,
+		}
+	}
+	results := []plex.MatchResult{inBand("A"), inBand("B"), inBand("C"), inBand("D"), inBand("E")}
+	searcher := &fakeReviewSearcher{tracks: []plex.PlexTrack{{ID: "9", Title: "Song", Artist: "Other Band"}}}
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	var out bytes.Buffer
+	rv := &reviewer{
+		// accept, pick 2, find with default query then pick 1, skip, quit
+		in:            bufio.NewReader(strings.NewReader("a\n2\nf\n\n1\ns\nq\n")),
+		out:           &out,
+		plex:          searcher,
+		overridesFile: path,
+	}
+	p := config.PlexConfig{AutoAcceptPercent: 90, AutoRejectPercent: 60, ReviewDefault: config.ReviewDefaultReject}
+	rv.run(context.Background(), p, results, []int{0, 1, 2, 3, 4})
+
+	gotKeys := make([]string, len(results))
+	for i, r := range results {
+		if r.PlexTrack != nil {
+			gotKeys[i] = r.PlexTrack.ID
+		}
+	}
+	if want := []string{"1", "2", "9", "", ""}; !reflect.DeepEqual(gotKeys, want) {
+		t.Fatalf("reviewed keys = %v, want %v\n%s", gotKeys, want, out.String())
+	}
+	if results[3].MatchType != plex.MatchTypeNone || results[4].MatchType != plex.MatchTypeNone {
+		t.Errorf("skipped and quit tracks should be misses: %+v / %+v", results[3], results[4])
+	}
+	if len(searcher.queries) != 1 || searcher.queries[0] != "C Band" {
+		t.Errorf("search queries = %v", searcher.queries)
+	}
+	if rv.stats != (reviewStats{accepted: 1, changed: 2, rejected: 1}) {
+		t.Errorf("stats = %+v", rv.stats)
+	}
+
+	set, err := overrides.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Len() != 3 {
+		t.Fatalf("expected 3 saved overrides, got %+v", set.Entries())
+	}
+	if e, ok := set.Find(results[2].SourceTrack); !ok || e.RatingKey != "9" {
+		t.Errorf("override for searched track = %+v, %v", e, ok)
+	}
+}
+
+func TestMissingArtists(t *testing.T) {
+	missing := []plex.MatchResult{
+		{SourceTrack: track.Track{Artist: "A feat. B", MusicBrainzArtists: []track.ArtistCredit{{Name: "A", ID: "mb-a"}, {Name: "B", ID: "mb-b"}}}},
+		{SourceTrack: track.Track{Artist: "A", MusicBrainzArtists: []track.ArtistCredit{{ID: "mb-a"}}}},
+		{SourceTrack: track.Track{Artist: "C", MusicBrainzArtists: []track.ArtistCredit{{Name: "C", ID: "mb-c"}}, MusicBrainzReleaseGroupID: "rg"}},
+		{SourceTrack: track.Track{Artist: "D"}},
+		{SourceTrack: track.Track{Artist: "E", MusicBrainzArtists: []track.ArtistCredit{{ID: "mb-e"}}}},
+		// The primary credit has no MBID: the guest's id must not be filed under the primary's name.
+		{SourceTrack: track.Track{Artist: "F feat. G", MusicBrainzArtists: []track.ArtistCredit{{Name: "F"}, {Name: "G", ID: "mb-g"}}}},
+	}
+	got := missingArtists(missing)
+	want := []missingArtist{{id: "mb-a", name: "A"}, {id: "mb-e", name: "E"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("missingArtists = %+v, want %+v", got, want)
+	}
+}
+
+func TestResolveMissingReleaseGroups(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ws/2/recording/rec-1" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"releases": [{"status": "Official", "date": "1994", "release-group": {"id": "rg-1", "primary-type": "Album"}}]}`)
+	}))
+	defer ts.Close()
+	mb, err := musicbrainz.NewClient(ts.URL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &Application{musicBrainz: mb}
+
+	missing := []plex.MatchResult{
+		{SourceTrack: track.Track{Name: "A", MusicBrainzID: "rec-1"}},
+		{SourceTrack: track.Track{Name: "B", MusicBrainzID: "rec-1", MusicBrainzReleaseGroupID: "rg-known"}},
+		{SourceTrack: track.Track{Name: "C", ISRC: "XX0000000000"}},
+	}
+	got := app.resolveMissingReleaseGroups(context.Background(), missing)
+	if got[0].SourceTrack.MusicBrainzReleaseGroupID != "rg-1" || got[1].SourceTrack.MusicBrainzReleaseGroupID != "rg-known" ||
+		got[2].SourceTrack.MusicBrainzReleaseGroupID != "" {
+		t.Errorf("resolved = %+v", got)
+	}
+	if missing[0].SourceTrack.MusicBrainzReleaseGroupID != "" {
+		t.Error("input results should not be modified")
+	}
+}
+
+func TestMissingReleaseGroupIDs(t *testing.T) {
+	missing := []plex.MatchResult{
+		{SourceTrack: track.Track{MusicBrainzReleaseGroupID: "rg-1"}},
+		{SourceTrack: track.Track{MusicBrainzID: "rec-2"}},
+		{SourceTrack: track.Track{MusicBrainzReleaseGroupID: " rg-2 "}},
+		{SourceTrack: track.Track{MusicBrainzReleaseGroupID: "rg-1"}},
+	}
+	if got, want := missingReleaseGroupIDs(missing), []string{"rg-1", "rg-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("missingReleaseGroupIDs = %v, want %v", got, want)
+	}
+}
+
+func TestQueueLidarrRequests(t *testing.T) {
+	app := &Application{config: &config.Config{Lidarr: config.LidarrConfig{
+		URL: "http://lidarr", Token: "k", PlaylistAllowlist: []string{"pl-jazz"},
+	}}}
+	app.lidarr, _ = lidarr.NewClient(&app.config.Lidarr)
+
+	missing := func(rgs ...string) []plex.MatchResult {
+		var out []plex.MatchResult
+		for _, rg := range rgs {
+			out = append(out, plex.MatchResult{SourceTrack: track.Track{MusicBrainzReleaseGroupID: rg}})
+		}
+		return out
+	}
+	app.queueLidarrRequests(PlaylistMeta{ID: "pl-discover"}, missing("rg-solo", "rg-shared", "rg-shared"))
+	app.queueLidarrRequests(PlaylistMeta{ID: "pl-mix"}, missing("rg-shared"))
+	app.queueLidarrRequests(PlaylistMeta{ID: "pl-jazz"}, missing("rg-jazz"))
+
+	got, below := app.lidarrQueue.groups.eligible(2)
+	var ids []string
+	for _, w := range got {
+		ids = append(ids, w.id)
+	}
+	if want := []string{"rg-shared", "rg-jazz"}; !reflect.DeepEqual(ids, want) || below != 1 {
+		t.Errorf("eligible = %v (%d below), want %v (1 below)", ids, below, want)
+	}
+	if n := len(got[0].playlists); n != 2 {
+		t.Errorf("rg-shared wanted by %d playlists, want 2", n)
+	}
+
+	all, _ := app.lidarrQueue.groups.eligible(1)
+	if len(all) != 3 || all[0].id != "rg-shared" || all[1].id != "rg-solo" {
+		t.Errorf("with no threshold the most wanted comes first, then source order: %+v", all)
+	}
+}
+
+func TestUnwantedAlbums(t *testing.T) {
+	app := &Application{}
+	app.lidarrQueue.referenced = []track.Track{
+		{Artist: "Band", MusicBrainzReleaseGroupID: "rg-keep"},
+		{Artist: "Other Band", MusicBrainzArtists: []track.ArtistCredit{{Name: "Other Band", ID: "mb-other"}}, ISRC: "XX0000000001"},
+	}
+	albums := []lidarr.RequestedAlbum{
+		{AlbumID: 1, ReleaseGroupID: "rg-keep", ArtistName: "Band", ArtistMBID: "mb-band"},
+		{AlbumID: 2, ReleaseGroupID: "rg-gone", ArtistName: "Band", ArtistMBID: "mb-band"},
+		{AlbumID: 3, ReleaseGroupID: "rg-other", ArtistName: "Somebody", ArtistMBID: "mb-other"},
+	}
+	got, kept := app.unwantedAlbums(context.Background(), albums)
+	if len(got) != 1 || got[0].AlbumID != 2 {
+		t.Errorf("unwanted = %+v, want album 2 only", got)
+	}
+	if kept != 1 {
+		t.Errorf("kept = %d, want 1 (Other Band's release group is unknown without MusicBrainz)", kept)
+	}
+}
+
+func TestUnmonitorUnwantedAlbums_keepsHandMonitoredAlbums(t *testing.T) {
+	// Plexify requested rg-requested; the same (tagged) artist's rg-hand was monitored by hand. Neither is
+	// on a playlist any more.
+	state := filepath.Join(t.TempDir(), "lidarr.json")
+	if err := os.WriteFile(state, []byte(`{"release_groups": ["rg-requested"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var unmonitored []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/album" && r.URL.Query().Get("foreignAlbumId") == "rg-requested":
+			_, _ = w.Write([]byte(`[{"id": 1, "title": "Requested", "monitored": true, "artist": {"artistName": "Band", "foreignArtistId": "mb-band"}}]`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/album/monitor":
+			var body struct {
+				AlbumIDs []int `json:"albumIds"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			unmonitored = append(unmonitored, body.AlbumIDs...)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{}
+	cfg.Lidarr = config.LidarrConfig{URL: srv.URL, Token: "k", Tags: true, Tag: "plexify", StateFile: state, Unmonitor: true}
+	app := &Application{config: cfg}
+	app.lidarr, _ = lidarr.NewClient(&cfg.Lidarr)
+	app.lidarrQueue.referenced = []track.Track{{Artist: "Band", Name: "Still Here", MusicBrainzReleaseGroupID: "rg-other"}}
+
+	app.unmonitorUnwantedAlbums(context.Background())
+	if !reflect.DeepEqual(unmonitored, []int{1}) {
+		t.Errorf("unmonitored %v, want only the requested album (the hand-monitored one is kept)", unmonitored)
+	}
+}
+
+type recordingAcquirer struct{ got []acquire.Payload }
+
+func (r *recordingAcquirer) Name() string { return "recorder" }
+
+func (r *recordingAcquirer) Acquire(_ context.Context, p acquire.Payload) error {
+	r.got = append(r.got, p)
+	return nil
+}
+
+type previewingAcquirer struct {
+	recordingAcquirer
+	previews int
+}
+
+func (p *previewingAcquirer) Preview(context.Context, acquire.Payload) { p.previews++ }
+
+func TestAcquireMissing_previewAndPartialRun(t *testing.T) {
+	self, rec := &previewingAcquirer{}, &recordingAcquirer{}
+	app := &Application{config: &config.Config{}, acquirers: []acquire.Acquirer{self, rec}}
+	app.queueMissingTracks(PlaylistMeta{ID: "pl-1"}, []plex.MatchResult{{SourceTrack: track.Track{Artist: "Band", Name: "Song"}}})
+
+	app.partialRun = true
+	app.acquireMissing(context.Background())
+	if self.previews != 0 || len(self.got) != 0 || len(rec.got) != 0 {
+		t.Fatalf("partial run reached a provider: previews=%d self=%d rec=%d", self.previews, len(self.got), len(rec.got))
+	}
+
+	app.partialRun = false
+	app.config.Plex.DryRun = true
+	app.acquireMissing(context.Background())
+	if self.previews != 1 || len(self.got) != 0 || len(rec.got) != 0 {
+		t.Errorf("dry run: previews=%d self=%d rec=%d, want one preview only", self.previews, len(self.got), len(rec.got))
+	}
+}
+
+func TestAcquireMissing(t *testing.T) {
+	rec := &recordingAcquirer{}
+	app := &Application{config: &config.Config{}, acquirers: []acquire.Acquirer{rec}}
+
+	song := track.Track{Artist: "Band", Name: "Song", ISRC: "usabc1234567", SpotifyAlbumURI: "spotify:album:abc"}
+	other := track.Track{Artist: "Band", Name: "Other"}
+	app.queueMissingTracks(PlaylistMeta{ID: "pl-1", Name: "One"}, []plex.MatchResult{
+		{SourceTrack: song},
+		{SourceTrack: other, PlexTrack: &plex.PlexTrack{}},
+		{SourceTrack: track.Track{Artist: "Band", Name: "Skipped"}, MatchType: plex.MatchTypeSkipped},
+	})
+	app.queueMissingTracks(PlaylistMeta{ID: "pl-2", Name: "Two"}, []plex.MatchResult{{SourceTrack: song}, {SourceTrack: other}})
+
+	app.config.Plex.DryRun = true
+	app.acquireMissing(context.Background())
+	if len(rec.got) != 0 {
+		t.Fatalf("dry run called the provider: %+v", rec.got)
+	}
+	app.config.Plex.DryRun = false
+	app.acquireMissing(context.Background())
+	if len(rec.got) != 1 {
+		t.Fatalf("provider called %d times, want 1", len(rec.got))
+	}
+	p := rec.got[0]
+	if p.Version != acquire.PayloadVersion || len(p.Tracks) != 2 {
+		t.Fatalf("payload = %+v", p)
+	}
+	first := p.Tracks[0]
+	if first.Title != "Song" || first.SpotifyAlbumID != "abc" || len(first.Playlists) != 2 || first.Playlists[1].Name != "Two" {
+		t.Errorf("first track = %+v", first)
+	}
+	if p.Tracks[1].Title != "Other" || len(p.Tracks[1].Playlists) != 1 || p.Tracks[1].Playlists[0].ID != "pl-2" {
+		t.Errorf("second track = %+v", p.Tracks[1])
+	}
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grrywlsn/plexify/overrides"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// explainedTrack is one entry of the PLEXIFY_EXPLAIN_JSON dump.
+type explainedTrack struct {
+	PlaylistID string           `json:"playlist_id"`
+	Playlist   string           `json:"playlist"`
+	Source     explainedSource  `json:"source"`
+	Trace      *plex.MatchTrace `json:"trace"`
+}
+
+type explainedSource struct {
+	ID     string `json:"id,omitempty"`
+	Artist string `json:"artist"`
+	Title  string `json:"title"`
+	Album  string `json:"album,omitempty"`
+	ISRC   string `json:"isrc,omitempty"`
+	MBID   string `json:"mbid,omitempty"`
+}
+
+// explainSelectorMatches reports whether a PLEXIFY_EXPLAIN selector names song: "Artist - Title" (any dash),
+// the source id, ISRC or recording MBID, or a case-insensitive fragment of the title.
+func explainSelectorMatches(selector string, song track.Track) bool {
+	sel := strings.TrimSpace(selector)
+	if sel == "" {
+		return false
+	}
+	for _, id := range []string{song.ID, song.ISRC, song.MusicBrainzID} {
+		if id != "" && strings.EqualFold(sel, strings.TrimSpace(id)) {
+			return true
+		}
+	}
+	key := overrides.ParseTrackKey(sel)
+	if key == overrides.TrackKey(song.Artist, song.Name) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(song.Name), key)
+}
+
+// filterExplainTracks keeps the songs selected by PLEXIFY_EXPLAIN.
+func filterExplainTracks(selector string, songs []track.Track) []track.Track {
+	var out []track.Track
+	for _, s := range songs {
+		if explainSelectorMatches(selector, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// recordExplained keeps traced results for the PLEXIFY_EXPLAIN_JSON dump.
+func (app *Application) recordExplained(meta PlaylistMeta, results []plex.MatchResult) {
+	if app.config.Plex.ExplainJSONFile == "" {
+		return
+	}
+	for _, r := range results {
+		st := r.SourceTrack
+		app.explained = append(app.explained, explainedTrack{
+			PlaylistID: meta.ID,
+			Playlist:   meta.Name,
+			Source: explainedSource{
+				ID: st.ID, Artist: st.Artist, Title: st.Name, Album: st.Album, ISRC: st.ISRC, MBID: st.MusicBrainzID,
+			},
+			Trace: r.Trace,
+		})
+	}
+}
+
+// writeExplainJSON writes the collected traces to PLEXIFY_EXPLAIN_JSON.
+func (app *Application) writeExplainJSON() error {
+	path := app.config.Plex.ExplainJSONFile
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(struct {
+		Tracks []explainedTrack `json:"tracks"`
+	}{Tracks: app.explained}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode match traces: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	fmt.Printf("📝 Wrote match traces for %d tracks to %s\n", len(app.explained), path)
+	return nil
+}
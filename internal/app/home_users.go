@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/plextv"
+)
+
+// homeUsers resolves Plex Home users named in PLEXIFY_HOME_USERS to their access tokens for this server.
+// Tokens are cached for the run so each user is switched to once, however many playlists they receive.
+type homeUsers struct {
+	tv         *plextv.Client
+	adminToken string
+	users      []plextv.HomeUser // fetched on first use
+	tokens     map[string]string // by user UUID
+}
+
+func newHomeUsers(cfg *config.Config) (*homeUsers, error) {
+	clientID := cfg.Plex.ClientIdentifier
+	if clientID == "" {
+		// plex.tv only needs a stable identifier here; "plexify login" writes a per-install one.
+		clientID = plextv.Product
+	}
+	tv, err := plextv.NewClient(cfg.Plex.TVURL, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return &homeUsers{tv: tv, adminToken: cfg.Plex.Token, tokens: make(map[string]string)}, nil
+}
+
+// token returns the Home user matching m and their access token for the server with machine identifier
+// serverID. The admin user keeps PLEX_TOKEN.
+func (h *homeUsers) token(ctx context.Context, m config.HomeUserMapping, serverID string) (plextv.HomeUser, string, error) {
+	if h.users == nil {
+		users, err := h.tv.HomeUsers(ctx, h.adminToken)
+		if err != nil {
+			return plextv.HomeUser{}, "", err
+		}
+		h.users = users
+	}
+	var user *plextv.HomeUser
+	for i := range h.users {
+		if h.users[i].Matches(m.User) {
+			user = &h.users[i]
+			break
+		}
+	}
+	if user == nil {
+		return plextv.HomeUser{}, "", fmt.Errorf("no Plex Home user named %q", m.User)
+	}
+	if user.Admin {
+		return *user, h.adminToken, nil
+	}
+	if tok, ok := h.tokens[user.UUID]; ok {
+		return *user, tok, nil
+	}
+	if user.Protected && m.PIN == "" {
+		return *user, "", fmt.Errorf("Plex Home user %q is PIN-protected; add :PIN to its PLEXIFY_HOME_USERS entry", user.Title)
+	}
+	if serverID == "" {
+		return *user, "", fmt.Errorf("PLEX_SERVER_ID is unknown; set it to write playlists for Plex Home users")
+	}
+	accountToken, err := h.tv.SwitchHomeUser(ctx, h.adminToken, *user, m.PIN)
+	if err != nil {
+		return *user, "", err
+	}
+	tok, err := h.tv.ServerAccessToken(ctx, accountToken, serverID)
+	if err != nil {
+		return *user, "", fmt.Errorf("Plex Home user %q: %w", user.Title, err)
+	}
+	h.tokens[user.UUID] = tok
+	return *user, tok, nil
+}
+
+// playlistUserContext returns ctx routed to the Plex Home user mapped to meta, or ctx unchanged when the
+// playlist belongs in the PLEX_TOKEN account.
+func (app *Application) playlistUserContext(ctx context.Context, meta PlaylistMeta) (context.Context, error) {
+	m, ok := app.config.Plex.HomeUserFor(meta.ID, meta.Owner)
+	if !ok || app.homeUsers == nil {
+		return ctx, nil
+	}
+	user, tok, err := app.homeUsers.token(ctx, m, app.config.Plex.ServerID)
+	if err != nil {
+		return ctx, fmt.Errorf("switch to Plex Home user %q: %w", m.User, err)
+	}
+	fmt.Printf("👤 Syncing into Plex Home user: %s\n", user.Title)
+	return plex.WithPlaylistUser(ctx, user.Title, tok), nil
+}
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/grrywlsn/plexify/acquire"
+	"github.com/grrywlsn/plexify/internal/cliutil"
+	"github.com/grrywlsn/plexify/lidarr"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// lidarrWanted is a release group or artist that one or more of the run's playlists are missing.
+type lidarrWanted struct {
+	id          string
+	name        string         // artist name, for output; empty for release groups
+	playlists   []PlaylistMeta // playlists missing it, in processing order
+	allowlisted bool           // one of them is in LIDARR_PLAYLIST_ALLOWLIST
+}
+
+// wantedSet collects lidarrWanted entries by id, in first-seen order.
+type wantedSet struct {
+	items []*lidarrWanted
+	byID  map[string]*lidarrWanted
+}
+
+func (s *wantedSet) add(id, name string, meta PlaylistMeta, allowlisted bool) {
+	if s.byID == nil {
+		s.byID = make(map[string]*lidarrWanted)
+	}
+	w, ok := s.byID[id]
+	if !ok {
+		w = &lidarrWanted{id: id, name: name}
+		s.byID[id] = w
+		s.items = append(s.items, w)
+	}
+	if n := len(w.playlists); n == 0 || w.playlists[n-1].ID != meta.ID {
+		w.playlists = append(w.playlists, meta)
+	}
+	w.allowlisted = w.allowlisted || allowlisted
+}
+
+// eligible returns the entries wanted by at least minPlaylists playlists or by an allowlisted one, most
+// wanted first, and how many fell short.
+func (s *wantedSet) eligible(minPlaylists int) ([]*lidarrWanted, int) {
+	var out []*lidarrWanted
+	for _, w := range s.items {
+		if w.allowlisted || len(w.playlists) >= minPlaylists {
+			out = append(out, w)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return len(out[i].playlists) > len(out[j].playlists) })
+	return out, len(s.items) - len(out)
+}
+
+// lidarrQueue holds what the run's playlists are missing, so Lidarr is asked once per release group or
+// artist after every playlist is synced, with the LIDARR_MIN_PLAYLISTS and LIDARR_MAX_ADDS_PER_RUN policies
+// applied across the whole run.
+type lidarrQueue struct {
+	groups  wantedSet
+	artists wantedSet
+
+	// referenced are the source tracks (matched or missing) of every playlist synced, and failed counts
+	// playlists that were not; both feed unmonitorUnwantedAlbums.
+	referenced []track.Track
+	failed     int
+}
+
+// lidarrContext carries the Lidarr add profile of the first of playlists and a tag for each of them to the
+// Lidarr client.
+func (app *Application) lidarrContext(ctx context.Context, playlists ...PlaylistMeta) context.Context {
+	if len(playlists) == 0 {
+		return ctx
+	}
+	first := playlists[0].ID
+	if _, ok := app.config.Lidarr.PlaylistAddProfiles[first]; ok {
+		ctx = lidarr.WithAddProfile(ctx, app.config.Lidarr.AddProfileFor(first))
+	}
+	names := make([]string, len(playlists))
+	for i, meta := range playlists {
+		names[i] = meta.Name
+	}
+	return lidarr.WithSourcePlaylists(ctx, names...)
+}
+
+// queueLidarrRequests records the release groups (and, with LIDARR_ADD_ARTISTS, artists) of a playlist's
+// missing tracks for addQueuedToLidarr.
+func (app *Application) queueLidarrRequests(meta PlaylistMeta, results []plex.MatchResult) {
+	if app.lidarr == nil || !app.config.LidarrEnabled() {
+		return
+	}
+	var missing []plex.MatchResult
+	for _, r := range results {
+		if r.MatchType == plex.MatchTypeSkipped {
+			continue
+		}
+		app.lidarrQueue.referenced = append(app.lidarrQueue.referenced, r.SourceTrack)
+		if r.PlexTrack == nil {
+			missing = append(missing, r)
+		}
+	}
+	allowlisted := app.config.Lidarr.Allowlisted(meta.ID)
+	for _, id := range missingReleaseGroupIDs(missing) {
+		app.lidarrQueue.groups.add(id, "", meta, allowlisted)
+	}
+	if app.config.Lidarr.AddArtists {
+		for _, a := range missingArtists(missing) {
+			app.lidarrQueue.artists.add(a.id, a.name, meta, allowlisted)
+		}
+	}
+}
+
+// lidarrAcquirer is the Lidarr acquisition provider. It requests what queueLidarrRequests collected rather
+// than the payload: LIDARR_MIN_PLAYLISTS, per-playlist profiles and tags work per release group and artist,
+// and the queue also feeds the unmonitor pass.
+type lidarrAcquirer struct{ app *Application }
+
+func (l lidarrAcquirer) Name() string { return "Lidarr" }
+
+func (l lidarrAcquirer) Acquire(ctx context.Context, _ acquire.Payload) error {
+	l.app.addQueuedToLidarr(ctx)
+	return nil
+}
+
+func (l lidarrAcquirer) Preview(ctx context.Context, _ acquire.Payload) {
+	l.app.addQueuedToLidarr(ctx)
+}
+
+// addQueuedToLidarr requests the queued release groups and artists from Lidarr, most wanted first. Each is
+// added with the profile of the first playlist that wanted it and tagged for every playlist that did.
+func (app *Application) addQueuedToLidarr(ctx context.Context) {
+	if app.lidarr == nil || !app.config.LidarrEnabled() {
+		return
+	}
+	cfg := app.config.Lidarr
+	groups, groupsBelow := app.lidarrQueue.groups.eligible(cfg.MinPlaylists)
+	artists, artistsBelow := app.lidarrQueue.artists.eligible(cfg.MinPlaylists)
+	belowNote := func() {
+		if below := groupsBelow + artistsBelow; below > 0 {
+			fmt.Printf("ℹ️  Lidarr: %d release group(s) or artist(s) missing from fewer than %d playlists were not requested (LIDARR_MIN_PLAYLISTS)\n", below, cfg.MinPlaylists)
+		}
+	}
+	if len(groups) == 0 && len(artists) == 0 {
+		if groupsBelow+artistsBelow > 0 {
+			fmt.Println()
+			belowNote()
+		}
+		return
+	}
+
+	settings, settingsErr := app.lidarr.AddSettings(ctx)
+	monitor := cfg.ArtistMonitor
+
+	if app.config.Plex.DryRun {
+		fmt.Println()
+		if len(groups) > 0 {
+			fmt.Println("Lidarr (dry-run): would request add for these MusicBrainz release group id(s):")
+			for _, g := range groups {
+				fmt.Printf("  - %s%s\n", g.id, wantedBy(g))
+			}
+			if cfg.MaxAddsPerRun > 0 {
+				fmt.Printf("At most %d new album(s) would be added this run (LIDARR_MAX_ADDS_PER_RUN)\n", cfg.MaxAddsPerRun)
+			}
+		}
+		if len(artists) > 0 {
+			fmt.Printf("Lidarr (dry-run): would add these artist(s) if missing, monitoring %s:\n", monitor)
+			for _, a := range artists {
+				fmt.Printf("  - %s (%s)%s\n", a.name, a.id, wantedBy(a))
+			}
+		}
+		if settingsErr == nil {
+			fmt.Printf("New artists would use %s\n", settings)
+		}
+		belowNote()
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
+	if len(artists) > 0 {
+		fmt.Println("LIDARR: ADD MISSING RELEASE GROUPS AND ARTISTS")
+	} else {
+		fmt.Println("LIDARR: ADD MISSING RELEASE GROUPS")
+	}
+	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
+	if settingsErr == nil {
+		fmt.Printf("New artists use %s\n", settings)
+	}
+	added := 0
+	for i, g := range groups {
+		if cfg.MaxAddsPerRun > 0 && added >= cfg.MaxAddsPerRun {
+			fmt.Printf("⏸️  Lidarr: added %d new album(s), the LIDARR_MAX_ADDS_PER_RUN limit; %d release group(s) left for a later run\n", added, len(groups)-i)
+			break
+		}
+		id := g.id
+		res, err := app.lidarr.AddReleaseGroupIfMissing(app.lidarrContext(ctx, g.playlists...), id)
+		if err != nil {
+			slog.Error("lidarr: add release group", "release_group", id, "err", err)
+			if we, ok := lidarr.AsWriteError(err); ok {
+				fmt.Printf("❌ %s\n", we.UserMessage())
+			} else {
+				fmt.Printf("❌ Lidarr: could not add release group %s: %v\n", id, err)
+			}
+			continue
+		}
+		switch {
+		case res.Skipped != "":
+			fmt.Printf("⏭️  Lidarr: skipped release group %s (%s, LIDARR_SKIP_COMPILATIONS)\n", id, strings.ToLower(res.Skipped))
+		case res.AlreadyPresent && res.EnsuredMonitored:
+			fmt.Printf("ℹ️  Lidarr: release group %s already in library; ensured album, releases, and artist are monitored\n", id)
+		case res.AlreadyPresent:
+			fmt.Printf("ℹ️  Lidarr: release group %s already in library\n", id)
+		case res.Added:
+			added++
+			fmt.Printf("✅ Lidarr: added release group %s%s (search for release started)\n", id, wantedBy(g))
+		}
+	}
+	for _, a := range artists {
+		res, err := app.lidarr.AddArtistIfMissing(app.lidarrContext(ctx, a.playlists...), a.id, monitor)
+		name := a.name
+		if err != nil {
+			slog.Error("lidarr: add artist", "artist", a.id, "err", err)
+			fmt.Printf("❌ Lidarr: could not add artist %s (%s): %v\n", name, a.id, err)
+			continue
+		}
+		if res.Name != "" {
+			name = res.Name
+		}
+		if res.AlreadyPresent {
+			fmt.Printf("ℹ️  Lidarr: artist %s already in library\n", name)
+		} else if res.Added {
+			fmt.Printf("✅ Lidarr: added artist %s (monitoring %s)\n", name, monitor)
+		}
+	}
+	belowNote()
+}
+
+// wantedBy notes how many playlists want an entry when more than one does.
+func wantedBy(w *lidarrWanted) string {
+	if len(w.playlists) < 2 {
+		return ""
+	}
+	return fmt.Sprintf(" (missing from %d playlists)", len(w.playlists))
+}
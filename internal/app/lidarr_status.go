@@ -0,0 +1,148 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/grrywlsn/plexify/internal/cliutil"
+	"github.com/grrywlsn/plexify/lidarr"
+	"github.com/grrywlsn/plexify/plex"
+)
+
+// lidarrStatusOrder is the order states are totalled in by LidarrStatus.
+var lidarrStatusOrder = []lidarr.AlbumState{
+	lidarr.StateQueued,
+	lidarr.StateGrabbed,
+	lidarr.StateImported,
+	lidarr.StateWanted,
+	lidarr.StateUnmonitored,
+	lidarr.StateNotInLidarr,
+}
+
+// missingReleaseGroupIDs returns the distinct release group ids of missing, in first-seen order.
+func missingReleaseGroupIDs(missing []plex.MatchResult) []string {
+	seen := make(map[string]struct{})
+	var ids []string
+	for _, m := range missing {
+		rg := strings.TrimSpace(m.SourceTrack.MusicBrainzReleaseGroupID)
+		if rg == "" {
+			continue
+		}
+		if _, ok := seen[rg]; ok {
+			continue
+		}
+		seen[rg] = struct{}{}
+		ids = append(ids, rg)
+	}
+	return ids
+}
+
+// lidarrStatuses looks up where each missing track's release group stands in Lidarr. It returns nil when
+// Lidarr is off or unreachable; the status is informational and never fails a run.
+func (app *Application) lidarrStatuses(ctx context.Context, missing []plex.MatchResult) map[string]lidarr.ReleaseGroupStatus {
+	if app.lidarr == nil || !app.config.LidarrEnabled() {
+		return nil
+	}
+	ids := missingReleaseGroupIDs(missing)
+	if len(ids) == 0 {
+		return nil
+	}
+	statuses, err := app.lidarr.ReleaseGroupStatuses(ctx, ids)
+	if err != nil {
+		slog.Warn("lidarr: could not fetch acquisition status", "err", err)
+		return nil
+	}
+	return statuses
+}
+
+// LidarrStatus matches every configured playlist (or only playlistID when set) against Plex and reports
+// the Lidarr state of the release groups behind tracks that are still missing. Plex playlists and Lidarr
+// are not modified.
+func (app *Application) LidarrStatus(ctx context.Context, playlistID string) error {
+	if app.lidarr == nil || !app.config.LidarrEnabled() {
+		return errors.New("lidarr-status needs LIDARR_URL and LIDARR_TOKEN")
+	}
+	if err := app.prepare(ctx); err != nil {
+		return err
+	}
+
+	metas, err := app.getPlaylistMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to get playlist metadata: %w", err)
+	}
+	if playlistID != "" {
+		var only []PlaylistMeta
+		for _, m := range metas {
+			if m.ID == playlistID {
+				only = append(only, m)
+			}
+		}
+		if len(only) == 0 {
+			return fmt.Errorf("playlist %s is not among the synced playlists", playlistID)
+		}
+		metas = only
+	}
+	if len(metas) == 0 {
+		return ErrNoPlaylists
+	}
+
+	totals := make(map[lidarr.AlbumState]int)
+	counted := make(map[string]bool) // release groups shared by several playlists are totalled once
+	unresolved := 0
+	for _, meta := range metas {
+		pl, err := app.musicSocial.GetPlaylist(meta.ID)
+		if err != nil {
+			slog.Warn("failed to fetch playlist", "playlist", meta.ID, "err", err)
+			continue
+		}
+		var missing []plex.MatchResult
+		for _, r := range app.plexClient.MatchSourceTracks(ctx, pl.Tracks) {
+			if r.PlexTrack == nil && r.MatchType != plex.MatchTypeSkipped {
+				missing = append(missing, r)
+			}
+		}
+
+		fmt.Println("\n" + cliutil.RepeatChar("=", cliutil.SectionWidth))
+		fmt.Printf("📋 %s (%s): %d missing of %d\n", meta.Name, meta.ID, len(missing), len(pl.Tracks))
+		fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
+		if len(missing) == 0 {
+			continue
+		}
+		missing = app.resolveMissingReleaseGroups(ctx, missing)
+		statuses, err := app.lidarr.ReleaseGroupStatuses(ctx, missingReleaseGroupIDs(missing))
+		if err != nil {
+			return fmt.Errorf("lidarr status: %w", err)
+		}
+		for _, m := range missing {
+			st := m.SourceTrack
+			s, ok := statuses[strings.TrimSpace(st.MusicBrainzReleaseGroupID)]
+			if !ok {
+				unresolved++
+				fmt.Printf("  %s - %s: no MusicBrainz release group\n", st.Artist, st.Name)
+				continue
+			}
+			fmt.Printf("  %s - %s: %s\n", st.Artist, st.Name, s.Describe())
+			if !counted[s.ReleaseGroupID] {
+				counted[s.ReleaseGroupID] = true
+				totals[s.State]++
+			}
+		}
+	}
+
+	fmt.Println("\n" + cliutil.RepeatChar("=", cliutil.SectionWidth))
+	fmt.Println("LIDARR STATUS")
+	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
+	fmt.Printf("Release groups: %d\n", len(counted))
+	for _, state := range lidarrStatusOrder {
+		if n := totals[state]; n > 0 {
+			fmt.Printf("  %s: %d\n", state, n)
+		}
+	}
+	if unresolved > 0 {
+		fmt.Printf("Tracks without a release group: %d\n", unresolved)
+	}
+	return nil
+}
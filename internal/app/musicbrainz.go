@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/grrywlsn/plexify/plex"
+)
+
+// resolveMissingReleaseGroups returns missing with MusicBrainzReleaseGroupID filled in from MusicBrainz for
+// tracks that only have a recording MBID or ISRC, so Lidarr can be asked for their albums. Lookup failures
+// are logged and leave the track as it was.
+func (app *Application) resolveMissingReleaseGroups(ctx context.Context, missing []plex.MatchResult) []plex.MatchResult {
+	if app.musicBrainz == nil {
+		return missing
+	}
+	out := make([]plex.MatchResult, len(missing))
+	copy(out, missing)
+	for i := range out {
+		st := &out[i].SourceTrack
+		if strings.TrimSpace(st.MusicBrainzReleaseGroupID) != "" || (st.MusicBrainzID == "" && st.ISRC == "") {
+			continue
+		}
+		res, ok, err := app.musicBrainz.ResolveReleaseGroup(ctx, *st)
+		if err != nil {
+			if ctx.Err() != nil {
+				return out
+			}
+			slog.Warn("musicbrainz: release group lookup failed", "artist", st.Artist, "title", st.Name, "err", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		st.MusicBrainzReleaseGroupID = res.ReleaseGroupID
+		fmt.Printf("🔎 MusicBrainz: %s - %s is on release group %s (from %s)\n", st.Artist, st.Name, res.ReleaseGroupID, res.Source)
+	}
+	return out
+}
+
+// fillMissingReleaseGroups resolves release groups for the unmatched tracks in results, in place.
+func (app *Application) fillMissingReleaseGroups(ctx context.Context, results []plex.MatchResult) {
+	if app.lidarr == nil || !app.config.LidarrEnabled() {
+		return
+	}
+	var idx []int
+	var missing []plex.MatchResult
+	for i, r := range results {
+		if r.PlexTrack == nil && r.MatchType != plex.MatchTypeSkipped {
+			idx = append(idx, i)
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+	missing = app.resolveMissingReleaseGroups(ctx, missing)
+	for j, i := range idx {
+		results[i].SourceTrack = missing[j].SourceTrack
+	}
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/grrywlsn/plexify/lidarr"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// rescanImportedAlbums handles tracks that are missing only because Plex has not scanned what Lidarr
+// imported: it scans those album folders (mapped by LIDARR_PLEX_PATH_MAP), waits up to
+// LIDARR_RESCAN_TIMEOUT_SECONDS for Plex to finish, and matches the tracks again, updating results in place. Release group ids
+// resolved on the way are kept on the results so the missing-tracks summary does not look them up twice.
+func (app *Application) rescanImportedAlbums(ctx context.Context, results []plex.MatchResult) {
+	if app.lidarr == nil || !app.config.LidarrEnabled() || !app.config.Lidarr.RescanPlex {
+		return
+	}
+	app.fillMissingReleaseGroups(ctx, results)
+	var idx []int
+	var missing []plex.MatchResult
+	for i, r := range results {
+		if r.PlexTrack == nil && r.MatchType != plex.MatchTypeSkipped {
+			idx = append(idx, i)
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	imported := make(map[string]lidarr.ReleaseGroupStatus)
+	for rg, s := range app.lidarrStatuses(ctx, missing) {
+		if s.State == lidarr.StateImported && s.AlbumID > 0 {
+			imported[rg] = s
+		}
+	}
+	if len(imported) == 0 {
+		return
+	}
+
+	sections := app.scanAlbumFolders(ctx, imported)
+	if len(sections) == 0 {
+		return
+	}
+	if timeout := time.Duration(app.config.Lidarr.RescanTimeoutSeconds) * time.Second; timeout > 0 {
+		fmt.Printf("⏳ Waiting up to %s for Plex to finish scanning...\n", timeout)
+		if err := app.plexClient.WaitForScans(ctx, sections, timeout); err != nil {
+			if !errors.Is(err, plex.ErrScanTimeout) {
+				slog.Warn("plex: could not check scan progress", "err", err)
+				return
+			}
+			fmt.Println("⚠️  Plex is still scanning; matching what it has picked up so far")
+		}
+	}
+
+	var retryIdx []int
+	var retry []track.Track
+	for _, i := range idx {
+		if _, ok := imported[strings.TrimSpace(results[i].SourceTrack.MusicBrainzReleaseGroupID)]; ok {
+			retryIdx = append(retryIdx, i)
+			retry = append(retry, results[i].SourceTrack)
+		}
+	}
+	found := 0
+	for j, r := range app.plexClient.MatchSourceTracks(ctx, retry) {
+		if r.PlexTrack == nil {
+			continue
+		}
+		results[retryIdx[j]] = r
+		found++
+		fmt.Printf("✅ Plex: found %s - %s after the rescan\n", r.SourceTrack.Artist, r.SourceTrack.Name)
+	}
+	fmt.Printf("🔄 Rescan matched %d of %d track(s) Lidarr had imported\n", found, len(retry))
+}
+
+// scanAlbumFolders asks Plex to scan the folders of the imported albums and returns the sections scanned.
+// In dry-run mode it only prints the folders.
+func (app *Application) scanAlbumFolders(ctx context.Context, imported map[string]lidarr.ReleaseGroupStatus) []int {
+	var sections []int
+	seen := make(map[string]bool)
+	for _, rg := range slices.Sorted(maps.Keys(imported)) {
+		s := imported[rg]
+		folders, err := app.lidarr.AlbumFolders(ctx, s.AlbumID)
+		if err != nil {
+			slog.Warn("lidarr: could not read album folders", "album", s.AlbumTitle, "err", err)
+			continue
+		}
+		for _, f := range folders {
+			dir := app.config.Lidarr.PlexPath(f)
+			if seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			sec, ok := app.plexClient.SectionForPath(dir)
+			if !ok {
+				fmt.Printf("⚠️  Plex: %s is not in a searched library folder; check LIDARR_PLEX_PATH_MAP\n", dir)
+				continue
+			}
+			if app.config.Plex.DryRun {
+				fmt.Printf("🔄 Plex (dry-run): would scan %s in %s for %s\n", dir, sec.Label(), albumLabel(s))
+				continue
+			}
+			if err := app.plexClient.ScanPath(ctx, sec.ID, dir); err != nil {
+				slog.Warn("plex: scan request failed", "path", dir, "err", err)
+				continue
+			}
+			fmt.Printf("🔄 Plex: scanning %s in %s for %s (imported by Lidarr)\n", dir, sec.Label(), albumLabel(s))
+			if !slices.Contains(sections, sec.ID) {
+				sections = append(sections, sec.ID)
+			}
+		}
+	}
+	return sections
+}
+
+func albumLabel(s lidarr.ReleaseGroupStatus) string {
+	if s.ArtistName == "" {
+		return s.AlbumTitle
+	}
+	return s.ArtistName + " - " + s.AlbumTitle
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/lidarr"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/track"
+)
+
+func TestRescanImportedAlbums(t *testing.T) {
+	lidarrSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/queue", "/api/v1/wanted/missing":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"totalRecords": 0, "records": []interface{}{}})
+		case "/api/v1/album":
+			var out []map[string]interface{}
+			if r.URL.Query().Get("foreignAlbumId") == "rg-1" {
+				out = append(out, map[string]interface{}{"id": 9, "title": "Debut", "monitored": true,
+					"statistics": map[string]interface{}{"trackFileCount": 10, "trackCount": 10}})
+			}
+			_ = json.NewEncoder(w).Encode(out)
+		case "/api/v1/trackfile":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"path": "/music/Band/Debut/01 - Song.flac"}})
+		default:
+			t.Errorf("unexpected Lidarr request %s", r.URL.String())
+		}
+	}))
+	defer lidarrSrv.Close()
+
+	var scanned atomic.Value
+	plexSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/library/sections":
+			_, _ = fmt.Fprint(w, `<MediaContainer><Directory key="3" type="artist" title="Music"><Location id="1" path="/data/music"/></Directory></MediaContainer>`)
+		case "/library/sections/3/refresh":
+			scanned.Store(r.URL.Query().Get("path"))
+		case "/library/sections/3/search":
+			if scanned.Load() != nil {
+				_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="70" title="Song" grandparentTitle="Band" parentTitle="Debut"/></MediaContainer>`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+		default:
+			_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+		}
+	}))
+	defer plexSrv.Close()
+
+	cfg := &config.Config{
+		Plex: config.PlexConfig{URL: plexSrv.URL, Token: "tok", LibrarySections: []string{"3"}, SkipFullLibrarySearch: true},
+		Lidarr: config.LidarrConfig{URL: lidarrSrv.URL, Token: "key", RescanPlex: true, RescanTimeoutSeconds: 0,
+			PlexPathMap: []config.PathMapping{{From: "/music", To: "/data/music"}}},
+	}
+	lc, err := lidarr.NewClient(&cfg.Lidarr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := plex.NewClient(cfg)
+	if err := pc.ResolveLibrarySections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	app := &Application{config: cfg, plexClient: pc, lidarr: lc}
+
+	results := []plex.MatchResult{
+		{SourceTrack: track.Track{Name: "Song", Artist: "Band", MusicBrainzReleaseGroupID: "rg-1"}, MatchType: plex.MatchTypeNone},
+		{SourceTrack: track.Track{Name: "Other", Artist: "Band"}, MatchType: plex.MatchTypeNone},
+	}
+	app.rescanImportedAlbums(context.Background(), results)
+
+	if got, _ := scanned.Load().(string); got != "/data/music/Band/Debut" {
+		t.Errorf("scanned %q, want the mapped album folder", got)
+	}
+	if results[0].PlexTrack == nil || results[0].PlexTrack.ID != "70" {
+		t.Errorf("imported track not matched after rescan: %+v", results[0])
+	}
+	if results[1].PlexTrack != nil {
+		t.Errorf("track without a release group should not be retried: %+v", results[1])
+	}
+}
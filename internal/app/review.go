@@ -0,0 +1,281 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/internal/cliutil"
+	"github.com/grrywlsn/plexify/overrides"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/track"
+	"golang.org/x/term"
+)
+
+// reviewCandidateCount is how many Plex candidates the review prompt lists per track.
+const reviewCandidateCount = 5
+
+// reviewBand classifies a match result against PLEXIFY_AUTO_ACCEPT_PERCENT / PLEXIFY_AUTO_REJECT_PERCENT.
+type reviewBand int
+
+const (
+	bandAccept reviewBand = iota
+	bandReview
+	bandReject
+)
+
+// classifyReviewBand places r in a band by its search score, the score the match threshold was checked
+// against. Only title/artist matches and held candidates are banded; overrides, skips and misses keep
+// their outcome. A held candidate missed the match threshold, so it is never accepted without review.
+func classifyReviewBand(p config.PlexConfig, r plex.MatchResult) reviewBand {
+	if !p.ReviewBandEnabled() || r.PlexTrack == nil {
+		return bandAccept
+	}
+	if r.MatchType != plex.MatchTypeTitleArtist && r.MatchType != plex.MatchTypeReview {
+		return bandAccept
+	}
+	switch {
+	case r.Score < float64(p.AutoRejectPercent)/100:
+		return bandReject
+	case r.Score >= float64(p.AutoAcceptPercent)/100 && r.MatchType != plex.MatchTypeReview:
+		return bandAccept
+	default:
+		return bandReview
+	}
+}
+
+// reviewSearcher is the part of the Plex client the review prompt uses.
+type reviewSearcher interface {
+	SearchTracks(ctx context.Context, query string) ([]plex.PlexTrack, error)
+	TitleArtistConfidence(song track.Track, tr *plex.PlexTrack) float64
+}
+
+// reviewStats tallies review-band outcomes for the end-of-review line.
+type reviewStats struct {
+	accepted, changed, rejected int
+}
+
+// reviewer prompts for review-band matches on a terminal.
+type reviewer struct {
+	in            *bufio.Reader
+	out           io.Writer
+	plex          reviewSearcher
+	overridesFile string // non-empty: save accepted choices as overrides
+	stats         reviewStats
+}
+
+// reviewMatches applies the review band to results in place: matches above the band are kept, those below
+// it are dropped, and those inside it are reviewed on the terminal (PLEXIFY_REVIEW) or decided by
+// PLEXIFY_REVIEW_DEFAULT.
+func (app *Application) reviewMatches(ctx context.Context, results []plex.MatchResult) {
+	p := app.config.Plex
+	if !p.ReviewBandEnabled() {
+		return
+	}
+	var pending []int
+	rejected := 0
+	for i, r := range results {
+		switch classifyReviewBand(p, r) {
+		case bandReject:
+			results[i] = rejectedMatch(r)
+			rejected++
+		case bandReview:
+			pending = append(pending, i)
+		}
+	}
+	if rejected > 0 {
+		fmt.Printf("🚫 Auto-rejected %d match(es) below %d%% confidence\n", rejected, p.AutoRejectPercent)
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	interactive := p.Review && term.IsTerminal(int(os.Stdin.Fd()))
+	if !interactive {
+		if p.Review {
+			fmt.Println("⚠️  PLEXIFY_REVIEW is set but stdin is not a terminal; using PLEXIFY_REVIEW_DEFAULT")
+		}
+		applyReviewDefault(p.ReviewDefault, results, pending)
+		fmt.Printf("🧐 %d match(es) between %d%% and %d%% confidence: %s (PLEXIFY_REVIEW_DEFAULT)\n",
+			len(pending), p.AutoRejectPercent, p.AutoAcceptPercent, pastTense(p.ReviewDefault))
+		return
+	}
+
+	rv := &reviewer{in: bufio.NewReader(os.Stdin), out: os.Stdout, plex: app.plexClient}
+	if p.ReviewSaveOverrides {
+		rv.overridesFile = p.OverridesFile
+	}
+	rv.run(ctx, p, results, pending)
+}
+
+func applyReviewDefault(def string, results []plex.MatchResult, pending []int) {
+	for _, i := range pending {
+		if def == config.ReviewDefaultReject {
+			results[i] = rejectedMatch(results[i])
+		} else {
+			results[i].MatchType = plex.MatchTypeTitleArtist
+		}
+	}
+}
+
+func pastTense(def string) string {
+	if def == config.ReviewDefaultAccept {
+		return "accepted"
+	}
+	return "rejected"
+}
+
+// rejectedMatch turns r into a miss, keeping the source track and trace.
+func rejectedMatch(r plex.MatchResult) plex.MatchResult {
+	return plex.MatchResult{SourceTrack: r.SourceTrack, MatchType: plex.MatchTypeNone, Trace: r.Trace}
+}
+
+// run prompts for each pending result. Quitting applies the review default to the rest.
+func (rv *reviewer) run(ctx context.Context, p config.PlexConfig, results []plex.MatchResult, pending []int) {
+	fmt.Fprintln(rv.out, "\n"+cliutil.RepeatChar("=", cliutil.SectionWidth))
+	fmt.Fprintf(rv.out, "REVIEW (%d match(es) between %d%% and %d%% confidence)\n", len(pending), p.AutoRejectPercent, p.AutoAcceptPercent)
+	fmt.Fprintln(rv.out, cliutil.RepeatChar("=", cliutil.SectionWidth))
+	for n, i := range pending {
+		if ctx.Err() != nil || !rv.reviewOne(ctx, &results[i], n+1, len(pending)) {
+			rest := pending[n:]
+			if ctx.Err() == nil {
+				fmt.Fprintf(rv.out, "Review stopped; %d remaining match(es) %s (PLEXIFY_REVIEW_DEFAULT)\n", len(rest), pastTense(p.ReviewDefault))
+			}
+			applyReviewDefault(p.ReviewDefault, results, rest)
+			break
+		}
+	}
+	fmt.Fprintf(rv.out, "🧐 Review: %d accepted, %d changed, %d skipped\n", rv.stats.accepted, rv.stats.changed, rv.stats.rejected)
+}
+
+// reviewOne prompts for one result and updates it. It returns false when the user quits (or input ends).
+func (rv *reviewer) reviewOne(ctx context.Context, r *plex.MatchResult, n, total int) bool {
+	cands := r.ReviewCandidates(reviewCandidateCount)
+	for {
+		st := r.SourceTrack
+		fmt.Fprintf(rv.out, "\n[%d/%d] %s - %s", n, total, st.Artist, st.Name)
+		if st.Album != "" {
+			fmt.Fprintf(rv.out, " (%s)", st.Album)
+		}
+		fmt.Fprintf(rv.out, " %s\n", formatTrackDuration(st.Duration))
+		printReviewCandidates(rv.out, cands)
+		fmt.Fprint(rv.out, "[a]ccept 1, [1-9] pick, [f]ind in Plex, [s]kip, [q]uit: ")
+
+		line, ok := rv.readLine()
+		if !ok {
+			return false
+		}
+		switch cmd := strings.ToLower(line); {
+		case cmd == "" || cmd == "a":
+			if len(cands) > 0 {
+				rv.choose(r, cands[0], false)
+				return true
+			}
+		case cmd == "s":
+			*r = rejectedMatch(*r)
+			rv.stats.rejected++
+			return true
+		case cmd == "q":
+			return false
+		case cmd == "f":
+			fmt.Fprintf(rv.out, "Search Plex for [%s %s]: ", st.Name, st.Artist)
+			q, ok := rv.readLine()
+			if !ok {
+				return false
+			}
+			if q == "" {
+				q = st.Name + " " + st.Artist
+			}
+			found, err := rv.plex.SearchTracks(ctx, q)
+			if err != nil {
+				fmt.Fprintf(rv.out, "❌ Search failed: %v\n", err)
+				continue
+			}
+			if len(found) == 0 {
+				fmt.Fprintln(rv.out, "No Plex tracks found.")
+				continue
+			}
+			cands = cands[:0]
+			for i := range found {
+				tr := found[i]
+				conf := rv.plex.TitleArtistConfidence(st, &tr)
+				cands = append(cands, plex.TraceCandidate{
+					RatingKey: tr.ID, Title: tr.Title, Artist: tr.DisplayArtist(), Album: tr.Album,
+					DurationMs: tr.Duration, Scores: plex.TraceScores{Total: conf},
+				})
+				if len(cands) == 9 {
+					break
+				}
+			}
+		default:
+			if k, err := strconv.Atoi(cmd); err == nil && k >= 1 && k <= len(cands) {
+				rv.choose(r, cands[k-1], true)
+				return true
+			}
+		}
+		fmt.Fprintln(rv.out, "Please choose one of the listed options.")
+	}
+}
+
+// choose applies a picked candidate to r and optionally saves it as an override.
+func (rv *reviewer) choose(r *plex.MatchResult, c plex.TraceCandidate, picked bool) {
+	if r.PlexTrack != nil && r.PlexTrack.ID == c.RatingKey {
+		rv.stats.accepted++
+	} else {
+		r.PlexTrack = c.PlexTrack()
+		r.Confidence = c.Scores.Total
+		r.Score = c.Scores.Total
+		rv.stats.changed++
+	}
+	r.MatchType = plex.MatchTypeTitleArtist
+	if rv.overridesFile == "" {
+		return
+	}
+	e := overrides.ForTrack(r.SourceTrack)
+	e.RatingKey = c.RatingKey
+	e.Note = "accepted in review"
+	if picked {
+		e.Note = "picked in review"
+	}
+	if err := overrides.Append(rv.overridesFile, e); err != nil {
+		fmt.Fprintf(rv.out, "⚠️  Could not save override: %v\n", err)
+		return
+	}
+	fmt.Fprintf(rv.out, "📌 Saved override %s → %s\n", e.Key(), c.RatingKey)
+}
+
+func (rv *reviewer) readLine() (string, bool) {
+	line, err := rv.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", false
+	}
+	return strings.TrimSpace(line), true
+}
+
+func printReviewCandidates(w io.Writer, cands []plex.TraceCandidate) {
+	if len(cands) == 0 {
+		fmt.Fprintln(w, "    (no Plex candidates; use [f]ind)")
+		return
+	}
+	for i, c := range cands {
+		fmt.Fprintf(w, "  %d) %3.0f%%  %s - %s", i+1, c.Scores.Total*100, c.Artist, c.Title)
+		if c.Album != "" {
+			fmt.Fprintf(w, " (%s)", c.Album)
+		}
+		fmt.Fprintf(w, " %s [key %s]\n", formatTrackDuration(c.DurationMs), c.RatingKey)
+	}
+}
+
+// formatTrackDuration renders milliseconds as m:ss, or an empty string when unknown.
+func formatTrackDuration(ms int) string {
+	if ms <= 0 {
+		return ""
+	}
+	s := ms / 1000
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
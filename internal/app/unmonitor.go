@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/grrywlsn/plexify/internal/cliutil"
+	"github.com/grrywlsn/plexify/lidarr"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// unmonitorUnwantedAlbums is the LIDARR_UNMONITOR pass. Albums Plexify asked Lidarr for (the release groups
+// recorded in LIDARR_STATE_FILE) that no synced playlist references any more, matched or missing, are
+// unmonitored. Files are never deleted. The pass is skipped when a playlist failed, since the
+// referenced set would be incomplete.
+func (app *Application) unmonitorUnwantedAlbums(ctx context.Context) {
+	if app.lidarr == nil || !app.config.LidarrEnabled() || !app.config.Lidarr.Unmonitor || app.config.Plex.Explain != "" || app.partialRun {
+		return
+	}
+	fmt.Println()
+	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
+	fmt.Println("LIDARR: UNMONITOR ALBUMS NO PLAYLIST WANTS")
+	fmt.Println(cliutil.RepeatChar("=", cliutil.SectionWidth))
+	if n := app.lidarrQueue.failed; n > 0 {
+		fmt.Printf("⚠️  Skipped: %d playlist(s) could not be synced, so the albums they want are unknown\n", n)
+		return
+	}
+
+	albums, err := app.lidarr.RequestedMonitoredAlbums(ctx)
+	if err != nil {
+		slog.Error("lidarr: list requested albums", "err", err)
+		fmt.Printf("❌ Lidarr: could not list the albums Plexify requested: %v\n", err)
+		return
+	}
+	unwanted, kept := app.unwantedAlbums(ctx, albums)
+	if len(unwanted) == 0 {
+		fmt.Println("✅ Every monitored album Plexify requested is still wanted by a playlist")
+		return
+	}
+	if kept > 0 {
+		fmt.Printf("ℹ️  Kept %d album(s) by artists with playlist tracks whose release group is unknown\n", kept)
+	}
+
+	if app.config.Plex.DryRun {
+		fmt.Printf("Lidarr (dry-run): would unmonitor %d album(s):\n", len(unwanted))
+		for _, a := range unwanted {
+			fmt.Printf("  - %s - %s (%s)\n", a.ArtistName, a.Title, a.ReleaseGroupID)
+		}
+		return
+	}
+	if err := app.lidarr.UnmonitorAlbums(ctx, unwanted); err != nil {
+		slog.Error("lidarr: unmonitor albums", "err", err)
+		fmt.Printf("❌ Lidarr: could not unmonitor albums: %v\n", err)
+		return
+	}
+	for _, a := range unwanted {
+		fmt.Printf("🔕 Lidarr: unmonitored %s - %s (%s)\n", a.ArtistName, a.Title, a.ReleaseGroupID)
+	}
+}
+
+// unwantedAlbums returns the albums whose release group no referenced track is on, and how many others
+// were kept only because their artist has referenced tracks with no known release group. Those tracks'
+// release groups are looked up on MusicBrainz first; only tracks by the albums' artists are looked up.
+func (app *Application) unwantedAlbums(ctx context.Context, albums []lidarr.RequestedAlbum) ([]lidarr.RequestedAlbum, int) {
+	if len(albums) == 0 {
+		return nil, 0
+	}
+	artists := make(map[string]bool)
+	for _, a := range albums {
+		for _, k := range albumArtistKeys(a) {
+			artists[k] = true
+		}
+	}
+
+	wanted := make(map[string]bool)
+	var lookup []plex.MatchResult
+	for _, st := range app.lidarrQueue.referenced {
+		if rg := strings.TrimSpace(st.MusicBrainzReleaseGroupID); rg != "" {
+			wanted[rg] = true
+			continue
+		}
+		for _, k := range trackArtistKeys(st) {
+			if artists[k] {
+				lookup = append(lookup, plex.MatchResult{SourceTrack: st})
+				break
+			}
+		}
+	}
+	protected := make(map[string]bool) // artists with a referenced track on an unknown release group
+	for _, r := range app.resolveMissingReleaseGroups(ctx, lookup) {
+		if rg := strings.TrimSpace(r.SourceTrack.MusicBrainzReleaseGroupID); rg != "" {
+			wanted[rg] = true
+			continue
+		}
+		for _, k := range trackArtistKeys(r.SourceTrack) {
+			protected[k] = true
+		}
+	}
+
+	var out []lidarr.RequestedAlbum
+	kept := 0
+	for _, a := range albums {
+		if wanted[a.ReleaseGroupID] {
+			continue
+		}
+		isProtected := false
+		for _, k := range albumArtistKeys(a) {
+			isProtected = isProtected || protected[k]
+		}
+		if isProtected {
+			kept++
+			continue
+		}
+		out = append(out, a)
+	}
+	return out, kept
+}
+
+// trackArtistKeys and albumArtistKeys identify an artist by MusicBrainz id and by lowercased name, so
+// tracks without artist MBIDs still match their Lidarr artist.
+func trackArtistKeys(st track.Track) []string {
+	var keys []string
+	for _, id := range st.MusicBrainzArtistIDs() {
+		keys = append(keys, "mbid:"+id)
+	}
+	for _, name := range append([]string{st.Artist}, st.MusicBrainzArtistCredits...) {
+		if n := strings.ToLower(strings.TrimSpace(name)); n != "" {
+			keys = append(keys, "name:"+n)
+		}
+	}
+	return keys
+}
+
+func albumArtistKeys(a lidarr.RequestedAlbum) []string {
+	var keys []string
+	if a.ArtistMBID != "" {
+		keys = append(keys, "mbid:"+a.ArtistMBID)
+	}
+	if n := strings.ToLower(strings.TrimSpace(a.ArtistName)); n != "" {
+		keys = append(keys, "name:"+n)
+	}
+	return keys
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/lidarr"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// maxWebhookBody bounds a Lidarr webhook request body; import payloads list track files but stay small.
+const maxWebhookBody = 4 << 20
+
+// importedAlbum is an album Lidarr reported as imported.
+type importedAlbum struct {
+	lidarrID       int
+	releaseGroupID string
+	title          string
+	artistName     string
+	artistMBID     string
+}
+
+// WebhookServer receives Lidarr import webhooks and re-syncs the playlists with tracks on the imported
+// albums. Imports are batched: each sync waits PLEXIFY_WEBHOOK_DELAY_SECONDS after the first pending import,
+// then handles everything received by then with a fresh Application.
+type WebhookServer struct {
+	cfg   *config.Config
+	debug bool
+	delay time.Duration
+	// sync re-syncs playlists for a batch of imports; replaced in tests.
+	sync func(ctx context.Context, albums []importedAlbum) error
+
+	mu      sync.Mutex
+	pending []importedAlbum
+	wake    chan struct{}
+}
+
+// NewWebhookServer builds the "plexify serve" handler. Call Run to process imports.
+func NewWebhookServer(cfg *config.Config, debug bool) *WebhookServer {
+	s := &WebhookServer{
+		cfg:   cfg,
+		debug: debug,
+		delay: time.Duration(cfg.Webhook.DelaySeconds) * time.Second,
+		wake:  make(chan struct{}, 1),
+	}
+	s.sync = s.syncWithNewApplication
+	return s
+}
+
+// ServeHTTP accepts POSTed Lidarr webhooks. Import events are queued and answered with 202; other events
+// (including Lidarr's connection test) get 200 and are otherwise ignored.
+func (s *WebhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="plexify"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	ev, err := lidarr.ParseWebhook(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !ev.Imported() {
+		slog.Info("webhook: ignoring Lidarr event", "event", ev.EventType)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	s.mu.Lock()
+	for _, a := range ev.Albums {
+		s.pending = append(s.pending, importedAlbum{
+			lidarrID:       a.ID,
+			releaseGroupID: a.ReleaseGroupID,
+			title:          a.Title,
+			artistName:     ev.ArtistName,
+			artistMBID:     ev.ArtistMBID,
+		})
+		fmt.Printf("📥 Lidarr imported %s - %s\n", ev.ArtistName, a.Title)
+	}
+	s.mu.Unlock()
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *WebhookServer) authorized(r *http.Request) bool {
+	if s.cfg.Webhook.Password == "" {
+		return true
+	}
+	user, pass, ok := r.BasicAuth()
+	return ok &&
+		subtle.ConstantTimeCompare([]byte(user), []byte(s.cfg.Webhook.Username)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(pass), []byte(s.cfg.Webhook.Password)) == 1
+}
+
+// Run processes queued imports until ctx is done. Syncs run one at a time.
+func (s *WebhookServer) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		}
+		if s.delay > 0 {
+			fmt.Printf("⏳ Re-syncing in %s, once Plex has had time to scan...\n", s.delay)
+			t := time.NewTimer(s.delay)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return
+			case <-t.C:
+			}
+		}
+		s.mu.Lock()
+		batch := s.pending
+		s.pending = nil
+		s.mu.Unlock()
+		if len(batch) == 0 {
+			continue
+		}
+		if err := s.sync(ctx, batch); err != nil {
+			slog.Error("webhook: re-sync failed", "err", err)
+		}
+	}
+}
+
+func (s *WebhookServer) syncWithNewApplication(ctx context.Context, albums []importedAlbum) error {
+	a, err := NewApplication(s.cfg, s.debug)
+	if err != nil {
+		return err
+	}
+	return a.syncImported(ctx, albums)
+}
+
+// syncImported matches and syncs only the playlists still missing tracks on the imported albums: tracks
+// carrying one of their release group ids, or tracks by the same artist whose release group MusicBrainz
+// resolves to one of them. Playlists that already hold all of those tracks are left alone.
+func (app *Application) syncImported(ctx context.Context, albums []importedAlbum) error {
+	groups := make(map[string]bool)
+	artists := make(map[string]bool)
+	for _, a := range albums {
+		rg := a.releaseGroupID
+		if rg == "" && a.lidarrID > 0 && app.lidarr != nil {
+			var err error
+			if rg, err = app.lidarr.AlbumReleaseGroupID(ctx, a.lidarrID); err != nil {
+				slog.Warn("lidarr: could not look up imported album", "album", a.title, "err", err)
+			}
+		}
+		if rg != "" {
+			groups[rg] = true
+		}
+		for _, k := range albumArtistKeys(lidarr.RequestedAlbum{ArtistName: a.artistName, ArtistMBID: a.artistMBID}) {
+			artists[k] = true
+		}
+	}
+	if len(groups) == 0 {
+		fmt.Println("ℹ️  The imported album(s) have no MusicBrainz release group; nothing to re-sync")
+		return nil
+	}
+
+	if err := app.prepare(ctx); err != nil {
+		return err
+	}
+	metas, err := app.getPlaylistMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to get playlist metadata: %w", err)
+	}
+	var affected []PlaylistMeta
+	for _, meta := range metas {
+		pl, err := app.musicSocial.GetPlaylist(meta.ID)
+		if err != nil {
+			slog.Warn("failed to fetch playlist", "playlist", meta.ID, "err", err)
+			continue
+		}
+		var onImported []track.Track
+		var byArtist []plex.MatchResult
+		for _, st := range pl.Tracks {
+			if rg := strings.TrimSpace(st.MusicBrainzReleaseGroupID); rg != "" {
+				if groups[rg] {
+					onImported = append(onImported, st)
+				}
+				continue
+			}
+			for _, k := range trackArtistKeys(st) {
+				if artists[k] {
+					byArtist = append(byArtist, plex.MatchResult{SourceTrack: st})
+					break
+				}
+			}
+		}
+		for _, r := range app.resolveMissingReleaseGroups(ctx, byArtist) {
+			if groups[r.SourceTrack.MusicBrainzReleaseGroupID] {
+				onImported = append(onImported, r.SourceTrack)
+			}
+		}
+		if len(onImported) > 0 && app.playlistMissesAny(ctx, meta, onImported) {
+			affected = append(affected, meta)
+		}
+	}
+	if len(affected) == 0 {
+		fmt.Println("ℹ️  No synced playlist is missing tracks on the imported album(s)")
+		return nil
+	}
+
+	fmt.Printf("🔁 Re-syncing %d playlist(s) missing tracks on the imported album(s)\n\n", len(affected))
+	app.partialRun = true
+	return app.processPlaylists(ctx, affected)
+}
+
+// playlistMissesAny matches songs against Plex and reports whether any of them is absent from the
+// playlist's Plex copy: not found in the library yet (the import may still need a scan), or found but
+// not in the playlist. When the Plex playlist cannot be read the playlist is treated as missing them.
+// The match results are kept in app.prematched for the re-sync that follows.
+func (app *Application) playlistMissesAny(ctx context.Context, meta PlaylistMeta, songs []track.Track) bool {
+	syncCtx, err := app.playlistUserContext(ctx, meta)
+	if err != nil {
+		return true
+	}
+	existing, err := app.plexClient.FindPlaylistByTitle(syncCtx, meta.Name)
+	if err != nil || existing == nil {
+		return true
+	}
+	items, err := app.plexClient.GetPlaylistItems(syncCtx, existing.ID)
+	if err != nil {
+		return true
+	}
+	results := app.plexClient.MatchSourceTracks(ctx, songs)
+	for _, r := range results {
+		if id := r.SourceTrack.ID; id != "" {
+			if app.prematched == nil {
+				app.prematched = make(map[string]plex.MatchResult)
+			}
+			app.prematched[id] = r
+		}
+	}
+	return missingFromPlaylist(results, items)
+}
+
+// matchSourceTracks is MatchSourceTracks that reuses the results in app.prematched and searches Plex
+// only for the other songs. Results keep the order of songs.
+func (app *Application) matchSourceTracks(ctx context.Context, songs []track.Track) []plex.MatchResult {
+	if len(app.prematched) == 0 {
+		return app.plexClient.MatchSourceTracks(ctx, songs)
+	}
+	results := make([]plex.MatchResult, len(songs))
+	var rest []track.Track
+	var restIdx []int
+	for i, s := range songs {
+		if r, ok := app.prematched[s.ID]; ok && s.ID != "" {
+			results[i] = r
+			continue
+		}
+		rest = append(rest, s)
+		restIdx = append(restIdx, i)
+	}
+	if len(rest) > 0 {
+		for j, r := range app.plexClient.MatchSourceTracks(ctx, rest) {
+			results[restIdx[j]] = r
+		}
+	}
+	return results
+}
+
+// missingFromPlaylist reports whether a non-skipped result is unmatched or matched to a track not in items.
+func missingFromPlaylist(results []plex.MatchResult, items []plex.PlexTrack) bool {
+	have := make(map[string]bool, len(items))
+	for _, it := range items {
+		have[it.ID] = true
+	}
+	for _, r := range results {
+		if r.MatchType == plex.MatchTypeSkipped {
+			continue
+		}
+		if r.PlexTrack == nil || !have[r.PlexTrack.ID] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/plex"
+	"github.com/grrywlsn/plexify/track"
+)
+
+func TestWebhookServer(t *testing.T) {
+	cfg := &config.Config{Webhook: config.WebhookConfig{Username: "lidarr", Password: "secret"}}
+	s := NewWebhookServer(cfg, false)
+	batches := make(chan []importedAlbum, 1)
+	s.sync = func(_ context.Context, albums []importedAlbum) error {
+		batches <- albums
+		return nil
+	}
+
+	post := func(body string, auth bool) int {
+		req := httptest.NewRequest(http.MethodPost, "/lidarr", strings.NewReader(body))
+		if auth {
+			req.SetBasicAuth("lidarr", "secret")
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	imported := `{"eventType": "Download", "artist": {"name": "Band", "mbId": "mb-band"}, "albums": [{"id": 9, "title": "Debut", "mbId": "rg-1"}]}`
+
+	if code := post(imported, false); code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated POST = %d, want 401", code)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lidarr", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET = %d, want 405", rec.Code)
+	}
+	if code := post(`{"eventType": "Test"}`, true); code != http.StatusOK {
+		t.Errorf("test event = %d, want 200", code)
+	}
+	if code := post(`{`, true); code != http.StatusBadRequest {
+		t.Errorf("malformed body = %d, want 400", code)
+	}
+	// Two imports before the worker wakes are synced together.
+	if code := post(imported, true); code != http.StatusAccepted {
+		t.Errorf("import = %d, want 202", code)
+	}
+	if code := post(strings.ReplaceAll(imported, "rg-1", "rg-2"), true); code != http.StatusAccepted {
+		t.Errorf("second import = %d, want 202", code)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+	select {
+	case got := <-batches:
+		if len(got) != 2 || got[0].releaseGroupID != "rg-1" || got[1].releaseGroupID != "rg-2" || got[0].artistMBID != "mb-band" {
+			t.Errorf("batch = %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("imports were not synced")
+	}
+	select {
+	case got := <-batches:
+		t.Errorf("unexpected second batch %+v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMissingFromPlaylist(t *testing.T) {
+	items := []plex.PlexTrack{{ID: "1"}, {ID: "2"}}
+	for name, tc := range map[string]struct {
+		results []plex.MatchResult
+		want    bool
+	}{
+		"all in playlist":      {[]plex.MatchResult{{PlexTrack: &plex.PlexTrack{ID: "1"}}, {PlexTrack: &plex.PlexTrack{ID: "2"}}}, false},
+		"skipped are ignored":  {[]plex.MatchResult{{PlexTrack: &plex.PlexTrack{ID: "1"}}, {MatchType: plex.MatchTypeSkipped}}, false},
+		"matched, not in list": {[]plex.MatchResult{{PlexTrack: &plex.PlexTrack{ID: "1"}}, {PlexTrack: &plex.PlexTrack{ID: "3"}}}, true},
+		"not in the library":   {[]plex.MatchResult{{PlexTrack: &plex.PlexTrack{ID: "1"}}, {MatchType: plex.MatchTypeNone}}, true},
+	} {
+		if got := missingFromPlaylist(tc.results, items); got != tc.want {
+			t.Errorf("%s: missingFromPlaylist = %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestMatchSourceTracks_reusesPrematched(t *testing.T) {
+	var queries []string
+	plexSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("query"); q != "" {
+			queries = append(queries, q)
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer plexSrv.Close()
+	cfg := &config.Config{Plex: config.PlexConfig{URL: plexSrv.URL, Token: "tok", LibrarySectionID: 1, SkipFullLibrarySearch: true, SkipAlbumFallback: true}}
+	known := plex.MatchResult{SourceTrack: track.Track{ID: "pl:1", Name: "Known", Artist: "Band"}, PlexTrack: &plex.PlexTrack{ID: "7"}, MatchType: plex.MatchTypeTitleArtist}
+	app := &Application{config: cfg, plexClient: plex.NewClient(cfg), prematched: map[string]plex.MatchResult{"pl:1": known}}
+
+	songs := []track.Track{known.SourceTrack, {ID: "pl:2", Name: "Fresh", Artist: "Band"}}
+	results := app.matchSourceTracks(context.Background(), songs)
+	if len(results) != 2 || results[0].PlexTrack == nil || results[0].PlexTrack.ID != "7" || results[1].SourceTrack.ID != "pl:2" {
+		t.Fatalf("results = %+v", results)
+	}
+	for _, q := range queries {
+		if strings.Contains(q, "Known") {
+			t.Errorf("prematched track searched again: %q", q)
+		}
+	}
+	if len(queries) == 0 {
+		t.Error("the other track was not searched")
+	}
+}
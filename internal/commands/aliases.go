@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grrywlsn/plexify/aliases"
+	"github.com/grrywlsn/plexify/config"
+)
+
+const aliasesUsage = `Usage: plexify aliases <command> [flags]
+
+Commands:
+  import -out FILE DUMP    convert a MusicBrainz JSON artist dump (mbdump/artist, one JSON
+                           object per line; .gz accepted) into an artist alias file
+  lookup [-files LIST] NAME
+                           print the aliases of NAME (files default to PLEXIFY_ARTIST_ALIASES)
+`
+
+// Aliases implements "plexify aliases import|lookup" and returns the process exit code.
+func Aliases(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, aliasesUsage)
+		return 2
+	}
+	switch args[0] {
+	case "import":
+		return aliasesImport(args[1:])
+	case "lookup":
+		return aliasesLookup(args[1:])
+	default:
+		fmt.Fprint(os.Stderr, aliasesUsage)
+		return 2
+	}
+}
+
+func aliasesImport(args []string) int {
+	fs := flag.NewFlagSet("aliases import", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, aliasesUsage) }
+	out := fs.String("out", "", "Alias file to write")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || strings.TrimSpace(*out) == "" {
+		fmt.Fprint(os.Stderr, aliasesUsage)
+		return 2
+	}
+	dump := fs.Arg(0)
+
+	in, err := os.Open(dump)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not open dump: %v\n", err)
+		return 1
+	}
+	defer in.Close()
+	var r io.Reader = in
+	if strings.EqualFold(filepath.Ext(dump), ".gz") {
+		gz, err := gzip.NewReader(in)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Could not read %s: %v\n", dump, err)
+			return 1
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	// Write next to the target and rename so an interrupted import never leaves a truncated alias file.
+	tmp, err := os.CreateTemp(filepath.Dir(*out), ".aliases-*.json")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not create alias file: %v\n", err)
+		return 1
+	}
+	defer os.Remove(tmp.Name())
+	st, err := aliases.Import(r, tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), *out)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Import failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("📇 Read %d artists; wrote %d alias groups to %s\n", st.Artists, st.Written, *out)
+	return 0
+}
+
+func aliasesLookup(args []string) int {
+	fs := flag.NewFlagSet("aliases lookup", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, aliasesUsage) }
+	files := fs.String("files", "", "Comma-separated alias files (default PLEXIFY_ARTIST_ALIASES)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	name := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(name) == "" {
+		fmt.Fprint(os.Stderr, aliasesUsage)
+		return 2
+	}
+	list := strings.TrimSpace(*files)
+	if list == "" {
+		list = config.EnvValue("PLEXIFY_ARTIST_ALIASES")
+	}
+	if list == "" {
+		fmt.Fprintln(os.Stderr, "❌ No alias files: pass -files or set PLEXIFY_ARTIST_ALIASES")
+		return 1
+	}
+	set, err := aliases.Load(strings.Split(list, ",")...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not load artist aliases: %v\n", err)
+		return 1
+	}
+	names := set.Names(name)
+	if len(names) == 0 {
+		fmt.Printf("No aliases for %q\n", name)
+		return 0
+	}
+	fmt.Printf("%s\n", name)
+	for _, n := range names {
+		fmt.Printf("  = %s\n", n)
+	}
+	return 0
+}
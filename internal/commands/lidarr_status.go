@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/internal/app"
+)
+
+const lidarrStatusUsage = `Usage: plexify lidarr-status [flags]
+
+Matches the synced playlists against Plex and, for every track still missing, shows where its album
+stands in Lidarr: queued (with download progress), grabbed, imported but not yet in Plex, wanted with
+no release found, or not in Lidarr. Nothing is changed in Plex or Lidarr. Settings come from the
+environment and .env as for a normal run.
+
+Flags:
+  -playlist ID    only report this source playlist
+`
+
+// LidarrStatus implements "plexify lidarr-status" and returns the process exit code.
+func LidarrStatus(args []string) int {
+	fs := flag.NewFlagSet("lidarr-status", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, lidarrStatusUsage) }
+	playlistID := fs.String("playlist", "", "Only report this source playlist id")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Configuration Error:\n%s\n", err)
+		return 1
+	}
+	a, err := app.NewApplication(cfg, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	if err := a.LidarrStatus(context.Background(), *playlistID); err != nil {
+		if errors.Is(err, app.ErrNoPlaylists) {
+			app.PrintNoPlaylistsMessage()
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/plextv"
+	"golang.org/x/term"
+)
+
+const loginUsage = `Usage: plexify login [flags]
+
+Signs in to Plex with a PIN code (no need to copy a token out of the browser), picks a server and
+its best connection, and writes PLEX_URL, PLEX_TOKEN, PLEX_SERVER_ID and PLEXIFY_CLIENT_IDENTIFIER to
+the env file. A PLEXIFY_CLIENT_IDENTIFIER already in that file is reused.
+
+Flags:
+  -env-file FILE      file to update (default .env)
+  -server NAME        server name or machine identifier, when the account has several servers
+  -plex-tv-url URL    plex.tv API base (default PLEX_TV_URL, env or the env file, else https://plex.tv)
+`
+
+// Login implements "plexify login" and returns the process exit code.
+func Login(args []string) int {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, loginUsage) }
+	envFile := fs.String("env-file", ".env", "File to write PLEX_URL, PLEX_TOKEN, PLEX_SERVER_ID and PLEXIFY_CLIENT_IDENTIFIER to")
+	serverName := fs.String("server", "", "Server name or machine identifier")
+	tvURL := fs.String("plex-tv-url", "", "plex.tv API base URL (default PLEX_TV_URL)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 || strings.TrimSpace(*envFile) == "" {
+		fmt.Fprint(os.Stderr, loginUsage)
+		return 2
+	}
+
+	base := strings.TrimSpace(*tvURL)
+	if base == "" {
+		base = config.EnvFileValue(*envFile, "PLEX_TV_URL")
+	}
+	if base == "" {
+		base = config.DefaultPlexTVURL
+	}
+	base, err := config.NormalizePlexTVURL(base)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ PLEX_TV_URL: %v\n", err)
+		return 2
+	}
+	clientID := config.EnvFileValue(*envFile, "PLEXIFY_CLIENT_IDENTIFIER")
+	if clientID == "" {
+		clientID = newClientIdentifier()
+	}
+	tv, err := plextv.NewClient(base, clientID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	pin, err := tv.CreatePIN(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not start Plex sign-in: %v\n", err)
+		return 1
+	}
+	fmt.Printf("🔑 Open %s and enter the code: %s\n", tv.LinkURL(), pin.Code)
+	fmt.Println("⏳ Waiting for you to sign in (Ctrl-C to cancel)...")
+	token, err := tv.WaitForToken(ctx, pin, plextv.DefaultPollInterval)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Sign-in did not complete: %v\n", err)
+		return 1
+	}
+	fmt.Println("✅ Signed in to Plex")
+
+	servers, err := tv.Servers(ctx, token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not list Plex servers: %v\n", err)
+		return 1
+	}
+	server, err := chooseServer(servers, *serverName, os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	serverToken := server.AccessToken
+	if serverToken == "" {
+		serverToken = token
+	}
+	conn, err := tv.BestConnection(ctx, server, serverToken)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	fmt.Printf("🖥️  Server %s via %s\n", server.Name, conn.URI)
+
+	err = config.UpdateEnvFile(*envFile, map[string]string{
+		"PLEX_URL":                  conn.URI,
+		"PLEX_TOKEN":                serverToken,
+		"PLEX_SERVER_ID":            server.ClientIdentifier,
+		"PLEXIFY_CLIENT_IDENTIFIER": clientID,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	fmt.Printf("📝 Wrote PLEX_URL, PLEX_TOKEN, PLEX_SERVER_ID and PLEXIFY_CLIENT_IDENTIFIER to %s\n", *envFile)
+	return 0
+}
+
+// chooseServer picks the server named by want (name or machine identifier), the only server, or asks on
+// the terminal when there are several.
+func chooseServer(servers []plextv.Resource, want string, in *os.File, out io.Writer) (plextv.Resource, error) {
+	if len(servers) == 0 {
+		return plextv.Resource{}, fmt.Errorf("no Plex Media Server is available to this account")
+	}
+	if want = strings.TrimSpace(want); want != "" {
+		for _, s := range servers {
+			if strings.EqualFold(s.Name, want) || s.ClientIdentifier == want {
+				return s, nil
+			}
+		}
+		return plextv.Resource{}, fmt.Errorf("no server named %q; available: %s", want, serverNames(servers))
+	}
+	if len(servers) == 1 {
+		return servers[0], nil
+	}
+	if !term.IsTerminal(int(in.Fd())) {
+		return plextv.Resource{}, fmt.Errorf("this account has %d servers; pass -server with one of: %s", len(servers), serverNames(servers))
+	}
+	for i, s := range servers {
+		owner := "shared"
+		if s.Owned {
+			owner = "owned"
+		}
+		fmt.Fprintf(out, "  %d) %s (%s, %s)\n", i+1, s.Name, owner, s.ClientIdentifier)
+	}
+	r := bufio.NewReader(in)
+	for {
+		fmt.Fprintf(out, "Choose a server [1-%d]: ", len(servers))
+		line, err := r.ReadString('\n')
+		if n, convErr := strconv.Atoi(strings.TrimSpace(line)); convErr == nil && n >= 1 && n <= len(servers) {
+			return servers[n-1], nil
+		}
+		if err != nil {
+			return plextv.Resource{}, fmt.Errorf("no server chosen")
+		}
+	}
+}
+
+func serverNames(servers []plextv.Resource) string {
+	names := make([]string, len(servers))
+	for i, s := range servers {
+		names[i] = fmt.Sprintf("%q", s.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// newClientIdentifier returns a random X-Plex-Client-Identifier; login saves it so later logins reuse
+// the same authorized device.
+func newClientIdentifier() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "plexify-" + hex.EncodeToString(b)
+}
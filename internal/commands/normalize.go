@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/normrules"
+	"github.com/grrywlsn/plexify/plex"
+)
+
+const normalizeUsage = `Usage: plexify normalize [flags] "string"
+
+Shows how a title, artist or album is rewritten by each built-in cleanup and by each
+user-defined normalization rule.
+
+Flags:
+  -scope title|artist|album   field the string belongs to (default title)
+  -rules FILE                 normalization rules file (default PLEXIFY_NORMALIZATION_RULES, env or .env)
+`
+
+// Normalize implements "plexify normalize" and returns the process exit code.
+func Normalize(args []string) int {
+	fs := flag.NewFlagSet("normalize", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, normalizeUsage) }
+	scopeFlag := fs.String("scope", "title", "title, artist or album")
+	file := fs.String("rules", "", "Normalization rules file (default PLEXIFY_NORMALIZATION_RULES)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	input := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(input) == "" {
+		fmt.Fprint(os.Stderr, normalizeUsage)
+		return 2
+	}
+	scope, err := normrules.ParseScope(*scopeFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	path := strings.TrimSpace(*file)
+	if path == "" {
+		path = config.EnvValue("PLEXIFY_NORMALIZATION_RULES")
+	}
+	rules, err := normrules.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not load normalization rules: %v\n", err)
+		return 1
+	}
+
+	printNormalization(os.Stdout, scope, input, path, rules)
+	return 0
+}
+
+func printNormalization(w io.Writer, scope normrules.Scope, input, path string, rules *normrules.Set) {
+	fmt.Fprintf(w, "Input (%s): %q\n", scope, input)
+
+	fmt.Fprintln(w, "\nBuilt-in cleanups (each applied to the input):")
+	for _, st := range plex.BuiltinNormalizations(scope, input) {
+		fmt.Fprintf(w, "  %-32s %s\n", st.Name, describeChange(input, st.Output))
+	}
+
+	fmt.Fprintln(w)
+	steps := rules.Trace(scope, input)
+	switch {
+	case path == "":
+		fmt.Fprintln(w, "User rules: none (set PLEXIFY_NORMALIZATION_RULES or pass -rules)")
+		return
+	case len(steps) == 0:
+		fmt.Fprintf(w, "User rules (%s): no %s rules\n", path, scope)
+		return
+	}
+	fmt.Fprintf(w, "User rules (%s, applied in order):\n", path)
+	for i, st := range steps {
+		fmt.Fprintf(w, "  %d. %-29s %s\n", i+1, st.Rule, describeChange(st.Before, st.After))
+	}
+	fmt.Fprintf(w, "Result: %q\n", rules.Apply(scope, input))
+}
+
+func describeChange(before, after string) string {
+	if before == after {
+		return "(no change)"
+	}
+	return fmt.Sprintf("%q", after)
+}
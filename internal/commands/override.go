@@ -0,0 +1,76 @@
+// Package commands implements plexify subcommands (e.g. "plexify override add") that run instead of a playlist sync.
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/overrides"
+)
+
+const overrideUsage = `Usage: plexify override add [flags]
+
+Appends (or replaces) one entry in the manual match overrides file.
+
+Source key (exactly one):
+  -source-id pl_abc:3      music-social track id ("{playlist id}:{position}")
+  -isrc USXXX1234567
+  -mbid <recording mbid>
+  -track "Artist – Title"
+
+Action (exactly one):
+  -rating-key 12345        pin to this Plex track
+  -plex-artist/-plex-title/-plex-album   search Plex with these values instead
+  -skip                    leave the track out of the playlist
+
+The file defaults to PLEXIFY_OVERRIDES_FILE (env or .env).
+`
+
+// Override implements "plexify override add" and returns the process exit code.
+func Override(args []string) int {
+	if len(args) == 0 || args[0] != "add" {
+		fmt.Fprint(os.Stderr, overrideUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("override add", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, overrideUsage) }
+	file := fs.String("file", "", "Overrides file (default PLEXIFY_OVERRIDES_FILE)")
+	var e overrides.Entry
+	var lookup overrides.Lookup
+	fs.StringVar(&e.SourceID, "source-id", "", "music-social track id")
+	fs.StringVar(&e.ISRC, "isrc", "", "ISRC")
+	fs.StringVar(&e.MBID, "mbid", "", "MusicBrainz recording id")
+	fs.StringVar(&e.Track, "track", "", `"Artist – Title"`)
+	fs.StringVar(&e.RatingKey, "rating-key", "", "Plex rating key to pin")
+	fs.StringVar(&lookup.Artist, "plex-artist", "", "Plex artist to search for")
+	fs.StringVar(&lookup.Title, "plex-title", "", "Plex title to search for")
+	fs.StringVar(&lookup.Album, "plex-album", "", "Plex album to search for")
+	fs.BoolVar(&e.Skip, "skip", false, "Skip this source track")
+	fs.StringVar(&e.Note, "note", "", "Free-form note stored with the entry")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if lookup != (overrides.Lookup{}) {
+		e.Plex = &lookup
+	}
+
+	path := strings.TrimSpace(*file)
+	if path == "" {
+		path = config.EnvValue("PLEXIFY_OVERRIDES_FILE")
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "❌ No overrides file: pass -file or set PLEXIFY_OVERRIDES_FILE")
+		return 1
+	}
+
+	if err := overrides.Append(path, e); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Could not add override: %v\n", err)
+		return 1
+	}
+	fmt.Printf("📌 Saved override %s (%s) to %s\n", e.Key(), e.Action(), path)
+	return 0
+}
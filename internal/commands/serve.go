@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/internal/app"
+)
+
+const serveUsage = `Usage: plexify serve [flags]
+
+Listens for Lidarr webhooks and, when Lidarr imports an album, re-syncs only the playlists with tracks
+on it, so newly downloaded music shows up without waiting for the next full run. Add a Webhook
+connection in Lidarr (Settings → Connect) pointing at http://<host>:8787/lidarr with "On Release
+Import" enabled. Settings come from the environment and .env as for a normal run.
+
+Flags:
+  -listen ADDR    address to listen on (default PLEXIFY_WEBHOOK_LISTEN, ":8787")
+  -delay N        seconds to wait after an import before re-syncing (default PLEXIFY_WEBHOOK_DELAY_SECONDS, 30)
+  -insecure-no-auth
+                  start without PLEXIFY_WEBHOOK_PASSWORD and accept any request
+                  (default PLEXIFY_WEBHOOK_ALLOW_UNAUTHENTICATED)
+  -debug          enable debug logging
+`
+
+// Serve implements "plexify serve" and returns the process exit code.
+func Serve(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, serveUsage) }
+	listen := fs.String("listen", "", "Address to listen on")
+	delay := fs.Int("delay", -1, "Seconds to wait after an import before re-syncing")
+	noAuth := fs.Bool("insecure-no-auth", false, "Start without PLEXIFY_WEBHOOK_PASSWORD and accept any request")
+	debug := fs.Bool("debug", false, "Enable debug logging")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Configuration Error:\n%s\n", err)
+		return 1
+	}
+	if *listen != "" {
+		cfg.Webhook.Listen = *listen
+	}
+	if *delay >= 0 {
+		cfg.Webhook.DelaySeconds = *delay
+	}
+	if *noAuth {
+		cfg.Webhook.AllowUnauthenticated = true
+	}
+	if cfg.Webhook.Password == "" && !cfg.Webhook.AllowUnauthenticated {
+		fmt.Fprintln(os.Stderr, "❌ PLEXIFY_WEBHOOK_PASSWORD is not set. Set it to the password in Lidarr's webhook connection, "+
+			"or pass -insecure-no-auth (PLEXIFY_WEBHOOK_ALLOW_UNAUTHENTICATED=true) to accept unauthenticated requests.")
+		return 1
+	}
+	if *debug {
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	}
+	// Fail fast on settings a sync would reject, rather than on the first webhook.
+	if _, err := app.NewApplication(cfg, *debug); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	hook := app.NewWebhookServer(cfg, *debug)
+	mux := http.NewServeMux()
+	mux.Handle("/lidarr", hook)
+	srv := &http.Server{
+		Addr:              cfg.Webhook.Listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		hook.Run(ctx)
+		close(done)
+	}()
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+	fmt.Printf("👂 Listening for Lidarr webhooks on %s/lidarr\n", cfg.Webhook.Listen)
+	if cfg.Webhook.Password == "" {
+		slog.Warn("running without PLEXIFY_WEBHOOK_PASSWORD; anyone who can reach the listener can trigger a sync")
+	}
+
+	select {
+	case err := <-errc:
+		stop()
+		<-done
+		if !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		return 0
+	case <-ctx.Done():
+	}
+	fmt.Println("👋 Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("webhook listener did not shut down cleanly", "err", err)
+	}
+	<-done
+	return 0
+}
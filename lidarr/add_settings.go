@@ -0,0 +1,186 @@
+package lidarr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+// ErrAddSettingNotFound is returned when a configured root folder or profile does not exist in Lidarr.
+var ErrAddSettingNotFound = errors.New("not found in Lidarr")
+
+// AddSettings are the resolved root folder and profiles given to artists Plexify adds to Lidarr.
+// Profile names are empty when the id came from the root folder's defaults.
+type AddSettings struct {
+	RootFolderPath      string
+	QualityProfileID    int
+	QualityProfileName  string
+	MetadataProfileID   int
+	MetadataProfileName string
+}
+
+// String describes the settings for output, e.g. `root folder /music, quality profile "Lossless" (id 4), ...`.
+func (s AddSettings) String() string {
+	return fmt.Sprintf("root folder %s, quality profile %s, metadata profile %s", s.RootFolderPath,
+		profileLabel(s.QualityProfileName, s.QualityProfileID), profileLabel(s.MetadataProfileName, s.MetadataProfileID))
+}
+
+func profileLabel(name string, id int) string {
+	if name == "" {
+		return fmt.Sprintf("id %d", id)
+	}
+	return fmt.Sprintf("%q (id %d)", name, id)
+}
+
+type addProfileKey struct{}
+
+// WithAddProfile makes AddReleaseGroupIfMissing calls made with ctx add new artists using p (for example a
+// source playlist's LIDARR_PLAYLIST_PROFILES entry) instead of the client's configured profile.
+func WithAddProfile(ctx context.Context, p config.LidarrAddProfile) context.Context {
+	return context.WithValue(ctx, addProfileKey{}, p)
+}
+
+func (c *Client) addProfile(ctx context.Context) config.LidarrAddProfile {
+	if p, ok := ctx.Value(addProfileKey{}).(config.LidarrAddProfile); ok {
+		return p
+	}
+	return c.profile
+}
+
+// AddSettings returns the resolved settings new artists get when added with ctx (see WithAddProfile).
+func (c *Client) AddSettings(ctx context.Context) (AddSettings, error) {
+	return c.ResolveAddSettings(ctx, c.addProfile(ctx))
+}
+
+// ResolveAddSettings looks p up in GET /api/v1/rootfolder, /qualityprofile and /metadataprofile. Empty
+// fields fall back to the first root folder, its default profiles, then the first profile of each kind.
+// Successful results are cached per Client; unknown names or ids wrap ErrAddSettingNotFound.
+func (c *Client) ResolveAddSettings(ctx context.Context, p config.LidarrAddProfile) (AddSettings, error) {
+	c.addDefMu.Lock()
+	defer c.addDefMu.Unlock()
+	if s, ok := c.addDefCache[p]; ok {
+		return s, nil
+	}
+	s, err := c.fetchAddSettings(ctx, p)
+	if err != nil {
+		return AddSettings{}, err
+	}
+	if c.addDefCache == nil {
+		c.addDefCache = make(map[config.LidarrAddProfile]AddSettings)
+	}
+	c.addDefCache[p] = s
+	return s, nil
+}
+
+func (c *Client) fetchAddSettings(ctx context.Context, p config.LidarrAddProfile) (AddSettings, error) {
+	var out AddSettings
+	roots, err := c.getJSONSlice(ctx, "/api/v1/rootfolder")
+	if err != nil {
+		return out, fmt.Errorf("root folder: %w", err)
+	}
+	if len(roots) == 0 {
+		return out, fmt.Errorf("Lidarr has no root folders configured (Settings → Media Management → Root Folders)")
+	}
+	root := roots[0]
+	if p.RootFolder != "" {
+		if root = findRootFolder(roots, p.RootFolder); root == nil {
+			return out, fmt.Errorf("root folder %q %w (root folders: %s)", p.RootFolder, ErrAddSettingNotFound, describeRootFolders(roots))
+		}
+	}
+	path, _ := root["path"].(string)
+	out.RootFolderPath = strings.TrimSpace(path)
+	if out.RootFolderPath == "" {
+		return out, fmt.Errorf("Lidarr root folder has no path")
+	}
+
+	out.QualityProfileID, out.QualityProfileName, err = c.resolveProfile(ctx, "quality", "/api/v1/qualityprofile",
+		p.QualityProfile, intFromInterface(root["defaultQualityProfileId"]))
+	if err != nil {
+		return out, err
+	}
+	out.MetadataProfileID, out.MetadataProfileName, err = c.resolveProfile(ctx, "metadata", "/api/v1/metadataprofile",
+		p.MetadataProfile, intFromInterface(root["defaultMetadataProfileId"]))
+	if err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// resolveProfile finds want (a name or id) in the profiles at path. With want empty it returns rootDefault
+// without a request, or else the first profile.
+func (c *Client) resolveProfile(ctx context.Context, kind, path, want string, rootDefault int) (int, string, error) {
+	if want == "" && rootDefault > 0 {
+		return rootDefault, "", nil
+	}
+	profiles, err := c.getJSONSlice(ctx, path)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s profile: %w", kind, err)
+	}
+	if len(profiles) == 0 {
+		return 0, "", fmt.Errorf("Lidarr has no %s profiles", kind)
+	}
+	prof := profiles[0]
+	if want != "" {
+		if prof = findByIDOrName(profiles, want); prof == nil {
+			return 0, "", fmt.Errorf("%s profile %q %w (%s profiles: %s)", kind, want, ErrAddSettingNotFound, kind, describeProfiles(profiles))
+		}
+	}
+	id := intFromInterface(prof["id"])
+	if id <= 0 {
+		return 0, "", fmt.Errorf("could not resolve %s profile id", kind)
+	}
+	name, _ := prof["name"].(string)
+	return id, name, nil
+}
+
+func findByIDOrName(items []map[string]interface{}, want string) map[string]interface{} {
+	if id, err := strconv.Atoi(want); err == nil {
+		for _, it := range items {
+			if intFromInterface(it["id"]) == id {
+				return it
+			}
+		}
+	}
+	for _, it := range items {
+		if name, _ := it["name"].(string); strings.EqualFold(strings.TrimSpace(name), want) {
+			return it
+		}
+	}
+	return nil
+}
+
+// findRootFolder matches want against root folder ids, names and paths (ignoring a trailing slash).
+func findRootFolder(roots []map[string]interface{}, want string) map[string]interface{} {
+	if r := findByIDOrName(roots, want); r != nil {
+		return r
+	}
+	norm := strings.TrimRight(want, "/")
+	for _, r := range roots {
+		if path, _ := r["path"].(string); strings.TrimRight(strings.TrimSpace(path), "/") == norm {
+			return r
+		}
+	}
+	return nil
+}
+
+func describeRootFolders(roots []map[string]interface{}) string {
+	parts := make([]string, 0, len(roots))
+	for _, r := range roots {
+		path, _ := r["path"].(string)
+		parts = append(parts, fmt.Sprintf("%s (id %d)", path, intFromInterface(r["id"])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeProfiles(profiles []map[string]interface{}) string {
+	parts := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		name, _ := p["name"].(string)
+		parts = append(parts, fmt.Sprintf("%q (id %d)", name, intFromInterface(p["id"])))
+	}
+	return strings.Join(parts, ", ")
+}
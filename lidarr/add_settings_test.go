@@ -0,0 +1,83 @@
+package lidarr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+func addSettingsServer(t *testing.T, gets *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*gets++
+		switch r.URL.Path {
+		case "/api/v1/rootfolder":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": 1, "name": "Standard", "path": "/music", "defaultQualityProfileId": 1, "defaultMetadataProfileId": 1},
+				{"id": 2, "name": "Hi-Res", "path": "/music/lossless/"},
+			})
+		case "/api/v1/qualityprofile":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 1, "name": "Standard"}, {"id": 4, "name": "Lossless"}})
+		case "/api/v1/metadataprofile":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 1, "name": "Standard"}, {"id": 3, "name": "Albums only"}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestResolveAddSettings(t *testing.T) {
+	var gets int
+	srv := addSettingsServer(t, &gets)
+	defer srv.Close()
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	def, err := c.AddSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def != (AddSettings{RootFolderPath: "/music", QualityProfileID: 1, MetadataProfileID: 1}) {
+		t.Errorf("defaults = %+v", def)
+	}
+	if gets != 1 {
+		t.Errorf("root folder defaults should need only the root folder list, got %d requests", gets)
+	}
+
+	p := config.LidarrAddProfile{RootFolder: "/music/lossless", QualityProfile: "lossless", MetadataProfile: "3"}
+	got, err := c.AddSettings(WithAddProfile(ctx, p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := AddSettings{RootFolderPath: "/music/lossless/", QualityProfileID: 4, QualityProfileName: "Lossless",
+		MetadataProfileID: 3, MetadataProfileName: "Albums only"}
+	if got != want {
+		t.Errorf("profile = %+v, want %+v", got, want)
+	}
+	if s := got.String(); s != `root folder /music/lossless/, quality profile "Lossless" (id 4), metadata profile "Albums only" (id 3)` {
+		t.Errorf("String() = %s", s)
+	}
+	before := gets
+	if _, err := c.ResolveAddSettings(ctx, p); err != nil || gets != before {
+		t.Errorf("expected cached settings, err %v, %d new requests", err, gets-before)
+	}
+
+	for _, bad := range []config.LidarrAddProfile{{RootFolder: "/nope"}, {QualityProfile: "Ultra"}, {MetadataProfile: "9"}} {
+		_, err := c.ResolveAddSettings(ctx, bad)
+		if !errors.Is(err, ErrAddSettingNotFound) {
+			t.Errorf("%+v: err = %v, want ErrAddSettingNotFound", bad, err)
+		} else if !strings.Contains(err.Error(), "(id 1)") {
+			t.Errorf("%+v: error should list the available choices: %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package lidarr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+// AddArtistResult is the outcome of trying to add a MusicBrainz artist to Lidarr.
+type AddArtistResult struct {
+	ArtistID       string // MusicBrainz artist id
+	Name           string // Lidarr artist name, when known
+	AlreadyPresent bool
+	Added          bool
+}
+
+// AddArtistIfMissing adds the MusicBrainz artist to Lidarr with the given monitor option (none, future,
+// latest or all; empty means future) unless Lidarr already has it. New artists get the root folder and
+// profiles from AddSettings. Albums are searched for right away unless monitor is none.
+func (c *Client) AddArtistIfMissing(ctx context.Context, artistMBID, monitor string) (AddArtistResult, error) {
+	aid := strings.TrimSpace(artistMBID)
+	out := AddArtistResult{ArtistID: aid}
+	if aid == "" {
+		return out, fmt.Errorf("empty artist id")
+	}
+	if monitor == "" {
+		monitor = config.LidarrMonitorFuture
+	}
+
+	existing, err := c.getJSONSlice(ctx, "/api/v1/artist?mbId="+url.QueryEscape(aid))
+	if err != nil {
+		return out, fmt.Errorf("list artist by MusicBrainz id: %w", err)
+	}
+	for _, a := range existing {
+		if strings.EqualFold(stringField(a, "foreignArtistId"), aid) {
+			out.Name = stringField(a, "artistName")
+			out.AlreadyPresent = true
+			return out, nil
+		}
+	}
+
+	artist, err := c.lookupArtist(ctx, aid)
+	if err != nil {
+		return out, err
+	}
+	out.Name = stringField(artist, "artistName")
+	def, err := c.AddSettings(ctx)
+	if err != nil {
+		return out, err
+	}
+	applyArtistAddPayload(artist, def, monitor)
+	c.applyTags(ctx, artist)
+	if err := c.postArtist(ctx, artist); err != nil {
+		return out, err
+	}
+	out.Added = true
+	return out, nil
+}
+
+// applyArtistAddPayload prepares an /artist/lookup result for POST /api/v1/artist.
+func applyArtistAddPayload(artist map[string]interface{}, def AddSettings, monitor string) {
+	if !positiveIntFromJSON(artist["qualityProfileId"]) {
+		artist["qualityProfileId"] = def.QualityProfileID
+	}
+	if !positiveIntFromJSON(artist["metadataProfileId"]) {
+		artist["metadataProfileId"] = def.MetadataProfileID
+	}
+	if s, _ := artist["rootFolderPath"].(string); strings.TrimSpace(s) == "" {
+		artist["rootFolderPath"] = def.RootFolderPath
+	}
+	artist["monitored"] = monitor != config.LidarrMonitorNone
+	artist["addOptions"] = map[string]interface{}{
+		"monitor":                monitor,
+		"searchForMissingAlbums": monitor != config.LidarrMonitorNone,
+	}
+}
+
+func (c *Client) lookupArtist(ctx context.Context, artistMBID string) (map[string]interface{}, error) {
+	for _, term := range []string{"lidarr:" + artistMBID, artistMBID} {
+		found, err := c.getJSONSlice(ctx, "/api/v1/artist/lookup?term="+url.QueryEscape(term))
+		if err != nil {
+			return nil, fmt.Errorf("artist/lookup: %w", err)
+		}
+		for _, a := range found {
+			if strings.EqualFold(stringField(a, "foreignArtistId"), artistMBID) {
+				return a, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("Lidarr artist/lookup returned no results for artist %s", artistMBID)
+}
+
+func (c *Client) postArtist(ctx context.Context, artist map[string]interface{}) error {
+	body, err := json.Marshal(artist)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/api/v1/artist", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	c.setDefaultHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("add artist: %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+func stringField(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,68 @@
+package lidarr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+func TestAddArtistIfMissing(t *testing.T) {
+	const known, unknown = "mb-known", "mb-new"
+	var posted map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/artist":
+			if r.URL.Query().Get("mbId") == known {
+				_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 5, "artistName": "Known", "foreignArtistId": known}})
+				return
+			}
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/artist/lookup":
+			if r.URL.Query().Get("term") != "lidarr:"+unknown {
+				t.Errorf("lookup term = %q", r.URL.Query().Get("term"))
+			}
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"artistName": "New", "foreignArtistId": unknown}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/rootfolder":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"path": "/music", "defaultQualityProfileId": 1, "defaultMetadataProfileId": 2,
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/artist":
+			_ = json.NewDecoder(r.Body).Decode(&posted)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := c.AddArtistIfMissing(context.Background(), known, config.LidarrMonitorLatest)
+	if err != nil || !res.AlreadyPresent || res.Added || res.Name != "Known" || posted != nil {
+		t.Fatalf("known artist: %+v, err %v, posted %v", res, err, posted)
+	}
+
+	res, err = c.AddArtistIfMissing(context.Background(), unknown, config.LidarrMonitorLatest)
+	if err != nil || !res.Added || res.Name != "New" {
+		t.Fatalf("new artist: %+v, err %v", res, err)
+	}
+	if posted["rootFolderPath"] != "/music" || posted["qualityProfileId"] != float64(1) || posted["metadataProfileId"] != float64(2) {
+		t.Errorf("add settings not applied: %v", posted)
+	}
+	ao, _ := posted["addOptions"].(map[string]interface{})
+	if posted["monitored"] != true || ao["monitor"] != "latest" || ao["searchForMissingAlbums"] != true {
+		t.Errorf("monitor options: monitored %v, addOptions %v", posted["monitored"], ao)
+	}
+
+	artist := map[string]interface{}{}
+	applyArtistAddPayload(artist, AddSettings{RootFolderPath: "/m", QualityProfileID: 1, MetadataProfileID: 1}, config.LidarrMonitorNone)
+	if ao, _ := artist["addOptions"].(map[string]interface{}); artist["monitored"] != false || ao["searchForMissingAlbums"] != false {
+		t.Errorf("monitor none should add unmonitored without searching: %v", artist)
+	}
+}
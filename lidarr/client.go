@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,14 +25,22 @@ type Client struct {
 	apiKey string
 	http   *http.Client
 
+	profile     config.LidarrAddProfile // default for WithAddProfile
 	addDefMu    sync.Mutex
-	addDefCache *addDefaults
-}
+	addDefCache map[config.LidarrAddProfile]AddSettings
+
+	tag      string // LIDARR_TAG; empty disables tagging
+	tagMu    sync.Mutex
+	tagCache map[string]int // tag id by label
+
+	skipCompilations bool // LIDARR_SKIP_COMPILATIONS
 
-type addDefaults struct {
-	rootFolderPath    string
-	qualityProfileID  int
-	metadataProfileID int
+	activityMu sync.Mutex
+	activity   *activity // queue and wanted list, fetched once per Client
+
+	stateFile   string // LIDARR_STATE_FILE; empty disables the requested record
+	requestedMu sync.Mutex
+	requested   map[string]bool // release groups Plexify added or re-monitored, loaded from stateFile
 }
 
 // NewClient builds a client for the given Lidarr config. Base URL and API key must be non-empty.
@@ -63,9 +72,20 @@ func NewClient(cfg *config.LidarrConfig) (*Client, error) {
 			Timeout:   60 * time.Second,
 			Transport: tr,
 		},
+		profile:          cfg.AddProfile,
+		tag:              tagFromConfig(cfg),
+		skipCompilations: cfg.SkipCompilations,
+		stateFile:        strings.TrimSpace(cfg.StateFile),
 	}, nil
 }
 
+func tagFromConfig(cfg *config.LidarrConfig) string {
+	if !cfg.Tags {
+		return ""
+	}
+	return SanitizeTag(cfg.Tag)
+}
+
 // AddReleaseGroupResult is the outcome of trying to add a MusicBrainz release group to Lidarr.
 type AddReleaseGroupResult struct {
 	ReleaseGroupID string
@@ -74,6 +94,12 @@ type AddReleaseGroupResult struct {
 	// EnsuredMonitored is true when the release group was already in Lidarr and we successfully ran
 	// monitor/album/artist refresh (PUT album/monitor, PUT album, PUT artist as needed).
 	EnsuredMonitored bool
+	// WasMonitored is true when every album already in Lidarr was monitored before the call, i.e. the
+	// monitoring was not Plexify's doing.
+	WasMonitored bool
+	// Skipped is the lookup's secondary type (e.g. "Compilation") when LIDARR_SKIP_COMPILATIONS kept a new
+	// album out of Lidarr.
+	Skipped string
 }
 
 // AddReleaseGroupIfMissing looks up a MusicBrainz release group (Lidarr foreign album id), adds it
@@ -105,6 +131,11 @@ func (c *Client) AddReleaseGroupIfMissing(ctx context.Context, releaseGroupMBID
 
 		res, err := c.addReleaseGroupIfMissingOnce(ctx, rid)
 		if err == nil {
+			if res.Added || (res.EnsuredMonitored && !res.WasMonitored) {
+				if err := c.updateRequested(true, rid); err != nil {
+					slog.WarnContext(ctx, "lidarr: could not record requested release group", "release_group", rid, "err", err)
+				}
+			}
 			return res, nil
 		}
 		lastErr = err
@@ -130,6 +161,7 @@ func (c *Client) addReleaseGroupIfMissingOnce(ctx context.Context, rid string) (
 	}
 	if len(existing) > 0 {
 		out.AlreadyPresent = true
+		out.WasMonitored = !slices.ContainsFunc(existing, func(a map[string]interface{}) bool { return !jsonTruthy(a["monitored"]) })
 		if err := c.ensureExistingAlbumsMonitored(ctx, existing); err != nil {
 			return out, err
 		}
@@ -141,9 +173,18 @@ func (c *Client) addReleaseGroupIfMissingOnce(ctx context.Context, rid string) (
 	if err != nil {
 		return out, err
 	}
+	if c.skipCompilations {
+		if kind := compilationType(album); kind != "" {
+			out.Skipped = kind
+			return out, nil
+		}
+	}
 	if err := c.applyArtistAddDefaults(ctx, album); err != nil {
 		return out, err
 	}
+	if art, ok := album["artist"].(map[string]interface{}); ok {
+		c.applyTags(ctx, art)
+	}
 	if err := c.postAlbum(ctx, album); err != nil {
 		return out, err
 	}
@@ -151,6 +192,18 @@ func (c *Client) addReleaseGroupIfMissingOnce(ctx context.Context, rid string) (
 	return out, nil
 }
 
+// compilationType returns the album's Compilation or Soundtrack secondary type from lookup metadata, or "".
+func compilationType(album map[string]interface{}) string {
+	types, _ := album["secondaryTypes"].([]interface{})
+	for _, t := range types {
+		name, _ := t.(string)
+		if strings.EqualFold(name, "compilation") || strings.EqualFold(name, "soundtrack") {
+			return name
+		}
+	}
+	return ""
+}
+
 func (c *Client) fetchAlbumsByForeignAlbumID(ctx context.Context, foreignAlbumID string) ([]map[string]interface{}, error) {
 	u, err := url.Parse(c.base + "/api/v1/album")
 	if err != nil {
@@ -459,6 +512,7 @@ func (c *Client) ensureArtistMonitored(ctx context.Context, artistID int) error
 		return err
 	}
 	art["monitored"] = true
+	c.applyTags(ctx, art)
 	return c.putArtistJSON(ctx, artistID, art)
 }
 
@@ -551,11 +605,11 @@ func (c *Client) albumLookup(ctx context.Context, term string) (map[string]inter
 }
 
 // applyArtistAddDefaults sets artist.qualityProfileId, artist.metadataProfileId, and artist.rootFolderPath
-// when the lookup payload has 0/empty values — Lidarr requires these for a new artist. Uses the first
-// root folder from GET /api/v1/rootfolder (and its default* profile ids when set), else first profile
-// from GET /api/v1/qualityprofile and GET /api/v1/metadataprofile. Results are cached per Client.
+// when the lookup payload has 0/empty values — Lidarr requires these for a new artist. Values come from
+// AddSettings: the configured (or per-playlist) root folder and profiles, else Lidarr's first root folder
+// and its default profiles.
 func (c *Client) applyArtistAddDefaults(ctx context.Context, album map[string]interface{}) error {
-	def, err := c.getAddDefaults(ctx)
+	def, err := c.AddSettings(ctx)
 	if err != nil {
 		return err
 	}
@@ -565,97 +619,41 @@ func (c *Client) applyArtistAddDefaults(ctx context.Context, album map[string]in
 		album["artist"] = art
 	}
 	if !positiveIntFromJSON(art["qualityProfileId"]) {
-		art["qualityProfileId"] = def.qualityProfileID
+		art["qualityProfileId"] = def.QualityProfileID
 	}
 	if !positiveIntFromJSON(art["metadataProfileId"]) {
-		art["metadataProfileId"] = def.metadataProfileID
+		art["metadataProfileId"] = def.MetadataProfileID
 	}
 	if s, _ := art["rootFolderPath"].(string); strings.TrimSpace(s) == "" {
-		art["rootFolderPath"] = def.rootFolderPath
+		art["rootFolderPath"] = def.RootFolderPath
 	}
 	return nil
 }
 
-func (c *Client) getAddDefaults(ctx context.Context) (*addDefaults, error) {
-	c.addDefMu.Lock()
-	defer c.addDefMu.Unlock()
-	if c.addDefCache != nil {
-		return c.addDefCache, nil
-	}
-	d, err := c.fetchAddDefaults(ctx)
-	if err != nil {
+func (c *Client) getJSONSlice(ctx context.Context, path string) ([]map[string]interface{}, error) {
+	var out []map[string]interface{}
+	if err := c.getJSON(ctx, path, &out); err != nil {
 		return nil, err
 	}
-	c.addDefCache = d
-	return c.addDefCache, nil
-}
-
-func (c *Client) fetchAddDefaults(ctx context.Context) (*addDefaults, error) {
-	roots, err := c.getJSONSlice(ctx, "/api/v1/rootfolder")
-	if err != nil {
-		return nil, fmt.Errorf("root folder: %w", err)
-	}
-	if len(roots) == 0 {
-		return nil, fmt.Errorf("Lidarr has no root folders configured (Settings → Media Management → Root Folders)")
-	}
-	root := roots[0]
-	path, _ := root["path"].(string)
-	path = strings.TrimSpace(path)
-	if path == "" {
-		return nil, fmt.Errorf("first Lidarr root folder has no path")
-	}
-	out := &addDefaults{rootFolderPath: path}
-
-	qp := intFromInterface(root["defaultQualityProfileId"])
-	mp := intFromInterface(root["defaultMetadataProfileId"])
-	if qp <= 0 {
-		profiles, err := c.getJSONSlice(ctx, "/api/v1/qualityprofile")
-		if err != nil {
-			return nil, fmt.Errorf("quality profile: %w", err)
-		}
-		if len(profiles) == 0 {
-			return nil, fmt.Errorf("Lidarr has no quality profiles")
-		}
-		qp = intFromInterface(profiles[0]["id"])
-	}
-	if mp <= 0 {
-		profiles, err := c.getJSONSlice(ctx, "/api/v1/metadataprofile")
-		if err != nil {
-			return nil, fmt.Errorf("metadata profile: %w", err)
-		}
-		if len(profiles) == 0 {
-			return nil, fmt.Errorf("Lidarr has no metadata profiles")
-		}
-		mp = intFromInterface(profiles[0]["id"])
-	}
-	if qp <= 0 || mp <= 0 {
-		return nil, fmt.Errorf("could not resolve default quality (%d) or metadata (%d) profile id", qp, mp)
-	}
-	out.qualityProfileID = qp
-	out.metadataProfileID = mp
 	return out, nil
 }
 
-func (c *Client) getJSONSlice(ctx context.Context, path string) ([]map[string]interface{}, error) {
+func (c *Client) getJSON(ctx context.Context, path string, out interface{}) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+path, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	c.setDefaultHeaders(req)
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		b, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
 	}
-	var out []map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return nil, err
-	}
-	return out, nil
+	return json.NewDecoder(resp.Body).Decode(out)
 }
 
 func intFromInterface(v interface{}) int {
@@ -286,6 +286,35 @@ func TestAddReleaseGroupIfMissing_FallbackTerm(t *testing.T) {
 	}
 }
 
+func TestAddReleaseGroupIfMissing_SkipsCompilations(t *testing.T) {
+	mbid := "44444444-4444-4444-4444-444444444444"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/album":
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/album/lookup":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"foreignAlbumId": mbid, "title": "Now 42", "secondaryTypes": []string{"Compilation"}},
+			})
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "k", SkipCompilations: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := c.AddReleaseGroupIfMissing(context.Background(), mbid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Added || res.Skipped != "Compilation" {
+		t.Errorf("result = %+v, want skipped compilation", res)
+	}
+}
+
 func TestAddReleaseGroupIfMissing_MonitorsArtistAndReleases(t *testing.T) {
 	mbid := "33333333-3333-3333-3333-333333333333"
 	lookup := []map[string]interface{}{
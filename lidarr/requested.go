@@ -0,0 +1,107 @@
+package lidarr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/grrywlsn/plexify/internal/fileutil"
+)
+
+// requestedDoc is the LIDARR_STATE_FILE layout: the release groups Plexify added to Lidarr or whose
+// monitoring it turned back on, so LIDARR_UNMONITOR never touches albums monitored by hand.
+type requestedDoc struct {
+	ReleaseGroups []string `json:"release_groups"`
+}
+
+// loadRequestedLocked reads the state file once per Client. A missing file is an empty record.
+func (c *Client) loadRequestedLocked() error {
+	if c.requested != nil {
+		return nil
+	}
+	set := make(map[string]bool)
+	raw, err := os.ReadFile(c.stateFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read Lidarr state file: %w", err)
+	}
+	if strings.TrimSpace(string(raw)) != "" {
+		var doc requestedDoc
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return fmt.Errorf("decode Lidarr state file %s: %w", c.stateFile, err)
+		}
+		for _, rg := range doc.ReleaseGroups {
+			if rg = strings.TrimSpace(rg); rg != "" {
+				set[rg] = true
+			}
+		}
+	}
+	c.requested = set
+	return nil
+}
+
+// requestedIDs returns the recorded release groups, sorted. It returns nil when no state file is set.
+func (c *Client) requestedIDs() ([]string, error) {
+	if c.stateFile == "" {
+		return nil, nil
+	}
+	c.requestedMu.Lock()
+	defer c.requestedMu.Unlock()
+	if err := c.loadRequestedLocked(); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(c.requested))
+	for rg := range c.requested {
+		ids = append(ids, rg)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// updateRequested adds (or, with add false, removes) release groups in the record and writes the state
+// file. It does nothing when no state file is set.
+func (c *Client) updateRequested(add bool, releaseGroupIDs ...string) error {
+	if c.stateFile == "" || len(releaseGroupIDs) == 0 {
+		return nil
+	}
+	c.requestedMu.Lock()
+	defer c.requestedMu.Unlock()
+	if err := c.loadRequestedLocked(); err != nil {
+		return err
+	}
+	changed := false
+	for _, rg := range releaseGroupIDs {
+		if c.requested[rg] != add {
+			changed = true
+			if add {
+				c.requested[rg] = true
+			} else {
+				delete(c.requested, rg)
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+	doc := requestedDoc{ReleaseGroups: make([]string, 0, len(c.requested))}
+	for rg := range c.requested {
+		doc.ReleaseGroups = append(doc.ReleaseGroups, rg)
+	}
+	slices.Sort(doc.ReleaseGroups)
+	return writeStateFile(c.stateFile, doc)
+}
+
+func writeStateFile(path string, doc requestedDoc) error {
+	raw, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode Lidarr state: %w", err)
+	}
+	raw = append(raw, '\n')
+
+	if err := fileutil.WriteFileAtomic(path, raw, 0o644); err != nil {
+		return fmt.Errorf("write Lidarr state file: %w", err)
+	}
+	return nil
+}
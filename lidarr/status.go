@@ -0,0 +1,236 @@
+package lidarr
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// AlbumState is where a requested release group stands in Lidarr.
+type AlbumState string
+
+const (
+	StateNotInLidarr AlbumState = "not in Lidarr"
+	StateQueued      AlbumState = "queued"   // in the download client queue
+	StateGrabbed     AlbumState = "grabbed"  // release sent to the download client, not in the queue yet
+	StateImported    AlbumState = "imported" // track files on disk (Plex has not picked them up)
+	StateWanted      AlbumState = "wanted"   // monitored and missing, no release found yet
+	StateUnmonitored AlbumState = "unmonitored"
+)
+
+// ReleaseGroupStatus is the Lidarr state of one MusicBrainz release group.
+type ReleaseGroupStatus struct {
+	ReleaseGroupID string
+	AlbumID        int // Lidarr album id; 0 when not in Lidarr
+	AlbumTitle     string
+	ArtistName     string
+	State          AlbumState
+	QueueStatus    string  // download client status or tracked state when queued, e.g. downloading, importPending
+	Progress       float64 // download progress 0–1 when queued
+	TrackFiles     int     // imported track files
+	TrackCount     int     // tracks on the monitored release
+}
+
+// Describe renders the status for a summary line.
+func (s ReleaseGroupStatus) Describe() string {
+	switch s.State {
+	case StateQueued:
+		label := fmt.Sprintf("queued (%.0f%%", s.Progress*100)
+		if s.QueueStatus != "" {
+			label += ", " + s.QueueStatus
+		}
+		return label + ")"
+	case StateGrabbed:
+		return "grabbed, waiting for the download client"
+	case StateImported:
+		if s.TrackCount > 0 && s.TrackFiles < s.TrackCount {
+			return fmt.Sprintf("partly imported (%d/%d tracks), not yet in Plex", s.TrackFiles, s.TrackCount)
+		}
+		return "imported, not yet in Plex (rescan the library)"
+	case StateWanted:
+		return "wanted, no release found yet"
+	case StateUnmonitored:
+		return "in Lidarr but not monitored"
+	default:
+		return string(StateNotInLidarr)
+	}
+}
+
+type queueItem struct {
+	status   string
+	progress float64
+}
+
+// activity is the download queue and wanted list, keyed by Lidarr album id.
+type activity struct {
+	queue  map[int]queueItem
+	wanted map[int]bool
+}
+
+// ReleaseGroupStatuses reports the Lidarr state of each release group, using the download queue
+// (/api/v1/queue), the wanted list (/api/v1/wanted/missing) and each album's track file statistics.
+// The queue and wanted list are fetched on the first call and reused for the life of the Client (one run),
+// so calling this once per playlist costs one album lookup per release group.
+func (c *Client) ReleaseGroupStatuses(ctx context.Context, releaseGroupIDs []string) (map[string]ReleaseGroupStatus, error) {
+	out := make(map[string]ReleaseGroupStatus, len(releaseGroupIDs))
+	if len(releaseGroupIDs) == 0 {
+		return out, nil
+	}
+	act, err := c.loadActivity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rg := range releaseGroupIDs {
+		if _, done := out[rg]; done {
+			continue
+		}
+		albums, err := c.fetchAlbumsByForeignAlbumID(ctx, rg)
+		if err != nil {
+			return nil, err
+		}
+		out[rg] = albumStatus(rg, albums, act.queue, act.wanted)
+	}
+	return out, nil
+}
+
+func (c *Client) loadActivity(ctx context.Context) (*activity, error) {
+	c.activityMu.Lock()
+	defer c.activityMu.Unlock()
+	if c.activity != nil {
+		return c.activity, nil
+	}
+	queue, err := c.queueByAlbum(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("queue: %w", err)
+	}
+	wanted, err := c.wantedAlbumIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("wanted/missing: %w", err)
+	}
+	c.activity = &activity{queue: queue, wanted: wanted}
+	return c.activity, nil
+}
+
+func albumStatus(rg string, albums []map[string]interface{}, queue map[int]queueItem, wanted map[int]bool) ReleaseGroupStatus {
+	st := ReleaseGroupStatus{ReleaseGroupID: rg, State: StateNotInLidarr}
+	if len(albums) == 0 {
+		return st
+	}
+	album := albums[0]
+	id := intFromInterface(album["id"])
+	st.AlbumID = id
+	st.AlbumTitle = stringField(album, "title")
+	if art, ok := album["artist"].(map[string]interface{}); ok {
+		st.ArtistName = stringField(art, "artistName")
+	}
+	if stats, ok := album["statistics"].(map[string]interface{}); ok {
+		st.TrackFiles = intFromInterface(stats["trackFileCount"])
+		st.TrackCount = intFromInterface(stats["trackCount"])
+	}
+	switch q, queued := queue[id]; {
+	case queued:
+		st.State, st.QueueStatus, st.Progress = StateQueued, q.status, q.progress
+	case jsonTruthy(album["grabbed"]):
+		st.State = StateGrabbed
+	case st.TrackFiles > 0:
+		st.State = StateImported
+	case wanted[id]:
+		st.State = StateWanted
+	case !jsonTruthy(album["monitored"]):
+		st.State = StateUnmonitored
+	default:
+		st.State = StateWanted
+	}
+	return st
+}
+
+// AlbumFolders returns the folders holding an album's imported track files, as Lidarr sees them.
+func (c *Client) AlbumFolders(ctx context.Context, albumID int) ([]string, error) {
+	files, err := c.getJSONSlice(ctx, "/api/v1/trackfile?albumId="+strconv.Itoa(albumID))
+	if err != nil {
+		return nil, fmt.Errorf("track files for album %d: %w", albumID, err)
+	}
+	var out []string
+	for _, f := range files {
+		p := stringField(f, "path")
+		if p == "" {
+			continue
+		}
+		if dir := path.Dir(p); !slices.Contains(out, dir) {
+			out = append(out, dir)
+		}
+	}
+	return out, nil
+}
+
+const statusPageSize = 500
+
+// pagedRecords fetches every page of a Lidarr paged resource (queue, wanted/missing).
+func (c *Client) pagedRecords(ctx context.Context, path string, q url.Values) ([]map[string]interface{}, error) {
+	var all []map[string]interface{}
+	for page := 1; ; page++ {
+		q.Set("page", strconv.Itoa(page))
+		q.Set("pageSize", strconv.Itoa(statusPageSize))
+		var doc struct {
+			TotalRecords int                      `json:"totalRecords"`
+			Records      []map[string]interface{} `json:"records"`
+		}
+		if err := c.getJSON(ctx, path+"?"+q.Encode(), &doc); err != nil {
+			return nil, err
+		}
+		all = append(all, doc.Records...)
+		if len(doc.Records) < statusPageSize || len(all) >= doc.TotalRecords {
+			return all, nil
+		}
+	}
+}
+
+func (c *Client) queueByAlbum(ctx context.Context) (map[int]queueItem, error) {
+	records, err := c.pagedRecords(ctx, "/api/v1/queue", url.Values{"includeUnknownArtistItems": {"false"}})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int]queueItem)
+	for _, r := range records {
+		id := intFromInterface(r["albumId"])
+		if id <= 0 {
+			continue
+		}
+		item := queueItem{status: stringField(r, "trackedDownloadState")}
+		if item.status == "" {
+			item.status = strings.ToLower(stringField(r, "status"))
+		}
+		if size := floatFromInterface(r["size"]); size > 0 {
+			item.progress = (size - floatFromInterface(r["sizeleft"])) / size
+		}
+		out[id] = item
+	}
+	return out, nil
+}
+
+func (c *Client) wantedAlbumIDs(ctx context.Context) (map[int]bool, error) {
+	records, err := c.pagedRecords(ctx, "/api/v1/wanted/missing", url.Values{"monitored": {"true"}})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int]bool, len(records))
+	for _, r := range records {
+		out[intFromInterface(r["id"])] = true
+	}
+	return out, nil
+}
+
+func floatFromInterface(v interface{}) float64 {
+	switch x := v.(type) {
+	case float64:
+		return x
+	case int:
+		return float64(x)
+	default:
+		return float64(intFromInterface(v))
+	}
+}
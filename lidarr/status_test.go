@@ -0,0 +1,129 @@
+package lidarr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+func TestReleaseGroupStatuses(t *testing.T) {
+	albums := map[string]map[string]interface{}{
+		"rg-queued":   {"id": 1, "title": "Queued", "monitored": true, "artist": map[string]interface{}{"artistName": "A"}},
+		"rg-grabbed":  {"id": 2, "title": "Grabbed", "monitored": true, "grabbed": true},
+		"rg-imported": {"id": 3, "title": "Imported", "monitored": true, "statistics": map[string]interface{}{"trackFileCount": 4, "trackCount": 10}},
+		"rg-wanted":   {"id": 4, "title": "Wanted", "monitored": true},
+		"rg-off":      {"id": 5, "title": "Off", "monitored": false},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/queue":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"totalRecords": 1, "records": []map[string]interface{}{
+				{"albumId": 1, "size": 200.0, "sizeleft": 50.0, "status": "Downloading", "trackedDownloadState": "downloading"},
+			}})
+		case "/api/v1/wanted/missing":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"totalRecords": 1, "records": []map[string]interface{}{{"id": 4}}})
+		case "/api/v1/album":
+			var out []map[string]interface{}
+			if a, ok := albums[r.URL.Query().Get("foreignAlbumId")]; ok {
+				out = append(out, a)
+			}
+			_ = json.NewEncoder(w).Encode(out)
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.ReleaseGroupStatuses(context.Background(), []string{"rg-queued", "rg-grabbed", "rg-imported", "rg-wanted", "rg-off", "rg-none"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rg, want := range map[string]string{
+		"rg-queued":   "queued (75%, downloading)",
+		"rg-grabbed":  "grabbed, waiting for the download client",
+		"rg-imported": "partly imported (4/10 tracks), not yet in Plex",
+		"rg-wanted":   "wanted, no release found yet",
+		"rg-off":      "in Lidarr but not monitored",
+		"rg-none":     "not in Lidarr",
+	} {
+		if d := got[rg].Describe(); d != want {
+			t.Errorf("%s: %q, want %q", rg, d, want)
+		}
+	}
+	if got["rg-queued"].ArtistName != "A" || got["rg-queued"].AlbumTitle != "Queued" {
+		t.Errorf("queued status = %+v", got["rg-queued"])
+	}
+}
+
+func TestReleaseGroupStatuses_fetchesQueueOnce(t *testing.T) {
+	var queueCalls, wantedCalls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/queue":
+			queueCalls++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"totalRecords": 0, "records": []map[string]interface{}{}})
+		case "/api/v1/wanted/missing":
+			wantedCalls++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"totalRecords": 1, "records": []map[string]interface{}{{"id": 4}}})
+		case "/api/v1/album":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 4, "title": "Wanted", "monitored": true}})
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ids := range [][]string{{"rg-a"}, {"rg-b", "rg-c"}} {
+		got, err := c.ReleaseGroupStatuses(context.Background(), ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got[ids[0]].State != StateWanted {
+			t.Errorf("%s: %+v", ids[0], got[ids[0]])
+		}
+	}
+	if queueCalls != 1 || wantedCalls != 1 {
+		t.Errorf("queue fetched %d times, wanted list %d times; want once each", queueCalls, wantedCalls)
+	}
+}
+
+func TestAlbumFolders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/trackfile" || r.URL.Query().Get("albumId") != "7" {
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"path": "/music/A/Album (2001)/CD1/01 - One.flac"},
+			{"path": "/music/A/Album (2001)/CD1/02 - Two.flac"},
+			{"path": "/music/A/Album (2001)/CD2/01 - Three.flac"},
+			{"path": ""},
+		})
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.AlbumFolders(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/music/A/Album (2001)/CD1", "/music/A/Album (2001)/CD2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AlbumFolders = %v, want %v", got, want)
+	}
+}
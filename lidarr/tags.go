@@ -0,0 +1,155 @@
+package lidarr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/grrywlsn/plexify/textnorm"
+)
+
+type sourcePlaylistKey struct{}
+
+// WithSourcePlaylists names the source playlists whose missing tracks are added with ctx; each sanitized
+// name becomes a Lidarr tag on the artists Plexify adds or re-monitors (see SanitizeTag).
+func WithSourcePlaylists(ctx context.Context, names ...string) context.Context {
+	return context.WithValue(ctx, sourcePlaylistKey{}, names)
+}
+
+// SanitizeTag turns s into a Lidarr tag label: lowercase letters, digits and single hyphens. Accented and
+// non-Latin letters are transliterated first, so "Música Latina" becomes "musica-latina".
+func SanitizeTag(s string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(textnorm.Transliterate(s)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
+
+// tagLabels returns the tags for artists touched with ctx: the configured tag and the source playlists'.
+func (c *Client) tagLabels(ctx context.Context) []string {
+	if c.tag == "" {
+		return nil
+	}
+	labels := []string{c.tag}
+	names, _ := ctx.Value(sourcePlaylistKey{}).([]string)
+	for _, name := range names {
+		if pl := SanitizeTag(name); pl != "" && !slices.Contains(labels, pl) {
+			labels = append(labels, pl)
+		}
+	}
+	return labels
+}
+
+// tagIDs maps labels to Lidarr tag ids, creating missing tags. Ids are cached per Client.
+func (c *Client) tagIDs(ctx context.Context, labels []string) ([]int, error) {
+	c.tagMu.Lock()
+	defer c.tagMu.Unlock()
+	if err := c.loadTagsLocked(ctx); err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(labels))
+	for _, label := range labels {
+		id, ok := c.tagCache[label]
+		if !ok {
+			var err error
+			if id, err = c.createTag(ctx, label); err != nil {
+				return nil, err
+			}
+			c.tagCache[label] = id
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// loadTagsLocked fills tagCache from Lidarr once; c.tagMu must be held.
+func (c *Client) loadTagsLocked(ctx context.Context) error {
+	if c.tagCache != nil {
+		return nil
+	}
+	existing, err := c.getJSONSlice(ctx, "/api/v1/tag")
+	if err != nil {
+		return fmt.Errorf("list tags: %w", err)
+	}
+	c.tagCache = make(map[string]int, len(existing))
+	for _, t := range existing {
+		c.tagCache[strings.ToLower(stringField(t, "label"))] = intFromInterface(t["id"])
+	}
+	return nil
+}
+
+func (c *Client) createTag(ctx context.Context, label string) (int, error) {
+	body, err := json.Marshal(map[string]string{"label": label})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/api/v1/tag", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	c.setDefaultHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return 0, fmt.Errorf("create tag %q: %s: %s", label, resp.Status, strings.TrimSpace(string(b)))
+	}
+	var tag map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&tag); err != nil {
+		return 0, fmt.Errorf("decode tag %q: %w", label, err)
+	}
+	id := intFromInterface(tag["id"])
+	if id <= 0 {
+		return 0, fmt.Errorf("create tag %q: no id returned", label)
+	}
+	return id, nil
+}
+
+// applyTags adds the ctx's tags to an artist payload, keeping any tags it already has. Tagging is
+// best-effort: a Lidarr tag error is logged and the artist is saved untagged.
+func (c *Client) applyTags(ctx context.Context, artist map[string]interface{}) {
+	labels := c.tagLabels(ctx)
+	if len(labels) == 0 || artist == nil {
+		return
+	}
+	ids, err := c.tagIDs(ctx, labels)
+	if err != nil {
+		slog.WarnContext(ctx, "lidarr: could not tag artist", "tags", labels, "err", err)
+		return
+	}
+	var tags []interface{}
+	have := make(map[int]bool)
+	if existing, ok := artist["tags"].([]interface{}); ok {
+		for _, t := range existing {
+			have[intFromInterface(t)] = true
+			tags = append(tags, t)
+		}
+	}
+	for _, id := range ids {
+		if !have[id] {
+			have[id] = true
+			tags = append(tags, id)
+		}
+	}
+	artist["tags"] = tags
+}
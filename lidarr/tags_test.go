@@ -0,0 +1,83 @@
+package lidarr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+func TestSanitizeTag(t *testing.T) {
+	for in, want := range map[string]string{
+		"Plexify":               "plexify",
+		"  Road Trip '24 🚗 ":    "road-trip-24",
+		"Chill -- Beats/Lo-Fi!": "chill-beats-lo-fi",
+		"Música Latina":         "musica-latina",
+		"Кино — Лучшее":         "kino-luchshee",
+		"🎵":                     "",
+	} {
+		if got := SanitizeTag(in); got != want {
+			t.Errorf("SanitizeTag(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTagLabels_everySourcePlaylist(t *testing.T) {
+	c, err := NewClient(&config.LidarrConfig{URL: "http://lidarr:8686", Token: "key", Tags: true, Tag: "plexify"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithSourcePlaylists(context.Background(), "Road Trip", "Plexify", "Chill", "road trip")
+	if got, want := c.tagLabels(ctx), []string{"plexify", "road-trip", "chill"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tagLabels = %v, want %v", got, want)
+	}
+}
+
+func TestApplyTags(t *testing.T) {
+	var created []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tag":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 1, "label": "plexify"}, {"id": 2, "label": "lossless"}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tag":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body["label"])
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 10 + len(created), "label": body["label"]})
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "key", Tags: true, Tag: "plexify"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithSourcePlaylists(context.Background(), "Road Trip")
+	artist := map[string]interface{}{"tags": []interface{}{float64(2)}}
+	c.applyTags(ctx, artist)
+	if want := []interface{}{float64(2), 1, 11}; !reflect.DeepEqual(artist["tags"], want) {
+		t.Errorf("tags = %v, want %v", artist["tags"], want)
+	}
+
+	again := map[string]interface{}{"tags": []interface{}{float64(11)}}
+	c.applyTags(ctx, again)
+	if want := []interface{}{float64(11), 1}; !reflect.DeepEqual(again["tags"], want) {
+		t.Errorf("tags = %v, want %v", again["tags"], want)
+	}
+	if !reflect.DeepEqual(created, []string{"road-trip"}) {
+		t.Errorf("created tags %v, want the playlist tag once", created)
+	}
+
+	off, _ := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "key", Tag: "plexify"})
+	untouched := map[string]interface{}{}
+	off.applyTags(ctx, untouched)
+	if _, ok := untouched["tags"]; ok {
+		t.Error("tagging disabled should leave tags alone")
+	}
+}
@@ -0,0 +1,62 @@
+package lidarr
+
+import (
+	"context"
+	"fmt"
+)
+
+// RequestedAlbum is a monitored Lidarr album whose release group Plexify added or re-monitored.
+type RequestedAlbum struct {
+	AlbumID        int
+	ReleaseGroupID string // MusicBrainz release group (Lidarr foreignAlbumId)
+	Title          string
+	ArtistName     string
+	ArtistMBID     string // MusicBrainz artist id (Lidarr foreignArtistId)
+}
+
+// RequestedMonitoredAlbums lists the still-monitored albums of the release groups recorded in
+// LIDARR_STATE_FILE. Albums monitored by hand, or by an artist's monitor option, are never recorded. It
+// returns nil when no state file is set.
+func (c *Client) RequestedMonitoredAlbums(ctx context.Context) ([]RequestedAlbum, error) {
+	ids, err := c.requestedIDs()
+	if err != nil {
+		return nil, err
+	}
+	var out []RequestedAlbum
+	for _, rg := range ids {
+		albums, err := c.fetchAlbumsByForeignAlbumID(ctx, rg)
+		if err != nil {
+			return nil, fmt.Errorf("album %s: %w", rg, err)
+		}
+		for _, al := range albums {
+			if !jsonTruthy(al["monitored"]) {
+				continue
+			}
+			a := RequestedAlbum{
+				AlbumID:        intFromInterface(al["id"]),
+				ReleaseGroupID: rg,
+				Title:          stringField(al, "title"),
+			}
+			if art, ok := al["artist"].(map[string]interface{}); ok {
+				a.ArtistName = stringField(art, "artistName")
+				a.ArtistMBID = stringField(art, "foreignArtistId")
+			}
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// UnmonitorAlbums turns monitoring off for albums (PUT /api/v1/album/monitor) and drops their release
+// groups from LIDARR_STATE_FILE. Files on disk are kept.
+func (c *Client) UnmonitorAlbums(ctx context.Context, albums []RequestedAlbum) error {
+	ids := make([]int, len(albums))
+	rgs := make([]string, len(albums))
+	for i, a := range albums {
+		ids[i], rgs[i] = a.AlbumID, a.ReleaseGroupID
+	}
+	if err := c.putAlbumsMonitor(ctx, ids, false); err != nil {
+		return err
+	}
+	return c.updateRequested(false, rgs...)
+}
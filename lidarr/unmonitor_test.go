@@ -0,0 +1,136 @@
+package lidarr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+func readStateFile(t *testing.T, path string) []string {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc requestedDoc
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc.ReleaseGroups
+}
+
+func TestRequestedMonitoredAlbums(t *testing.T) {
+	state := filepath.Join(t.TempDir(), "lidarr.json")
+	if err := os.WriteFile(state, []byte(`{"release_groups": ["rg-1", "rg-2"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var unmonitored []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/album" && r.URL.Query().Get("foreignAlbumId") == "rg-1":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": 10, "title": "Wanted", "foreignAlbumId": "rg-1", "monitored": true,
+					"artist": map[string]interface{}{"artistName": "Band", "foreignArtistId": "mb-a"}},
+			})
+		case r.URL.Path == "/api/v1/album" && r.URL.Query().Get("foreignAlbumId") == "rg-2":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 11, "title": "Off", "foreignAlbumId": "rg-2", "monitored": false}})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/album/monitor":
+			var body struct {
+				AlbumIDs  []int `json:"albumIds"`
+				Monitored bool  `json:"monitored"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Monitored {
+				t.Error("expected monitored=false")
+			}
+			unmonitored = body.AlbumIDs
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			// Albums outside the state file (e.g. monitored by hand) are never looked up.
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "k", StateFile: state})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.RequestedMonitoredAlbums(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RequestedAlbum{{AlbumID: 10, ReleaseGroupID: "rg-1", Title: "Wanted", ArtistName: "Band", ArtistMBID: "mb-a"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RequestedMonitoredAlbums = %+v, want %+v", got, want)
+	}
+
+	if err := c.UnmonitorAlbums(context.Background(), got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unmonitored, []int{10}) {
+		t.Errorf("unmonitored %v", unmonitored)
+	}
+	if rgs := readStateFile(t, state); !reflect.DeepEqual(rgs, []string{"rg-2"}) {
+		t.Errorf("state file after unmonitor = %v, want [rg-2]", rgs)
+	}
+
+	off, _ := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "k"})
+	if got, err := off.RequestedMonitoredAlbums(context.Background()); err != nil || got != nil {
+		t.Errorf("no state file: %v, %v", got, err)
+	}
+}
+
+func TestAddReleaseGroupIfMissing_recordsOnlyWhatItMonitored(t *testing.T) {
+	// rg-hand was already monitored (by hand); rg-off was in Lidarr but unmonitored.
+	albums := map[string]map[string]interface{}{
+		"rg-hand": {"id": 1.0, "foreignAlbumId": "rg-hand", "monitored": true},
+		"rg-off":  {"id": 2.0, "foreignAlbumId": "rg-off", "monitored": false},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/album":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{albums[r.URL.Query().Get("foreignAlbumId")]})
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/album/"):
+			id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/v1/album/"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": float64(id), "monitored": true, "artist": map[string]interface{}{"id": 10.0, "monitored": true},
+				"releases": []interface{}{map[string]interface{}{"id": 5.0, "monitored": true}},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/artist/10":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 10.0, "monitored": true})
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer srv.Close()
+
+	state := filepath.Join(t.TempDir(), "lidarr.json")
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "k", StateFile: state})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rg := range []string{"rg-hand", "rg-off"} {
+		res, err := c.AddReleaseGroupIfMissing(context.Background(), rg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.WasMonitored != (rg == "rg-hand") {
+			t.Errorf("%s: WasMonitored = %v", rg, res.WasMonitored)
+		}
+	}
+	if rgs := readStateFile(t, state); !reflect.DeepEqual(rgs, []string{"rg-off"}) {
+		t.Errorf("state file = %v, want only the album Plexify re-monitored", rgs)
+	}
+}
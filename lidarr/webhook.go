@@ -0,0 +1,102 @@
+package lidarr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Lidarr webhook event types handled by "plexify serve".
+const (
+	WebhookEventDownload    = "Download"
+	WebhookEventAlbumImport = "AlbumImport"
+	WebhookEventTest        = "Test"
+)
+
+// WebhookEvent is the part of a Lidarr webhook (Settings → Connect → Webhook) Plexify uses.
+type WebhookEvent struct {
+	EventType  string
+	ArtistName string
+	ArtistMBID string
+	Albums     []WebhookAlbum
+}
+
+// WebhookAlbum is an album named in a webhook. ReleaseGroupID is empty when the payload has no MBID; see
+// AlbumReleaseGroupID.
+type WebhookAlbum struct {
+	ID             int // Lidarr album id
+	Title          string
+	ReleaseGroupID string
+}
+
+// Imported is true for events sent after Lidarr imported files.
+func (e WebhookEvent) Imported() bool {
+	return strings.EqualFold(e.EventType, WebhookEventDownload) || strings.EqualFold(e.EventType, WebhookEventAlbumImport)
+}
+
+type webhookAlbumJSON struct {
+	ID             int    `json:"id"`
+	Title          string `json:"title"`
+	MBID           string `json:"mbId"`
+	ForeignAlbumID string `json:"foreignAlbumId"`
+}
+
+// ParseWebhook decodes a Lidarr webhook body. Import events carry the album as "album" or "albums"
+// depending on the Lidarr version; both are read.
+func ParseWebhook(r io.Reader) (WebhookEvent, error) {
+	var doc struct {
+		EventType string `json:"eventType"`
+		Artist    struct {
+			Name            string `json:"name"`
+			MBID            string `json:"mbId"`
+			ForeignArtistID string `json:"foreignArtistId"`
+		} `json:"artist"`
+		Album  *webhookAlbumJSON  `json:"album"`
+		Albums []webhookAlbumJSON `json:"albums"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return WebhookEvent{}, fmt.Errorf("decode Lidarr webhook: %w", err)
+	}
+	ev := WebhookEvent{
+		EventType:  doc.EventType,
+		ArtistName: strings.TrimSpace(doc.Artist.Name),
+		ArtistMBID: firstNonEmpty(doc.Artist.MBID, doc.Artist.ForeignArtistID),
+	}
+	albums := doc.Albums
+	if doc.Album != nil {
+		albums = append([]webhookAlbumJSON{*doc.Album}, albums...)
+	}
+	seen := make(map[int]bool)
+	for _, a := range albums {
+		if a.ID > 0 && seen[a.ID] {
+			continue
+		}
+		seen[a.ID] = true
+		ev.Albums = append(ev.Albums, WebhookAlbum{
+			ID:             a.ID,
+			Title:          strings.TrimSpace(a.Title),
+			ReleaseGroupID: firstNonEmpty(a.MBID, a.ForeignAlbumID),
+		})
+	}
+	return ev, nil
+}
+
+// AlbumReleaseGroupID returns the MusicBrainz release group of a Lidarr album.
+func (c *Client) AlbumReleaseGroupID(ctx context.Context, albumID int) (string, error) {
+	album, err := c.getAlbumJSON(ctx, albumID)
+	if err != nil {
+		return "", err
+	}
+	return stringField(album, "foreignAlbumId"), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package lidarr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+func TestParseWebhook(t *testing.T) {
+	body := `{
+		"eventType": "Download",
+		"artist": {"id": 3, "name": "Band", "mbId": "mb-band"},
+		"album": {"id": 9, "title": "Debut", "mbId": "rg-1"},
+		"albums": [{"id": 9, "title": "Debut"}, {"id": 10, "title": "Second", "foreignAlbumId": "rg-2"}, {"id": 11, "title": "Third"}]
+	}`
+	ev, err := ParseWebhook(strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ev.Imported() || ev.ArtistName != "Band" || ev.ArtistMBID != "mb-band" {
+		t.Errorf("event = %+v", ev)
+	}
+	want := []WebhookAlbum{{ID: 9, Title: "Debut", ReleaseGroupID: "rg-1"}, {ID: 10, Title: "Second", ReleaseGroupID: "rg-2"}, {ID: 11, Title: "Third"}}
+	if !reflect.DeepEqual(ev.Albums, want) {
+		t.Errorf("albums = %+v, want %+v", ev.Albums, want)
+	}
+
+	test, err := ParseWebhook(strings.NewReader(`{"eventType": "Test"}`))
+	if err != nil || test.Imported() {
+		t.Errorf("test event = %+v, %v", test, err)
+	}
+	if _, err := ParseWebhook(strings.NewReader(`not json`)); err == nil {
+		t.Error("expected error for a malformed body")
+	}
+}
+
+func TestAlbumReleaseGroupID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/album/11" {
+			t.Fatalf("unexpected %s", r.URL.String())
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": 11, "foreignAlbumId": "rg-3"})
+	}))
+	defer srv.Close()
+	c, err := NewClient(&config.LidarrConfig{URL: srv.URL, Token: "k"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.AlbumReleaseGroupID(context.Background(), 11); err != nil || got != "rg-3" {
+		t.Errorf("AlbumReleaseGroupID = %q, %v", got, err)
+	}
+}
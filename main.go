@@ -11,6 +11,7 @@ import (
 
 	"github.com/grrywlsn/plexify/config"
 	"github.com/grrywlsn/plexify/internal/app"
+	"github.com/grrywlsn/plexify/internal/commands"
 )
 
 // Version is set via: -ldflags "-X main.version=1.2.3"
@@ -30,12 +31,32 @@ func parseFlags() map[string]string {
 	var plexURL, plexToken, plexLibrarySectionID, plexServerID string
 	flag.StringVar(&plexURL, "PLEX_URL", "", "Plex server URL (overrides env var)")
 	flag.StringVar(&plexToken, "PLEX_TOKEN", "", "Plex authentication token (overrides env var)")
-	flag.StringVar(&plexLibrarySectionID, "PLEX_LIBRARY_SECTION_ID", "", "Plex library section ID (overrides env var)")
+	flag.StringVar(&plexLibrarySectionID, "PLEX_LIBRARY_SECTION_ID", "", "Plex music library section ID(s) or name(s), comma-separated in priority order (overrides env var)")
 	flag.StringVar(&plexServerID, "PLEX_SERVER_ID", "", "Plex server ID (overrides env var)")
 
 	var lidarrURL, lidarrToken string
 	flag.StringVar(&lidarrURL, "LIDARR_URL", "", "Lidarr base URL for auto-adding missing MB release groups (overrides env; requires LIDARR_TOKEN)")
 	flag.StringVar(&lidarrToken, "LIDARR_TOKEN", "", "Lidarr API key (overrides env; requires LIDARR_URL)")
+	var lidarrRootFolder, lidarrQualityProfile, lidarrMetadataProfile string
+	flag.StringVar(&lidarrRootFolder, "lidarr-root-folder", "", "Lidarr root folder path, name or id for added artists (same as LIDARR_ROOT_FOLDER)")
+	flag.StringVar(&lidarrQualityProfile, "lidarr-quality-profile", "", "Lidarr quality profile name or id for added artists (same as LIDARR_QUALITY_PROFILE)")
+	flag.StringVar(&lidarrMetadataProfile, "lidarr-metadata-profile", "", "Lidarr metadata profile name or id for added artists (same as LIDARR_METADATA_PROFILE)")
+	var musicBrainzURL string
+	flag.StringVar(&musicBrainzURL, "musicbrainz-url", "", "MusicBrainz web service (or mirror) used to find release groups for Lidarr (same as MUSICBRAINZ_URL)")
+	var lidarrAddArtists bool
+	var lidarrArtistMonitor string
+	flag.BoolVar(&lidarrAddArtists, "lidarr-add-artists", false, "Add the artist of missing tracks without a release group id to Lidarr (same as LIDARR_ADD_ARTISTS=true)")
+	flag.StringVar(&lidarrArtistMonitor, "lidarr-artist-monitor", "", "Monitor option for artists added to Lidarr: none, future, latest or all (same as LIDARR_ARTIST_MONITOR)")
+	var lidarrPlexPathMap string
+	flag.StringVar(&lidarrPlexPathMap, "lidarr-plex-path-map", "", "Lidarr-to-Plex path prefixes for rescans, e.g. /music=/data/music (same as LIDARR_PLEX_PATH_MAP)")
+	var lidarrUnmonitor bool
+	flag.BoolVar(&lidarrUnmonitor, "lidarr-unmonitor", false, "Unmonitor Plexify-requested Lidarr albums no playlist references (same as LIDARR_UNMONITOR=true)")
+	var lidarrMinPlaylists, lidarrMaxAddsPerRun int
+	flag.IntVar(&lidarrMinPlaylists, "lidarr-min-playlists", -1, "Request albums missing from at least this many playlists (same as LIDARR_MIN_PLAYLISTS)")
+	flag.IntVar(&lidarrMaxAddsPerRun, "lidarr-max-adds-per-run", -1, "Max new Lidarr albums per run, 0 = no limit (same as LIDARR_MAX_ADDS_PER_RUN)")
+	var acquireWebhookURL, acquireExec string
+	flag.StringVar(&acquireWebhookURL, "acquire-webhook-url", "", "POST each run's missing tracks as JSON to this URL (same as PLEXIFY_ACQUIRE_WEBHOOK_URL)")
+	flag.StringVar(&acquireExec, "acquire-exec", "", "Run this command with each run's missing tracks as JSON on stdin (same as PLEXIFY_ACQUIRE_EXEC)")
 	var lidarrInsecureSkipVerify bool
 	flag.BoolVar(&lidarrInsecureSkipVerify, "lidarr-insecure-skip-verify", false, "Skip TLS verify for Lidarr HTTPS (same as LIDARR_INSECURE_SKIP_VERIFY=true)")
 
@@ -51,8 +72,9 @@ func parseFlags() map[string]string {
 	var plexVerifyTLS bool
 	flag.BoolVar(&plexVerifyTLS, "plex-verify-tls", false, "Verify Plex HTTPS certificates (same as PLEX_VERIFY_TLS=true; overrides insecure default)")
 
-	var plexFastSearch bool
+	var plexFastSearch, skipAlbumFallback bool
 	flag.BoolVar(&plexFastSearch, "plex-fast-search", false, "Skip full-library Plex scan; use indexed /search only (same as PLEXIFY_FAST_SEARCH=true)")
+	flag.BoolVar(&skipAlbumFallback, "skip-album-fallback", false, "Do not look unmatched tracks up on their source album (same as PLEXIFY_SKIP_ALBUM_FALLBACK=true)")
 
 	var exactMatchesOnly bool
 	flag.BoolVar(&exactMatchesOnly, "exact-matches-only", false, "Match using raw title/artist only (first strategy); skip normalizations and full-library scan (PLEXIFY_EXACT_MATCHES_ONLY)")
@@ -60,6 +82,37 @@ func parseFlags() map[string]string {
 	var plexMaxRPS float64
 	flag.Float64Var(&plexMaxRPS, "plex-max-rps", -1, "Max Plex HTTP requests per second (0 = unlimited; negative uses PLEX_MAX_REQUESTS_PER_SECOND or default 4)")
 
+	var overridesFile string
+	flag.StringVar(&overridesFile, "overrides-file", "", "Manual match overrides JSON file (same as PLEXIFY_OVERRIDES_FILE)")
+
+	var similarityAlgorithm string
+	flag.StringVar(&similarityAlgorithm, "similarity-algorithm", "", "String similarity for match scoring: heuristic, jaro-winkler, token-set or levenshtein (same as PLEXIFY_SIMILARITY_ALGORITHM)")
+
+	var explain, explainJSON string
+	flag.StringVar(&explain, "explain", "", "Explain matching for source tracks matching this selector (\"Artist - Title\", title fragment, source id, ISRC or MBID); implies -dry-run (same as PLEXIFY_EXPLAIN)")
+	flag.StringVar(&explainJSON, "explain-json", "", "Write every track's match trace to this JSON file (same as PLEXIFY_EXPLAIN_JSON)")
+
+	var autoAccept, autoReject int
+	flag.IntVar(&autoAccept, "auto-accept-percent", -1, "Keep title/artist matches at or above this confidence without review (same as PLEXIFY_AUTO_ACCEPT_PERCENT)")
+	flag.IntVar(&autoReject, "auto-reject-percent", -1, "Drop candidates below this confidence; matches in between are reviewed (same as PLEXIFY_AUTO_REJECT_PERCENT)")
+
+	var review, reviewSaveOverrides bool
+	var reviewDefault string
+	flag.BoolVar(&review, "review", false, "Review matches between the auto-reject and auto-accept thresholds on the terminal (same as PLEXIFY_REVIEW=true)")
+	flag.StringVar(&reviewDefault, "review-default", "", "Decision for review-band matches when not reviewing interactively: accept or reject (same as PLEXIFY_REVIEW_DEFAULT)")
+	flag.BoolVar(&reviewSaveOverrides, "review-save-overrides", false, "Save choices made in review to the overrides file (same as PLEXIFY_REVIEW_SAVE_OVERRIDES=true)")
+
+	var searchStrategies, searchPhases string
+	var maxHTTPCallsPerTrack int
+	flag.StringVar(&searchStrategies, "search-strategies", "", "Comma-separated search strategies to run, in order; prefix with ! to disable one instead (same as PLEXIFY_SEARCH_STRATEGIES)")
+	flag.StringVar(&searchPhases, "search-phases", "", "Indexed search phases to run, in order: combined and/or title-artist (same as PLEXIFY_SEARCH_PHASES)")
+	flag.IntVar(&maxHTTPCallsPerTrack, "max-http-calls-per-track", -1, "Max Plex requests per source track (0 = unlimited; same as PLEXIFY_MAX_HTTP_CALLS_PER_TRACK)")
+
+	var normalizationRules, artistAliases, homeUsers string
+	flag.StringVar(&normalizationRules, "normalization-rules", "", "User-defined normalization rules JSON file (same as PLEXIFY_NORMALIZATION_RULES)")
+	flag.StringVar(&artistAliases, "artist-aliases", "", "Comma-separated artist alias JSON files (same as PLEXIFY_ARTIST_ALIASES)")
+	flag.StringVar(&homeUsers, "home-users", "", "Comma-separated source=Plex user[:PIN] entries writing playlists into Plex Home users' accounts (same as PLEXIFY_HOME_USERS)")
+
 	flag.BoolVar(&debugMode, "DEBUG", false, "Enable debug output")
 
 	var showVersion bool
@@ -102,6 +155,42 @@ func parseFlags() map[string]string {
 	if lidarrToken != "" {
 		overrides["LIDARR_TOKEN"] = lidarrToken
 	}
+	if lidarrRootFolder != "" {
+		overrides["LIDARR_ROOT_FOLDER"] = lidarrRootFolder
+	}
+	if lidarrQualityProfile != "" {
+		overrides["LIDARR_QUALITY_PROFILE"] = lidarrQualityProfile
+	}
+	if lidarrMetadataProfile != "" {
+		overrides["LIDARR_METADATA_PROFILE"] = lidarrMetadataProfile
+	}
+	if musicBrainzURL != "" {
+		overrides["MUSICBRAINZ_URL"] = musicBrainzURL
+	}
+	if lidarrAddArtists {
+		overrides["LIDARR_ADD_ARTISTS"] = "true"
+	}
+	if lidarrArtistMonitor != "" {
+		overrides["LIDARR_ARTIST_MONITOR"] = lidarrArtistMonitor
+	}
+	if lidarrPlexPathMap != "" {
+		overrides["LIDARR_PLEX_PATH_MAP"] = lidarrPlexPathMap
+	}
+	if lidarrUnmonitor {
+		overrides["LIDARR_UNMONITOR"] = "true"
+	}
+	if lidarrMinPlaylists >= 0 {
+		overrides["LIDARR_MIN_PLAYLISTS"] = strconv.Itoa(lidarrMinPlaylists)
+	}
+	if lidarrMaxAddsPerRun >= 0 {
+		overrides["LIDARR_MAX_ADDS_PER_RUN"] = strconv.Itoa(lidarrMaxAddsPerRun)
+	}
+	if acquireWebhookURL != "" {
+		overrides["PLEXIFY_ACQUIRE_WEBHOOK_URL"] = acquireWebhookURL
+	}
+	if acquireExec != "" {
+		overrides["PLEXIFY_ACQUIRE_EXEC"] = acquireExec
+	}
 	if lidarrInsecureSkipVerify {
 		overrides["LIDARR_INSECURE_SKIP_VERIFY"] = "true"
 	}
@@ -120,17 +209,82 @@ func parseFlags() map[string]string {
 	if plexFastSearch {
 		overrides["PLEXIFY_FAST_SEARCH"] = "true"
 	}
+	if skipAlbumFallback {
+		overrides["PLEXIFY_SKIP_ALBUM_FALLBACK"] = "true"
+	}
 	if exactMatchesOnly {
 		overrides["PLEXIFY_EXACT_MATCHES_ONLY"] = "true"
 	}
 	if plexMaxRPS >= 0 {
 		overrides["PLEX_MAX_REQUESTS_PER_SECOND"] = strconv.FormatFloat(plexMaxRPS, 'f', -1, 64)
 	}
+	if overridesFile != "" {
+		overrides["PLEXIFY_OVERRIDES_FILE"] = overridesFile
+	}
+	if similarityAlgorithm != "" {
+		overrides["PLEXIFY_SIMILARITY_ALGORITHM"] = similarityAlgorithm
+	}
+	if explain != "" {
+		overrides["PLEXIFY_EXPLAIN"] = explain
+	}
+	if explainJSON != "" {
+		overrides["PLEXIFY_EXPLAIN_JSON"] = explainJSON
+	}
+	if autoAccept >= 0 {
+		overrides["PLEXIFY_AUTO_ACCEPT_PERCENT"] = strconv.Itoa(autoAccept)
+	}
+	if autoReject >= 0 {
+		overrides["PLEXIFY_AUTO_REJECT_PERCENT"] = strconv.Itoa(autoReject)
+	}
+	if review {
+		overrides["PLEXIFY_REVIEW"] = "true"
+	}
+	if reviewDefault != "" {
+		overrides["PLEXIFY_REVIEW_DEFAULT"] = reviewDefault
+	}
+	if reviewSaveOverrides {
+		overrides["PLEXIFY_REVIEW_SAVE_OVERRIDES"] = "true"
+	}
+	if searchStrategies != "" {
+		overrides["PLEXIFY_SEARCH_STRATEGIES"] = searchStrategies
+	}
+	if searchPhases != "" {
+		overrides["PLEXIFY_SEARCH_PHASES"] = searchPhases
+	}
+	if maxHTTPCallsPerTrack >= 0 {
+		overrides["PLEXIFY_MAX_HTTP_CALLS_PER_TRACK"] = strconv.Itoa(maxHTTPCallsPerTrack)
+	}
+	if normalizationRules != "" {
+		overrides["PLEXIFY_NORMALIZATION_RULES"] = normalizationRules
+	}
+	if artistAliases != "" {
+		overrides["PLEXIFY_ARTIST_ALIASES"] = artistAliases
+	}
+	if homeUsers != "" {
+		overrides["PLEXIFY_HOME_USERS"] = homeUsers
+	}
 
 	return overrides
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "override":
+			os.Exit(commands.Override(os.Args[2:]))
+		case "normalize":
+			os.Exit(commands.Normalize(os.Args[2:]))
+		case "aliases":
+			os.Exit(commands.Aliases(os.Args[2:]))
+		case "login":
+			os.Exit(commands.Login(os.Args[2:]))
+		case "lidarr-status":
+			os.Exit(commands.LidarrStatus(os.Args[2:]))
+		case "serve":
+			os.Exit(commands.Serve(os.Args[2:]))
+		}
+	}
+
 	overrides := parseFlags()
 
 	level := slog.LevelInfo
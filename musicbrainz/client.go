@@ -0,0 +1,160 @@
+// Package musicbrainz looks up release groups in the MusicBrainz web service (or a compatible mirror) so
+// missing tracks that only carry a recording MBID or an ISRC can still be requested from Lidarr.
+package musicbrainz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+// UserAgent identifies Plexify to MusicBrainz, which rejects anonymous clients.
+const UserAgent = "plexify ( https://github.com/grrywlsn/plexify )"
+
+// ErrNotFound is returned when MusicBrainz has no recording for the MBID or ISRC.
+var ErrNotFound = errors.New("not found in MusicBrainz")
+
+// Client calls the MusicBrainz JSON web service (ws/2).
+type Client struct {
+	base string
+	http *http.Client
+
+	mu    sync.Mutex
+	cache map[string][]string // release group candidates by "recording:" / "isrc:" key
+}
+
+// NewClient returns a client for baseURL (e.g. https://musicbrainz.org). rps caps the request rate; the
+// public server allows 1 request per second, and rps <= 0 disables limiting for local mirrors.
+func NewClient(baseURL string, rps float64) (*Client, error) {
+	base := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("parse MusicBrainz URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("MusicBrainz URL must be an absolute http(s) URL")
+	}
+	return &Client{
+		base: base,
+		http: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: newRateLimitedTransport(http.DefaultTransport, rps),
+		},
+		cache: make(map[string][]string),
+	}, nil
+}
+
+// Resolution is a release group found for a track, with what it was found from.
+type Resolution struct {
+	ReleaseGroupID string
+	Source         string // "recording" or "ISRC"
+}
+
+// ResolveReleaseGroup returns the preferred release group for t from its recording MBID, falling back to
+// its ISRC. ok is false when neither identifier leads to a release group.
+func (c *Client) ResolveReleaseGroup(ctx context.Context, t track.Track) (res Resolution, ok bool, err error) {
+	if id := strings.TrimSpace(t.MusicBrainzID); id != "" {
+		groups, err := c.ReleaseGroupsForRecording(ctx, id)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return res, false, err
+		}
+		if len(groups) > 0 {
+			return Resolution{ReleaseGroupID: groups[0], Source: "recording"}, true, nil
+		}
+	}
+	if isrc := strings.TrimSpace(t.ISRC); isrc != "" {
+		groups, err := c.ReleaseGroupsForISRC(ctx, isrc)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return res, false, err
+		}
+		if len(groups) > 0 {
+			return Resolution{ReleaseGroupID: groups[0], Source: "ISRC"}, true, nil
+		}
+	}
+	return res, false, nil
+}
+
+// ReleaseGroupsForRecording returns the release groups containing the recording, best candidate first
+// (see rankReleaseGroups).
+func (c *Client) ReleaseGroupsForRecording(ctx context.Context, recordingMBID string) ([]string, error) {
+	return c.cached(ctx, "recording:"+recordingMBID, func() ([]string, error) {
+		var rec recordingDTO
+		if err := c.getJSON(ctx, "/ws/2/recording/"+url.PathEscape(recordingMBID), &rec); err != nil {
+			return nil, err
+		}
+		return rankReleaseGroups(rec.Releases), nil
+	})
+}
+
+// ReleaseGroupsForISRC returns the release groups of every recording with the ISRC, best candidate first.
+func (c *Client) ReleaseGroupsForISRC(ctx context.Context, isrc string) ([]string, error) {
+	isrc = strings.ToUpper(isrc)
+	return c.cached(ctx, "isrc:"+isrc, func() ([]string, error) {
+		var doc struct {
+			Recordings []recordingDTO `json:"recordings"`
+		}
+		if err := c.getJSON(ctx, "/ws/2/isrc/"+url.PathEscape(isrc), &doc); err != nil {
+			return nil, err
+		}
+		var releases []releaseDTO
+		for _, rec := range doc.Recordings {
+			releases = append(releases, rec.Releases...)
+		}
+		return rankReleaseGroups(releases), nil
+	})
+}
+
+// cached runs fetch once per key; tracks repeat across playlists and each request costs a second.
+func (c *Client) cached(ctx context.Context, key string, fetch func() ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	groups, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok {
+		return groups, nil
+	}
+	groups, err := fetch()
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.cache[key] = groups
+	c.mu.Unlock()
+	return groups, err
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, out interface{}) error {
+	q := url.Values{"inc": {"releases+release-groups"}, "fmt": {"json"}}
+	// MusicBrainz expects a literal "+" between includes.
+	reqURL := c.base + path + "?" + strings.ReplaceAll(q.Encode(), "%2B", "+")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", UserAgent)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("MusicBrainz %s: %s: %s", path, resp.Status, strings.TrimSpace(string(b)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode MusicBrainz %s: %w", path, err)
+	}
+	return nil
+}
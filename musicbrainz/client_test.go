@@ -0,0 +1,105 @@
+package musicbrainz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+func TestRankReleaseGroups(t *testing.T) {
+	t.Parallel()
+	releases := []releaseDTO{
+		{Status: "Official", Date: "2001", ReleaseGroup: releaseGroupDTO{ID: "best-of", PrimaryType: "Album", SecondaryTypes: []string{"Compilation"}}},
+		{Status: "Official", Date: "1997-05", ReleaseGroup: releaseGroupDTO{ID: "single", PrimaryType: "Single"}},
+		{Status: "Bootleg", Date: "1990", ReleaseGroup: releaseGroupDTO{ID: "bootleg", PrimaryType: "Album"}},
+		{Status: "Official", Date: "1999", ReleaseGroup: releaseGroupDTO{ID: "reissue-era", PrimaryType: "Album"}},
+		{Status: "Official", ReleaseGroup: releaseGroupDTO{ID: "undated", PrimaryType: "Album"}},
+		{Status: "Official", Date: "1997-05-21", ReleaseGroup: releaseGroupDTO{ID: "debut", PrimaryType: "Album"}},
+		{Status: "Official", Date: "1998", ReleaseGroup: releaseGroupDTO{ID: "live", PrimaryType: "Album", SecondaryTypes: []string{"Live"}}},
+		{Status: "Official", Date: "2010", ReleaseGroup: releaseGroupDTO{ID: "debut", PrimaryType: "Album"}},
+	}
+	want := []string{"debut", "reissue-era", "undated", "live", "best-of", "single", "bootleg"}
+	if got := rankReleaseGroups(releases); !reflect.DeepEqual(got, want) {
+		t.Errorf("rankReleaseGroups = %q, want %q", got, want)
+	}
+}
+
+func TestResolveReleaseGroup(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("User-Agent") != UserAgent || r.URL.Query().Get("fmt") != "json" || r.URL.RawQuery != "fmt=json&inc=releases+release-groups" {
+			http.Error(w, "bad request "+r.URL.RawQuery, http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/ws/2/recording/rec-1":
+			_, _ = fmt.Fprint(w, `{"id": "rec-1", "releases": [
+				{"status": "Official", "date": "2005", "release-group": {"id": "rg-hits", "primary-type": "Album", "secondary-types": ["Compilation"]}},
+				{"status": "Official", "date": "1994", "release-group": {"id": "rg-album", "primary-type": "Album"}}
+			]}`)
+		case "/ws/2/recording/rec-gone":
+			http.Error(w, `{"error": "Not Found"}`, http.StatusNotFound)
+		case "/ws/2/isrc/GBAAA9400001":
+			_, _ = fmt.Fprint(w, `{"isrc": "GBAAA9400001", "recordings": [
+				{"id": "rec-2", "releases": [{"status": "Official", "date": "1994", "release-group": {"id": "rg-isrc", "primary-type": "Single"}}]}
+			]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL+"/", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	res, ok, err := c.ResolveReleaseGroup(ctx, track.Track{MusicBrainzID: "rec-1", ISRC: "GBAAA9400001"})
+	if err != nil || !ok || res != (Resolution{ReleaseGroupID: "rg-album", Source: "recording"}) {
+		t.Fatalf("recording: %+v %v %v", res, ok, err)
+	}
+	res, ok, err = c.ResolveReleaseGroup(ctx, track.Track{MusicBrainzID: "rec-gone", ISRC: "gbaaa9400001"})
+	if err != nil || !ok || res != (Resolution{ReleaseGroupID: "rg-isrc", Source: "ISRC"}) {
+		t.Fatalf("ISRC fallback: %+v %v %v", res, ok, err)
+	}
+	if _, ok, err := c.ResolveReleaseGroup(ctx, track.Track{ISRC: "XX0000000000"}); ok || err != nil {
+		t.Fatalf("unknown ISRC: ok %v err %v", ok, err)
+	}
+
+	before := requests.Load()
+	if _, ok, _ := c.ResolveReleaseGroup(ctx, track.Track{MusicBrainzID: "rec-gone", ISRC: "GBAAA9400001"}); !ok || requests.Load() != before {
+		t.Errorf("repeat lookups should be cached, got %d new requests", requests.Load()-before)
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"releases": []}`)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL, 20) // 50ms apart
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for i := range 3 {
+		if _, err := c.ReleaseGroupsForRecording(context.Background(), fmt.Sprintf("rec-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests at 20/s took %s, want at least 100ms", elapsed)
+	}
+}
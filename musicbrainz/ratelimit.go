@@ -0,0 +1,44 @@
+package musicbrainz
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// newRateLimitedTransport spaces request starts at least 1/rps apart. rps <= 0 disables limiting.
+func newRateLimitedTransport(base http.RoundTripper, rps float64) http.RoundTripper {
+	if rps <= 0 {
+		return base
+	}
+	return &rateLimitedTransport{base: base, minGap: time.Duration(float64(time.Second) / rps)}
+}
+
+type rateLimitedTransport struct {
+	base   http.RoundTripper
+	minGap time.Duration
+	mu     sync.Mutex
+	next   time.Time // earliest start of the next request
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	t.next = start.Add(t.minGap)
+	t.mu.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	return t.base.RoundTrip(req)
+}
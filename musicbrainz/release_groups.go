@@ -0,0 +1,97 @@
+package musicbrainz
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+type recordingDTO struct {
+	ID       string       `json:"id"`
+	Releases []releaseDTO `json:"releases"`
+}
+
+type releaseDTO struct {
+	ID           string          `json:"id"`
+	Status       string          `json:"status"`
+	Date         string          `json:"date"` // YYYY, YYYY-MM or YYYY-MM-DD; empty when unknown
+	ReleaseGroup releaseGroupDTO `json:"release-group"`
+}
+
+type releaseGroupDTO struct {
+	ID             string   `json:"id"`
+	PrimaryType    string   `json:"primary-type"`
+	SecondaryTypes []string `json:"secondary-types"`
+}
+
+// releaseRank orders releases: official before other statuses, albums before EPs before singles, plain
+// release groups before ones with secondary types (live, soundtrack, ...) and those before compilations,
+// then earliest date first (unknown dates last).
+type releaseRank struct {
+	unofficial int
+	primary    int
+	secondary  int
+	date       string
+}
+
+func rankOf(r releaseDTO) releaseRank {
+	rk := releaseRank{date: r.Date}
+	if !strings.EqualFold(r.Status, "Official") {
+		rk.unofficial = 1
+	}
+	switch strings.ToLower(r.ReleaseGroup.PrimaryType) {
+	case "album":
+		rk.primary = 0
+	case "ep":
+		rk.primary = 1
+	case "single":
+		rk.primary = 2
+	default:
+		rk.primary = 3
+	}
+	if slices.ContainsFunc(r.ReleaseGroup.SecondaryTypes, func(s string) bool { return strings.EqualFold(s, "Compilation") }) {
+		rk.secondary = 2
+	} else if len(r.ReleaseGroup.SecondaryTypes) > 0 {
+		rk.secondary = 1
+	}
+	return rk
+}
+
+func (a releaseRank) less(b releaseRank) bool {
+	if a.unofficial != b.unofficial {
+		return a.unofficial < b.unofficial
+	}
+	if a.primary != b.primary {
+		return a.primary < b.primary
+	}
+	if a.secondary != b.secondary {
+		return a.secondary < b.secondary
+	}
+	if (a.date == "") != (b.date == "") {
+		return a.date != ""
+	}
+	return a.date < b.date
+}
+
+// rankReleaseGroups returns the distinct release groups of releases, ordered by the best-ranked release
+// each contains, so the earliest official album comes before singles and compilations.
+func rankReleaseGroups(releases []releaseDTO) []string {
+	best := make(map[string]releaseRank)
+	var ids []string
+	for _, r := range releases {
+		id := strings.TrimSpace(r.ReleaseGroup.ID)
+		if id == "" {
+			continue
+		}
+		rk := rankOf(r)
+		cur, seen := best[id]
+		if !seen {
+			ids = append(ids, id)
+		}
+		if !seen || rk.less(cur) {
+			best[id] = rk
+		}
+	}
+	sort.SliceStable(ids, func(i, j int) bool { return best[ids[i]].less(best[ids[j]]) })
+	return ids
+}
@@ -80,6 +80,8 @@ type playlistTrackDTO struct {
 	Artist     string           `json:"artist"`
 	Album      string           `json:"album,omitempty"`
 	DurationMS int              `json:"duration_ms,omitempty"`
+	TrackNum   int              `json:"track_number,omitempty"`
+	DiscNum    int              `json:"disc_number,omitempty"`
 	MB         *mbTrackDTO      `json:"musicbrainz,omitempty"`
 	Spotify    *spotifyTrackDTO `json:"spotify,omitempty"`
 	AppleMusic *appleMusicDTO   `json:"apple_music,omitempty"`
@@ -160,11 +162,13 @@ func (c *Client) GetPlaylist(playlistID string) (*Playlist, error) {
 	tracks := make([]track.Track, 0, len(doc.Tracks))
 	for _, tj := range doc.Tracks {
 		tr := track.Track{
-			ID:       fmt.Sprintf("%s:%d", doc.ID, tj.Position),
-			Name:     tj.Title,
-			Artist:   tj.Artist,
-			Album:    tj.Album,
-			Duration: tj.DurationMS,
+			ID:          fmt.Sprintf("%s:%d", doc.ID, tj.Position),
+			Name:        tj.Title,
+			Artist:      tj.Artist,
+			Album:       tj.Album,
+			Duration:    tj.DurationMS,
+			TrackNumber: tj.TrackNum,
+			DiscNumber:  tj.DiscNum,
 		}
 		if tj.MB != nil {
 			tr.MusicBrainzID = tj.MB.TrackGID
@@ -173,9 +177,13 @@ func (c *Client) GetPlaylist(playlistID string) (*Playlist, error) {
 				tr.ISRC = tj.MB.ISRCs[0]
 			}
 			for _, ac := range tj.MB.ArtistCredits {
-				if n := strings.TrimSpace(ac.Name); n != "" {
+				n, id := strings.TrimSpace(ac.Name), strings.TrimSpace(ac.ArtistGID)
+				if n != "" {
 					tr.MusicBrainzArtistCredits = append(tr.MusicBrainzArtistCredits, n)
 				}
+				if n != "" || id != "" {
+					tr.MusicBrainzArtists = append(tr.MusicBrainzArtists, track.ArtistCredit{Name: n, ID: id})
+				}
 			}
 		}
 		if tj.Spotify != nil {
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+
+	"github.com/grrywlsn/plexify/track"
 )
 
 func TestClient_ListUserPlaylists(t *testing.T) {
@@ -113,6 +116,50 @@ func TestClient_GetPlaylist_musicbrainzArtistCredits(t *testing.T) {
 	}
 }
 
+func TestClient_GetPlaylist_musicbrainzArtistIDs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc := map[string]any{
+			"id": "plids", "title": "IDs", "owner": "alice", "track_count": 1,
+			"updated_at": "2025-01-01T00:00:00Z",
+			"tracks": []map[string]any{
+				{
+					"position": 1, "title": "Song", "artist": "Lead feat. Guest",
+					"musicbrainz": map[string]any{
+						"track_gid": "tg",
+						"artist_credits": []map[string]any{
+							{"name": "Lead"},
+							{"artist_gid": "mb-guest", "name": "Guest"},
+							{"artist_gid": "mb-guest", "name": "Guest (again)"},
+						},
+					},
+				},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(doc)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl, err := c.GetPlaylist("plids")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := pl.Tracks[0]
+	want := []track.ArtistCredit{{Name: "Lead"}, {Name: "Guest", ID: "mb-guest"}, {Name: "Guest (again)", ID: "mb-guest"}}
+	if !reflect.DeepEqual(tr.MusicBrainzArtists, want) {
+		t.Fatalf("artists: %+v, want %+v", tr.MusicBrainzArtists, want)
+	}
+	if ids := tr.MusicBrainzArtistIDs(); !reflect.DeepEqual(ids, []string{"mb-guest"}) {
+		t.Errorf("artist ids: %v", ids)
+	}
+	if primary, ok := tr.PrimaryMusicBrainzArtist(); ok {
+		t.Errorf("primary credit has no MBID, got %+v", primary)
+	}
+}
+
 func TestClient_GetPlaylist_streamingAlbums(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/playlist/pl2.json" {
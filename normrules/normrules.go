@@ -0,0 +1,157 @@
+// Package normrules loads user-defined normalization rules: ordered regular-expression replacements applied
+// to source and Plex titles, artists or albums in addition to plexify's built-in cleanups.
+package normrules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Scope is the metadata field a rule applies to.
+type Scope string
+
+const (
+	ScopeTitle  Scope = "title"
+	ScopeArtist Scope = "artist"
+	ScopeAlbum  Scope = "album"
+)
+
+// ParseScope accepts title, artist or album (case-insensitive); empty means title.
+func ParseScope(s string) (Scope, error) {
+	switch Scope(strings.ToLower(strings.TrimSpace(s))) {
+	case "", ScopeTitle:
+		return ScopeTitle, nil
+	case ScopeArtist:
+		return ScopeArtist, nil
+	case ScopeAlbum:
+		return ScopeAlbum, nil
+	}
+	return "", fmt.Errorf("unknown scope %q (want %s, %s or %s)", s, ScopeTitle, ScopeArtist, ScopeAlbum)
+}
+
+// Rule replaces every match of Pattern (Go RE2 syntax) in one field with Replace, which may use $1-style
+// group references. An empty Replace strips the match.
+type Rule struct {
+	Name    string `json:"name,omitempty"`
+	Scope   Scope  `json:"scope,omitempty"` // title (default), artist or album
+	Pattern string `json:"pattern"`
+	Replace string `json:"replace,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// Label is the rule's name, or its pattern when unnamed.
+func (r Rule) Label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Pattern
+}
+
+type fileDoc struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Set is a loaded rules file in file order. A nil *Set has no rules and leaves strings unchanged.
+type Set struct {
+	rules []Rule
+}
+
+// Load reads and compiles the rules file at path. An empty path yields an empty set; unlike the overrides
+// file, a missing rules file is an error because nothing ever writes it.
+func Load(path string) (*Set, error) {
+	s := &Set{}
+	if strings.TrimSpace(path) == "" {
+		return s, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("normalization rules file %s does not exist", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read normalization rules file: %w", err)
+	}
+	var doc fileDoc
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("decode normalization rules file %s: %w", path, err)
+	}
+	for i, r := range doc.Rules {
+		if err := r.compile(); err != nil {
+			return nil, fmt.Errorf("normalization rules file %s: rule %d (%s): %w", path, i+1, r.Label(), err)
+		}
+		s.rules = append(s.rules, r)
+	}
+	return s, nil
+}
+
+func (r *Rule) compile() error {
+	scope, err := ParseScope(string(r.Scope))
+	if err != nil {
+		return err
+	}
+	r.Scope = scope
+	if strings.TrimSpace(r.Pattern) == "" {
+		return fmt.Errorf("pattern is empty")
+	}
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	r.re = re
+	return nil
+}
+
+// Len returns the number of rules.
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.rules)
+}
+
+// Step is the result of one rule in Trace.
+type Step struct {
+	Rule   string
+	Before string
+	After  string
+}
+
+// Changed reports whether the rule modified the string.
+func (st Step) Changed() bool {
+	return st.Before != st.After
+}
+
+// Apply runs the scope's rules over v in file order, collapsing whitespace after each rule that matched.
+// v is returned unchanged when no rule matches.
+func (s *Set) Apply(scope Scope, v string) string {
+	steps := s.Trace(scope, v)
+	if len(steps) == 0 {
+		return v
+	}
+	return steps[len(steps)-1].After
+}
+
+// Trace runs the scope's rules over v like Apply and reports the string before and after each rule.
+func (s *Set) Trace(scope Scope, v string) []Step {
+	if s == nil {
+		return nil
+	}
+	var steps []Step
+	cur := v
+	for _, r := range s.rules {
+		if r.Scope != scope {
+			continue
+		}
+		next := r.re.ReplaceAllString(cur, r.Replace)
+		if next != cur {
+			next = strings.Join(strings.Fields(next), " ")
+		}
+		steps = append(steps, Step{Rule: r.Label(), Before: cur, After: next})
+		cur = next
+	}
+	return steps
+}
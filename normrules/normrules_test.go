@@ -0,0 +1,70 @@
+package normrules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRules(t *testing.T, doc string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_emptyPathAndMissingFile(t *testing.T) {
+	s, err := Load("")
+	if err != nil || s.Len() != 0 {
+		t.Fatalf("Load(\"\") = %d rules, %v; want empty set", s.Len(), err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "nope.json")); err == nil {
+		t.Fatal("expected error for missing rules file")
+	}
+}
+
+func TestLoad_rejectsInvalidRule(t *testing.T) {
+	for _, doc := range []string{
+		`{"rules":[{"pattern":"("}]}`,
+		`{"rules":[{"pattern":"x","scope":"genre"}]}`,
+		`{"rules":[{"name":"blank","pattern":" "}]}`,
+	} {
+		_, err := Load(writeRules(t, doc))
+		if err == nil || !strings.Contains(err.Error(), "rule 1") {
+			t.Errorf("Load(%s) error = %v, want rule 1 error", doc, err)
+		}
+	}
+}
+
+func TestSet_ApplyAndTrace(t *testing.T) {
+	s, err := Load(writeRules(t, `{"rules":[
+		{"name":"remaster","pattern":"(?i)\\s*-\\s*\\d{4} remaster(ed)?$"},
+		{"name":"and","scope":"artist","pattern":"\\s+&\\s+","replace":" and "},
+		{"name":"colors","scope":"TITLE","pattern":"(?i)\\|\\s*a colors show","replace":" "}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Apply(ScopeTitle, "Song | A COLORS SHOW - 2011 Remaster"); got != "Song" {
+		t.Errorf("Apply(title) = %q, want %q", got, "Song")
+	}
+	if got := s.Apply(ScopeArtist, "Simon & Garfunkel"); got != "Simon and Garfunkel" {
+		t.Errorf("Apply(artist) = %q", got)
+	}
+	if got := s.Apply(ScopeAlbum, "  Album  "); got != "  Album  " {
+		t.Errorf("Apply(album) without rules changed input: %q", got)
+	}
+
+	steps := s.Trace(ScopeTitle, "Song - 2011 Remaster")
+	if len(steps) != 2 || steps[0].Rule != "remaster" || !steps[0].Changed() || steps[1].Changed() {
+		t.Fatalf("Trace = %+v", steps)
+	}
+
+	var nilSet *Set
+	if nilSet.Len() != 0 || nilSet.Apply(ScopeTitle, "x") != "x" || nilSet.Trace(ScopeTitle, "x") != nil {
+		t.Error("nil Set should have no rules")
+	}
+}
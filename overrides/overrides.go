@@ -0,0 +1,274 @@
+// Package overrides loads the manual match overrides file: user-maintained entries that pin a source
+// track to a Plex rating key, redirect it to a different Plex artist/title/album lookup, or skip it.
+package overrides
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grrywlsn/plexify/internal/fileutil"
+	"github.com/grrywlsn/plexify/textnorm"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// Action is what an override entry does when it matches a source track.
+type Action string
+
+const (
+	// ActionRatingKey pins the source track to an existing Plex track by rating key.
+	ActionRatingKey Action = "rating_key"
+	// ActionLookup searches Plex with the entry's artist/title/album instead of the source metadata.
+	ActionLookup Action = "lookup"
+	// ActionSkip leaves the source track out of the Plex playlist without reporting it as missing.
+	ActionSkip Action = "skip"
+)
+
+// Lookup replaces the source metadata used for the Plex search. Empty fields fall back to the source track.
+type Lookup struct {
+	Artist string `json:"artist,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Album  string `json:"album,omitempty"`
+}
+
+// Entry is one override. Exactly one source key (SourceID, ISRC, MBID or Track) and exactly one
+// action (RatingKey, Plex or Skip) must be set.
+type Entry struct {
+	SourceID string `json:"source_id,omitempty"` // music-social track id: "{playlist id}:{position}"
+	ISRC     string `json:"isrc,omitempty"`
+	MBID     string `json:"mbid,omitempty"`  // MusicBrainz recording id
+	Track    string `json:"track,omitempty"` // "Artist – Title" (compared after normalization, see TrackKey)
+
+	RatingKey string  `json:"rating_key,omitempty"`
+	Plex      *Lookup `json:"plex,omitempty"`
+	Skip      bool    `json:"skip,omitempty"`
+
+	Note string `json:"note,omitempty"`
+}
+
+type fileDoc struct {
+	Overrides []Entry `json:"overrides"`
+}
+
+// Action reports which action the entry carries (empty when none is set).
+func (e Entry) Action() Action {
+	switch {
+	case e.Skip:
+		return ActionSkip
+	case strings.TrimSpace(e.RatingKey) != "":
+		return ActionRatingKey
+	case e.Plex != nil:
+		return ActionLookup
+	default:
+		return ""
+	}
+}
+
+// Key returns the entry's source key as "kind:value" (e.g. "isrc:USXXX1234567"), used for display and
+// for replacing an existing entry in Append.
+func (e Entry) Key() string {
+	switch {
+	case strings.TrimSpace(e.SourceID) != "":
+		return "source_id:" + strings.TrimSpace(e.SourceID)
+	case strings.TrimSpace(e.MBID) != "":
+		return "mbid:" + strings.ToLower(strings.TrimSpace(e.MBID))
+	case strings.TrimSpace(e.ISRC) != "":
+		return "isrc:" + strings.ToUpper(strings.TrimSpace(e.ISRC))
+	case strings.TrimSpace(e.Track) != "":
+		return "track:" + ParseTrackKey(e.Track)
+	default:
+		return ""
+	}
+}
+
+// Validate checks that exactly one source key and exactly one action are set.
+func (e Entry) Validate() error {
+	keys := 0
+	for _, s := range []string{e.SourceID, e.ISRC, e.MBID, e.Track} {
+		if strings.TrimSpace(s) != "" {
+			keys++
+		}
+	}
+	if keys != 1 {
+		return fmt.Errorf("exactly one of source_id, isrc, mbid or track is required (got %d)", keys)
+	}
+	actions := 0
+	if e.Skip {
+		actions++
+	}
+	if strings.TrimSpace(e.RatingKey) != "" {
+		actions++
+	}
+	if e.Plex != nil {
+		actions++
+		if strings.TrimSpace(e.Plex.Artist) == "" && strings.TrimSpace(e.Plex.Title) == "" && strings.TrimSpace(e.Plex.Album) == "" {
+			return fmt.Errorf("plex lookup needs at least one of artist, title or album")
+		}
+	}
+	if actions != 1 {
+		return fmt.Errorf("exactly one of rating_key, plex or skip is required (got %d)", actions)
+	}
+	return nil
+}
+
+// Set is a loaded overrides file indexed by source key. A nil *Set has no entries.
+type Set struct {
+	entries []Entry
+	byKey   map[string]int
+}
+
+// Load reads the overrides file at path. A missing file yields an empty set so the file can be created
+// later with Append.
+func Load(path string) (*Set, error) {
+	s := &Set{byKey: make(map[string]int)}
+	if strings.TrimSpace(path) == "" {
+		return s, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read overrides file: %w", err)
+	}
+	if strings.TrimSpace(string(raw)) == "" {
+		return s, nil
+	}
+	var doc fileDoc
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("decode overrides file %s: %w", path, err)
+	}
+	for i, e := range doc.Overrides {
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("overrides file %s: entry %d: %w", path, i+1, err)
+		}
+		s.put(e)
+	}
+	return s, nil
+}
+
+func (s *Set) put(e Entry) {
+	k := e.Key()
+	if i, ok := s.byKey[k]; ok {
+		s.entries[i] = e
+		return
+	}
+	s.byKey[k] = len(s.entries)
+	s.entries = append(s.entries, e)
+}
+
+// Len returns the number of entries.
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.entries)
+}
+
+// Entries returns a copy of the entries in file order.
+func (s *Set) Entries() []Entry {
+	if s == nil {
+		return nil
+	}
+	return append([]Entry(nil), s.entries...)
+}
+
+// Find returns the override for t, checking the most specific key first: source id, recording MBID,
+// ISRC, then normalized "artist – title".
+func (s *Set) Find(t track.Track) (Entry, bool) {
+	if s == nil || len(s.entries) == 0 {
+		return Entry{}, false
+	}
+	var keys []string
+	if id := strings.TrimSpace(t.ID); id != "" {
+		keys = append(keys, "source_id:"+id)
+	}
+	if mbid := strings.TrimSpace(t.MusicBrainzID); mbid != "" {
+		keys = append(keys, "mbid:"+strings.ToLower(mbid))
+	}
+	if isrc := strings.TrimSpace(t.ISRC); isrc != "" {
+		keys = append(keys, "isrc:"+strings.ToUpper(isrc))
+	}
+	if k := TrackKey(t.Artist, t.Name); k != "" {
+		keys = append(keys, "track:"+k)
+	}
+	for _, k := range keys {
+		if i, ok := s.byKey[k]; ok {
+			return s.entries[i], true
+		}
+	}
+	return Entry{}, false
+}
+
+// ForTrack returns an entry keyed on the most stable identifier of t (ISRC, then recording MBID, then
+// "Artist – Title"), with no action set. The music-social source id is not used because it changes when
+// the playlist is reordered.
+func ForTrack(t track.Track) Entry {
+	switch {
+	case strings.TrimSpace(t.ISRC) != "":
+		return Entry{ISRC: strings.TrimSpace(t.ISRC)}
+	case strings.TrimSpace(t.MusicBrainzID) != "":
+		return Entry{MBID: strings.TrimSpace(t.MusicBrainzID)}
+	}
+	return Entry{Track: strings.TrimSpace(t.Artist) + " – " + strings.TrimSpace(t.Name)}
+}
+
+// TrackKey is the normalized "artist – title" form used to match Entry.Track. Both sides get the matcher's
+// folding (typographic punctuation, Latin diacritics, "feat." credits), are lowercased and have whitespace
+// collapsed, so "Beyoncé – Halo" and "Beyonce - Halo" share a key.
+func TrackKey(artist, title string) string {
+	a := normalizeKeyPart(artist)
+	t := normalizeKeyPart(title)
+	if a == "" || t == "" {
+		return ""
+	}
+	return a + " – " + t
+}
+
+// ParseTrackKey accepts "Artist - Title", "Artist – Title" or "Artist — Title" and returns TrackKey.
+func ParseTrackKey(s string) string {
+	for _, sep := range []string{" – ", " — ", " - "} {
+		if artist, title, ok := strings.Cut(s, sep); ok {
+			return TrackKey(artist, title)
+		}
+	}
+	return normalizeKeyPart(s)
+}
+
+func normalizeKeyPart(s string) string {
+	s = textnorm.RemoveFeaturing(textnorm.FoldAccents(textnorm.Punctuation(s)))
+	return collapseSpaces(strings.ToLower(s))
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Append adds e to the overrides file at path (creating it if needed). An existing entry with the same
+// source key is replaced so re-running the command corrects a previous choice.
+func Append(path string, e Entry) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("overrides file path is empty")
+	}
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	s, err := Load(path)
+	if err != nil {
+		return err
+	}
+	s.put(e)
+
+	raw, err := json.MarshalIndent(fileDoc{Overrides: s.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode overrides: %w", err)
+	}
+	raw = append(raw, '\n')
+
+	if err := fileutil.WriteFileAtomic(path, raw, 0o644); err != nil {
+		return fmt.Errorf("write overrides file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package overrides
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+func TestLoad_missingFileIsEmpty(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "nope.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("expected empty set, got %d", s.Len())
+	}
+}
+
+func TestLoad_rejectsInvalidEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	doc := `{"overrides":[{"isrc":"USXXX","rating_key":"1","skip":true}]}`
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected error for entry with two actions")
+	}
+}
+
+func TestSet_Find_priority(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	doc := `{"overrides":[
+		{"track":"Some Artist - Some Song","skip":true},
+		{"isrc":"usxxx1234567","rating_key":"42"},
+		{"source_id":"pl1:3","plex":{"title":"Other Song"}}
+	]}`
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr := track.Track{ID: "pl1:3", Name: "Some  Song", Artist: "some artist", ISRC: "USXXX1234567"}
+	e, ok := s.Find(tr)
+	if !ok || e.Action() != ActionLookup {
+		t.Fatalf("source id should win: %+v ok=%v", e, ok)
+	}
+
+	tr.ID = "pl2:1"
+	e, ok = s.Find(tr)
+	if !ok || e.Action() != ActionRatingKey || e.RatingKey != "42" {
+		t.Fatalf("ISRC should win over artist/title: %+v ok=%v", e, ok)
+	}
+
+	tr.ISRC = ""
+	e, ok = s.Find(tr)
+	if !ok || e.Action() != ActionSkip {
+		t.Fatalf("normalized artist – title should match: %+v ok=%v", e, ok)
+	}
+
+	if _, ok := s.Find(track.Track{Name: "Else", Artist: "Someone"}); ok {
+		t.Fatal("unexpected match")
+	}
+}
+
+func TestAppend_replacesSameKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	if err := Append(path, Entry{ISRC: "USXXX1234567", RatingKey: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Append(path, Entry{Track: "A – B", Skip: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Append(path, Entry{ISRC: "usxxx1234567", RatingKey: "2"}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 {
+		t.Fatalf("expected 2 entries, got %d: %+v", s.Len(), s.Entries())
+	}
+	e, ok := s.Find(track.Track{ISRC: "USXXX1234567"})
+	if !ok || e.RatingKey != "2" {
+		t.Fatalf("expected replaced entry, got %+v", e)
+	}
+}
+
+func TestAppend_rejectsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	if err := Append(path, Entry{ISRC: "X"}); err == nil {
+		t.Fatal("expected error for entry without action")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("file should not be created for invalid entry: %v", err)
+	}
+}
+
+func TestForTrack_matchesBack(t *testing.T) {
+	tests := []struct {
+		src  track.Track
+		want string
+	}{
+		{track.Track{ID: "pl:1", Artist: "A", Name: "B", ISRC: "USXXX1234567", MusicBrainzID: "mbid-1"}, "isrc:USXXX1234567"},
+		{track.Track{ID: "pl:2", Artist: "A", Name: "B", MusicBrainzID: "mbid-1"}, "mbid:mbid-1"},
+		{track.Track{ID: "pl:3", Artist: "Some  Artist", Name: "Some Song"}, "track:some artist – some song"},
+	}
+	for _, tt := range tests {
+		e := ForTrack(tt.src)
+		e.RatingKey = "1"
+		if got := e.Key(); got != tt.want {
+			t.Errorf("ForTrack(%+v).Key() = %q, want %q", tt.src, got, tt.want)
+		}
+		path := filepath.Join(t.TempDir(), "overrides.json")
+		if err := Append(path, e); err != nil {
+			t.Fatal(err)
+		}
+		s, err := Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		moved := tt.src
+		moved.ID = "pl:99"
+		if _, ok := s.Find(moved); !ok {
+			t.Errorf("entry %+v does not match %+v after the playlist was reordered", e, moved)
+		}
+	}
+}
+
+func TestSet_Find_trackKeyFolding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	if err := Append(path, Entry{Track: "Beyoncé – Halo (feat. Someone)", RatingKey: "7"}); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := s.Find(track.Track{Artist: "Beyonce", Name: "Halo"})
+	if !ok || e.RatingKey != "7" {
+		t.Fatalf("accents, dash and feat. credit should be folded: %+v ok=%v", e, ok)
+	}
+	if got, want := ParseTrackKey("Beyonce - Halo"), TrackKey("Beyoncé", "Halo"); got != want {
+		t.Errorf("ParseTrackKey = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,226 @@
+package plex
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+// PlexMusicAlbumType is the Plex metadata type for albums (search type=9).
+const PlexMusicAlbumType = "9"
+
+// albumFallbackStrategy and albumFallbackPhase name the album-then-track step in traces and statistics.
+const (
+	albumFallbackStrategy = "album fallback"
+	albumFallbackPhase    = "album"
+)
+
+// Album fallback thresholds. An album must clearly be the source album by the same artist before its
+// tracks are considered; a track-number hit still needs a similar title or duration to count.
+const (
+	albumFallbackMinAlbumSimilarity  = 0.85
+	albumFallbackMinArtistSimilarity = 0.6
+	albumFallbackMaxAlbums           = 3
+	albumFallbackTrackNumberTitleMin = 0.5
+	albumFallbackTrackNumberDuration = 0.97
+)
+
+// PlexAlbum is an album Directory from /search?type=9.
+type PlexAlbum struct {
+	ID         string `xml:"ratingKey,attr"`
+	Title      string `xml:"title,attr"`
+	Artist     string `xml:"parentTitle,attr"`
+	TrackCount int    `xml:"leafCount,attr"`
+}
+
+type plexAlbumResponse struct {
+	XMLName xml.Name    `xml:"MediaContainer"`
+	Albums  []PlexAlbum `xml:"Directory"`
+}
+
+// searchAlbumFallback looks for song in albums titled like song.Album by artist: it searches albums
+// (type 9), loads the best few albums' tracks and picks by track number or by title and duration. It
+// returns nil when the source has no album or no owned album matches, leaving the /all scan to run.
+func (c *Client) searchAlbumFallback(ctx context.Context, song track.Track, artist string) (*PlexTrack, error) {
+	if strings.TrimSpace(song.Album) == "" {
+		return nil, nil
+	}
+	queries := []string{strings.TrimSpace(song.Album)}
+	if clean := strings.TrimSpace(c.removeBrackets(song.Album)); clean != "" && clean != queries[0] {
+		queries = append(queries, clean)
+	}
+	var albums []PlexAlbum
+	for _, q := range queries {
+		found, err := c.searchAlbums(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		if albums = c.matchingAlbums(found, song.Album, artist); len(albums) > 0 {
+			break
+		}
+	}
+	if len(albums) == 0 {
+		c.debugLog("🔍 albumFallback: no owned album like '%s' by '%s'", song.Album, artist)
+		return nil, nil
+	}
+
+	target := song
+	target.Artist = artist
+	var traced []TraceCandidate
+	for _, album := range albums {
+		tracks, err := c.albumTracks(ctx, album.ID)
+		if err != nil {
+			return nil, err
+		}
+		c.debugLog("🔍 albumFallback: checking %d tracks on '%s' by '%s'", len(tracks), album.Title, album.Artist)
+		tr, cands := c.pickAlbumTrack(target, tracks)
+		traced = append(traced, cands...)
+		if tr != nil {
+			c.debugLog("✅ albumFallback: '%s' matched '%s' on '%s'", song.Name, tr.Title, album.Title)
+			tracerFrom(ctx).recordCandidates(traced, tr, fmt.Sprintf("track on album '%s'", album.Title))
+			return tr, nil
+		}
+	}
+	tracerFrom(ctx).recordCandidates(traced, nil, "no track on the matching albums")
+	return nil, nil
+}
+
+// matchingAlbums keeps albums similar to sourceAlbum by artist, best first, at most albumFallbackMaxAlbums.
+// "Various Artists" compilations are kept too but rank below the artist's own albums.
+func (c *Client) matchingAlbums(found []PlexAlbum, sourceAlbum, artist string) []PlexAlbum {
+	type scored struct {
+		album PlexAlbum
+		score float64
+	}
+	var keep []scored
+	for _, a := range found {
+		albumSim := c.bestAlbumSimilarity(sourceAlbum, a.Title)
+		if albumSim < albumFallbackMinAlbumSimilarity {
+			continue
+		}
+		artistSim := albumFallbackMinArtistSimilarity
+		if !strings.EqualFold(strings.TrimSpace(a.Artist), "various artists") {
+			artistSim = c.bestPlexTrackArtistSimilarity(artist, PlexTrack{Artist: a.Artist})
+			if artistSim < albumFallbackMinArtistSimilarity {
+				continue
+			}
+		}
+		keep = append(keep, scored{a, albumSim + artistSim})
+	}
+	sort.SliceStable(keep, func(i, j int) bool { return keep[i].score > keep[j].score })
+	out := make([]PlexAlbum, 0, min(len(keep), albumFallbackMaxAlbums))
+	for i := 0; i < len(keep) && i < albumFallbackMaxAlbums; i++ {
+		out = append(out, keep[i].album)
+	}
+	return out
+}
+
+// pickAlbumTrack chooses song among one album's tracks. With a source track number, the track at that
+// position wins when its title or duration agrees; otherwise the best title (and duration, when both
+// sides have one) score at or above the match threshold wins.
+func (c *Client) pickAlbumTrack(song track.Track, tracks []PlexTrack) (*PlexTrack, []TraceCandidate) {
+	var cands []TraceCandidate
+	var best *PlexTrack
+	var bestScore float64
+	for i := range tracks {
+		tr := tracks[i]
+		sc := c.confidenceScores(song, &tr)
+		if sc.Artist < albumFallbackMinArtistSimilarity {
+			// Compilation tracks by someone else (the album artist check passed on "Various Artists").
+			continue
+		}
+		total := sc.Title
+		if song.Duration > 0 && tr.Duration > 0 {
+			total = sc.Title*0.75 + sc.Duration*0.25
+		}
+		total -= sc.VersionPenalty
+		cands = append(cands, newTraceCandidate(tr, TraceScores{
+			Title: sc.Title, Artist: sc.Artist, Album: sc.Album, Duration: sc.Duration, VersionPenalty: sc.VersionPenalty, Total: total,
+		}))
+
+		if song.TrackNumber > 0 && tr.Index == song.TrackNumber &&
+			(song.DiscNumber == 0 || tr.ParentIndex == 0 || tr.ParentIndex == song.DiscNumber) &&
+			(sc.Title >= albumFallbackTrackNumberTitleMin || sc.Duration >= albumFallbackTrackNumberDuration) {
+			c.debugLog("   albumFallback: track number %d is '%s' (title %s, duration %s)", song.TrackNumber, tr.Title,
+				formatConfidencePercent(sc.Title), formatConfidencePercent(sc.Duration))
+			return &tr, cands
+		}
+		if best == nil || total > bestScore+scoreEqEps {
+			best, bestScore = &tr, total
+		}
+	}
+	if best != nil && bestScore >= c.minMatchScore() {
+		return best, cands
+	}
+	return nil, cands
+}
+
+// searchAlbums runs an album search in the library section (GET /library/sections/{id}/search?type=9).
+func (c *Client) searchAlbums(ctx context.Context, query string) ([]PlexAlbum, error) {
+	reqURL := fmt.Sprintf("%s/library/sections/%d/search", c.baseURL, c.currentLibrarySection(ctx).ID)
+	params := url.Values{}
+	params.Add("X-Plex-Token", c.token)
+	params.Add("query", query)
+	params.Add("type", PlexMusicAlbumType)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create album search request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make album search request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, newPlexHTTPError(resp.StatusCode, "search albums", b)
+	}
+
+	var mc plexAlbumResponse
+	if err := decodePlexResponseXML(resp, &mc); err != nil {
+		return nil, fmt.Errorf("failed to decode album search response: %w", err)
+	}
+	return mc.Albums, nil
+}
+
+// albumTracks lists an album's tracks (GET /library/metadata/{ratingKey}/children).
+func (c *Client) albumTracks(ctx context.Context, albumKey string) ([]PlexTrack, error) {
+	reqURL := fmt.Sprintf("%s/library/metadata/%s/children", strings.TrimSuffix(c.baseURL, "/"), url.PathEscape(albumKey))
+	params := url.Values{}
+	params.Add("X-Plex-Token", c.token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create album tracks request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make album tracks request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, newPlexHTTPError(resp.StatusCode, "album tracks", b)
+	}
+
+	var mc PlexResponse
+	if err := decodePlexResponseXML(resp, &mc); err != nil {
+		return nil, fmt.Errorf("failed to decode album tracks response: %w", err)
+	}
+	stampLibrarySection(c.currentLibrarySection(ctx), mc.Tracks)
+	return mc.Tracks, nil
+}
@@ -0,0 +1,113 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// albumLibraryServer serves an empty track search, one album "Debut (Deluxe)" by Band with three tracks,
+// and fails the test if the /all scan is requested.
+func albumLibraryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/library/sections/1/search" && q.Get("type") == PlexMusicAlbumType:
+			if q.Get("query") == "Debut" {
+				_, _ = fmt.Fprint(w, `<MediaContainer>
+					<Directory type="album" ratingKey="50" title="Debut (Deluxe)" parentTitle="Band" leafCount="3"/>
+					<Directory type="album" ratingKey="60" title="Debut" parentTitle="Someone Else" leafCount="9"/>
+				</MediaContainer>`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+		case r.URL.Path == "/library/metadata/50/children":
+			_, _ = fmt.Fprint(w, `<MediaContainer>
+				<Track ratingKey="51" index="1" parentIndex="1" title="Opener" grandparentTitle="Band" parentTitle="Debut (Deluxe)" duration="200000"/>
+				<Track ratingKey="52" index="2" parentIndex="1" title="Hidden Track (Bonus)" grandparentTitle="Band" parentTitle="Debut (Deluxe)" duration="181000"/>
+				<Track ratingKey="53" index="3" parentIndex="1" title="Closer" grandparentTitle="Band" parentTitle="Debut (Deluxe)" duration="240000"/>
+			</MediaContainer>`)
+		case r.URL.Path == "/library/sections/1/all":
+			t.Errorf("album fallback match should not fall through to /all")
+			_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+		default:
+			_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+		}
+	}))
+}
+
+func TestSearchTrack_albumFallbackByTitleAndDuration(t *testing.T) {
+	t.Parallel()
+	ts := albumLibraryServer(t)
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 1}})
+	song := track.Track{Name: "Hidden Track", Artist: "Band", Album: "Debut", Duration: 180000}
+	tr, kind, err := c.SearchTrack(context.Background(), song)
+	if err != nil || tr == nil || tr.ID != "52" || kind != MatchTypeTitleArtist {
+		t.Fatalf("SearchTrack = %v, %s, %v; want rating key 52", tr, kind, err)
+	}
+	var hit bool
+	for _, st := range c.SearchStats().Strategies {
+		hit = hit || (st.Strategy == albumFallbackStrategy && st.Hits == 1)
+	}
+	if !hit {
+		t.Errorf("stats = %+v, want an album fallback hit", c.SearchStats().Strategies)
+	}
+}
+
+func TestSearchTrack_albumFallbackByTrackNumber(t *testing.T) {
+	t.Parallel()
+	ts := albumLibraryServer(t)
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 1}})
+	// The title alone is too far off, but track 3 with the same duration is the one.
+	song := track.Track{Name: "Closing Theme", Artist: "Band", Album: "Debut", Duration: 240500, TrackNumber: 3, DiscNumber: 1}
+	tr, _, err := c.SearchTrack(context.Background(), song)
+	if err != nil || tr == nil || tr.ID != "53" {
+		t.Fatalf("SearchTrack = %v, %v; want rating key 53", tr, err)
+	}
+}
+
+func TestSearchTrack_albumFallbackSkipped(t *testing.T) {
+	t.Parallel()
+	var albumSearches int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("type") == PlexMusicAlbumType {
+			albumSearches++
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{
+		URL: ts.URL, Token: "tok", LibrarySectionID: 1, SkipFullLibrarySearch: true, SkipAlbumFallback: true,
+	}})
+	if tr, _, err := c.SearchTrack(context.Background(), track.Track{Name: "Song", Artist: "Band", Album: "Debut"}); err != nil || tr != nil {
+		t.Fatalf("SearchTrack = %v, %v", tr, err)
+	}
+	if albumSearches != 0 {
+		t.Errorf("album searches = %d with SkipAlbumFallback", albumSearches)
+	}
+}
+
+func TestMatchingAlbums(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	got := c.matchingAlbums([]PlexAlbum{
+		{ID: "1", Title: "Other Record", Artist: "Band"},
+		{ID: "2", Title: "Debut", Artist: "Different Act"},
+		{ID: "3", Title: "Debut (Remastered)", Artist: "Band"},
+		{ID: "4", Title: "Debut", Artist: "Various Artists"},
+	}, "Debut", "Band")
+	if len(got) != 2 || got[0].ID != "3" || got[1].ID != "4" {
+		t.Errorf("matchingAlbums = %+v, want Band's remaster, then the Various Artists compilation", got)
+	}
+}
@@ -0,0 +1,65 @@
+package plex
+
+import (
+	"strings"
+
+	"github.com/grrywlsn/plexify/aliases"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// SetArtistAliases installs the artist alias table (nil disables). Alias names are tried as extra artist
+// candidates in SearchTrack and count as the same artist when scoring.
+func (c *Client) SetArtistAliases(set *aliases.Set) {
+	c.artistAliases = set
+}
+
+// artistAliasNames returns the aliases of artist and of its primary listed artist.
+func (c *Client) artistAliasNames(artist string) []string {
+	if c.artistAliases.Len() == 0 {
+		return nil
+	}
+	names := c.artistAliases.Names(artist)
+	if primary := track.PrimaryListedArtist(artist); primary != strings.TrimSpace(artist) {
+		names = append(names, c.artistAliases.Names(primary)...)
+	}
+	return names
+}
+
+// artistSearchCandidates is song.PlexSearchArtistCandidates followed by the alias names of each candidate.
+func (c *Client) artistSearchCandidates(song track.Track) []string {
+	candidates := song.PlexSearchArtistCandidates()
+	if c.artistAliases.Len() == 0 {
+		return candidates
+	}
+	seen := make(map[string]bool, len(candidates))
+	for _, a := range candidates {
+		seen[aliases.Key(a)] = true
+	}
+	out := candidates
+	for _, a := range candidates {
+		for _, alias := range c.artistAliases.Names(a) {
+			if k := aliases.Key(alias); !seen[k] {
+				seen[k] = true
+				out = append(out, alias)
+			}
+		}
+	}
+	return out
+}
+
+// bestAliasArtistSimilarity is the best artist similarity between any alias of artist and the Plex track's
+// artist fields (album artist, originalTitle, titleSort); 0 without aliases.
+func (c *Client) bestAliasArtistSimilarity(artist string, tr PlexTrack) float64 {
+	var best float64
+	for _, alias := range c.artistAliasNames(artist) {
+		for _, field := range []string{tr.Artist, tr.OriginalTitle, tr.GrandparentTitleSort} {
+			if strings.TrimSpace(field) == "" {
+				continue
+			}
+			if v := c.artistMatchSimilarity(alias, field); v > best {
+				best = v
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,71 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/grrywlsn/plexify/aliases"
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/track"
+)
+
+func testAliases() *aliases.Set {
+	s := &aliases.Set{}
+	s.Add(aliases.Artist{Name: "Diana Gordon", Aliases: []string{"Wynter Gordon"}})
+	s.Add(aliases.Artist{Name: "P!nk", Aliases: []string{"Pink"}})
+	return s
+}
+
+func TestArtistSearchCandidates_aliases(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	song := track.Track{Name: "Dirty Talk", Artist: "Wynter Gordon, Guest"}
+	if got, want := c.artistSearchCandidates(song), []string{"Wynter Gordon", "Wynter Gordon, Guest"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("without aliases = %q, want %q", got, want)
+	}
+	c.SetArtistAliases(testAliases())
+	if got, want := c.artistSearchCandidates(song), []string{"Wynter Gordon", "Wynter Gordon, Guest", "Diana Gordon"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("with aliases = %q, want %q", got, want)
+	}
+}
+
+func TestConfidenceScores_artistAliases(t *testing.T) {
+	t.Parallel()
+	song := track.Track{Name: "So What", Artist: "Pink"}
+	plexTrack := &PlexTrack{Title: "So What", Artist: "P!nk"}
+	c := &Client{}
+	before := c.confidenceScores(song, plexTrack).Artist
+	c.SetArtistAliases(testAliases())
+	if after := c.confidenceScores(song, plexTrack).Artist; after != 1 || after <= before {
+		t.Errorf("artist similarity = %v with aliases (was %v), want 1", after, before)
+	}
+}
+
+func TestSearchTrack_aliasCandidate(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "Dirty Talk Diana Gordon" {
+			_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="9" title="Dirty Talk" grandparentTitle="Diana Gordon"/></MediaContainer>`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{
+		URL: ts.URL, Token: "tok", LibrarySectionID: 1, SkipFullLibrarySearch: true,
+		SearchPhases: []string{config.SearchPhaseCombined},
+	}})
+	c.SetArtistAliases(testAliases())
+	tr, kind, err := c.SearchTrack(context.Background(), track.Track{Name: "Dirty Talk", Artist: "Wynter Gordon"})
+	if err != nil || tr == nil || tr.ID != "9" || kind != MatchTypeTitleArtist {
+		t.Fatalf("SearchTrack = %v, %s, %v", tr, kind, err)
+	}
+	if conf := c.TitleArtistConfidence(track.Track{Name: "Dirty Talk", Artist: "Wynter Gordon"}, tr); conf != 1 {
+		t.Errorf("confidence = %v, want 1", conf)
+	}
+}
@@ -3,6 +3,7 @@ package plex
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -99,7 +100,8 @@ func firstArtistElement(mc plexArtistMediaContainer) *plexArtistEl {
 	return nil
 }
 
-// getArtistTitleSortCached returns titleSort for key, using an in-memory cache.
+// getArtistTitleSortCached returns titleSort for key, using an in-memory cache. Rating keys are unique
+// across the server, so one cache serves every library section.
 func (c *Client) getArtistTitleSortCached(ctx context.Context, artistRatingKey string) (string, error) {
 	key := strings.TrimSpace(artistRatingKey)
 	if key == "" {
@@ -140,6 +142,9 @@ func (c *Client) enrichGrandparentSortTitles(ctx context.Context, tracks []PlexT
 			return ctx.Err()
 		}
 		if _, err := c.getArtistTitleSortCached(ctx, k); err != nil {
+			if errors.Is(err, errHTTPCallBudget) {
+				return err
+			}
 			slog.WarnContext(ctx, "plex artist sort fetch failed; continuing without titleSort for key",
 				"key", k, "err", err)
 		}
@@ -216,7 +221,9 @@ func (c *Client) findBestMatchWithOptionalArtistSortRetry(ctx context.Context, t
 	if len(tracks) == 0 {
 		return nil
 	}
-	if first := c.FindBestMatch(tracks, title, artist, sourceAlbum); first != nil {
+	versionTitle := qualifierTitle(ctx, title)
+	st := searchStateFrom(ctx)
+	if first := c.findBestMatch(tracks, title, artist, sourceAlbum, versionTitle, st); first != nil {
 		return first
 	}
 	var keysFilter map[string]struct{}
@@ -230,5 +237,5 @@ func (c *Client) findBestMatchWithOptionalArtistSortRetry(ctx context.Context, t
 		slog.WarnContext(ctx, "enrich grandparent sort titles failed", "err", err)
 		return nil
 	}
-	return c.FindBestMatch(tracks, title, artist, sourceAlbum)
+	return c.findBestMatch(tracks, title, artist, sourceAlbum, versionTitle, st)
 }
@@ -13,8 +13,10 @@ import (
 	"sync"
 	"time"
 
-	"github.com/LukeHagar/plexgo"
+	"github.com/grrywlsn/plexify/aliases"
 	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/normrules"
+	"github.com/grrywlsn/plexify/overrides"
 	"github.com/grrywlsn/plexify/track"
 )
 
@@ -39,6 +41,13 @@ const (
 	MatchTypeError       MatchKind = "error"
 	// MatchKindISRC is reserved for tests / future ISRC-based confidence.
 	MatchKindISRC MatchKind = "isrc"
+	// MatchTypeOverride is a track pinned or redirected by the manual overrides file.
+	MatchTypeOverride MatchKind = "override"
+	// MatchTypeSkipped is a source track the overrides file says to leave out (not reported as missing).
+	MatchTypeSkipped MatchKind = "skipped"
+	// MatchTypeReview is a best candidate held inside the review band: below the match threshold but
+	// above PLEXIFY_AUTO_REJECT_PERCENT. It needs a review decision before it joins a playlist.
+	MatchTypeReview MatchKind = "review"
 
 	// HTTP status codes
 	StatusOK        = http.StatusOK
@@ -53,23 +62,52 @@ const (
 type Client struct {
 	baseURL    string
 	token      string
-	sectionID  int
+	sectionID  int // first entry of sections; used where a single section is needed
 	serverID   string
 	httpClient *http.Client
 	// httpPipelineMu is held from the start of each outbound RoundTrip until the response body
 	// is closed (see pipelineLockTransport). Do not copy Client by value while using the client.
 	httpPipelineMu        sync.Mutex
 	debug                 bool
-	plexgoClient          *plexgo.PlexAPI
 	matchConcurrency      int
 	dryRun                bool
 	skipFullLibrarySearch bool
+	skipAlbumFallback     bool
 	exactMatchesOnly      bool
 	// matchConfidencePercent is the minimum combined match score (0–100) as a fraction in minMatchScore; nil means use config.DefaultMatchConfidencePercent (for tests using &Client{}).
 	matchConfidencePercent *int
+	// reviewFloorPercent is PLEXIFY_AUTO_REJECT_PERCENT when the review band reaches below the match
+	// threshold; nil otherwise. See reviewFloor.
+	reviewFloorPercent *int
 
 	artistSortMu    sync.Mutex
 	artistSortCache map[string]string // Plex artist ratingKey → titleSort from GET /library/metadata/{key}
+
+	// sections are the music library sections searched, in priority order; sectionRefs are the configured
+	// IDs or names they were resolved from (see ResolveLibrarySections).
+	sections    []LibrarySection
+	sectionRefs []string
+
+	overrides *overrides.Set // manual match overrides consulted before searching; nil = none
+	normRules *normrules.Set // user-defined normalization rules; nil = built-ins only
+	// artistAliases expands artist search candidates and artist scoring with alias names; nil = none.
+	artistAliases *aliases.Set
+
+	// versionMismatchPenaltyPercent is the score deduction per mismatched version qualifier; nil means config.DefaultVersionMismatchPenaltyPercent.
+	versionMismatchPenaltyPercent *int
+	// versionPrefs orders tie-breaks between equally scored versions; nil means config.DefaultVersionPreferences.
+	versionPrefs []string
+	// qualityPrefs breaks remaining ties by audio quality or library folder (PLEXIFY_QUALITY_PREFERENCES); nil = none.
+	qualityPrefs []string
+
+	scorer Scorer // string similarity for title/artist/album scoring; nil = heuristic default
+
+	traceMatches bool // record a MatchTrace on every MatchResult
+
+	searchStrategies     []string      // indexed strategy names in run order; nil = all, default order
+	searchPhases         []searchPhase // indexed /search phases in run order; nil = combined, then title/artist
+	maxHTTPCallsPerTrack int           // Plex requests allowed per source track; 0 = unlimited
+	strategyStats        strategyStats
 }
 
 // PlexTrack represents a track from Plex search and library API responses.
@@ -86,9 +124,17 @@ type PlexTrack struct {
 	GrandparentTitleSort string `xml:"-"` // from Artist metadata titleSort, not on Track XML
 	Album                string `xml:"parentTitle,attr"`
 	Duration             int    `xml:"duration,attr"`
+	Index                int    `xml:"index,attr"`       // track number on the album
+	ParentIndex          int    `xml:"parentIndex,attr"` // disc number
 	AddedAt              string `xml:"addedAt,attr"`
 	UpdatedAt            string `xml:"updatedAt,attr"`
 	File                 string `xml:"file,attr"`
+	// LibrarySectionID and LibrarySectionTitle name the library section the track was found in. Plex sets
+	// them on some responses; searches fill them in from the section they queried (see LibrarySections).
+	LibrarySectionID    int    `xml:"librarySectionID,attr"`
+	LibrarySectionTitle string `xml:"librarySectionTitle,attr"`
+	// Media lists the files (versions) backing the track, with codec and bitrate; see Quality.
+	Media []PlexMedia `xml:"Media"`
 }
 
 // DisplayArtist returns a label for user-facing output: the track artist when set, else the album artist.
@@ -160,6 +206,11 @@ type MatchResult struct {
 	PlexTrack   *PlexTrack
 	MatchType   MatchKind
 	Confidence  float64
+	// Score is the FindBestMatch score that accepted (MatchTypeTitleArtist) or held (MatchTypeReview) the
+	// track, the same score the match threshold is checked against; 0 for other match types.
+	Score float64
+	// Trace explains how the match was found; set only when SetTraceMatches(true) is on.
+	Trace *MatchTrace
 }
 
 // NewClient creates a new Plex client using TLS settings from cfg (InsecureSkipVerify defaults true in config.Load).
@@ -210,12 +261,6 @@ func NewClientWithTLSConfig(cfg *config.Config, skipTLSVerify bool) *Client {
 		base: newPipelineLockTransport(&c.httpPipelineMu, inner),
 	}
 
-	plexgoClient := plexgo.New(
-		plexgo.WithSecurity(cfg.Plex.Token),
-		plexgo.WithServerURL(cfg.Plex.URL),
-		plexgo.WithClient(httpClient),
-	)
-
 	mc := cfg.Plex.MatchConcurrency
 	if mc < 1 {
 		mc = 1
@@ -224,23 +269,49 @@ func NewClientWithTLSConfig(cfg *config.Config, skipTLSVerify bool) *Client {
 		mc = 32
 	}
 
-	mp := cfg.Plex.MatchConfidencePercent
-	mpCopy := mp
+	mpCopy := cfg.Plex.MatchConfidencePercent
+	if cfg.Plex.ReviewBandEnabled() && cfg.Plex.AutoRejectPercent < mpCopy {
+		floor := cfg.Plex.AutoRejectPercent
+		c.reviewFloorPercent = &floor
+	}
 	c.baseURL = cfg.Plex.URL
 	c.token = cfg.Plex.Token
 	c.sectionID = cfg.Plex.LibrarySectionID
+	c.sectionRefs = cfg.Plex.LibrarySections
+	c.sections = numericLibrarySections(cfg.Plex.LibrarySections, cfg.Plex.LibrarySectionID)
 	c.serverID = cfg.Plex.ServerID
 	c.httpClient = httpClient
 	c.debug = false
-	c.plexgoClient = plexgoClient
 	c.matchConcurrency = mc
 	c.dryRun = cfg.Plex.DryRun
 	c.skipFullLibrarySearch = cfg.Plex.SkipFullLibrarySearch
+	c.skipAlbumFallback = cfg.Plex.SkipAlbumFallback
 	c.exactMatchesOnly = cfg.Plex.ExactMatchesOnly
 	c.matchConfidencePercent = &mpCopy
+	vp := cfg.Plex.VersionMismatchPenaltyPercent
+	c.versionMismatchPenaltyPercent = &vp
+	c.versionPrefs = cfg.Plex.VersionPreferences
+	c.qualityPrefs = cfg.Plex.QualityPreferences
+	// config.validate rejects unknown names; a hand-built config still gets told about the fallback.
+	if s, err := NewScorer(cfg.Plex.SimilarityAlgorithm); err != nil {
+		slog.Warn("using the default heuristic similarity", "error", err)
+	} else {
+		c.scorer = s
+	}
+	c.searchPhases = searchPhasesFromConfig(cfg.Plex.SearchPhases)
+	c.maxHTTPCallsPerTrack = max(cfg.Plex.MaxHTTPCallsPerTrack, 0)
 	return c
 }
 
+// reviewFloor is the lowest score a best candidate may have and still be returned for review when no
+// strategy reaches minMatchScore. ok is false when the review band does not reach below the threshold.
+func (c *Client) reviewFloor() (floor float64, ok bool) {
+	if c.reviewFloorPercent == nil {
+		return 0, false
+	}
+	return float64(*c.reviewFloorPercent) / 100.0, true
+}
+
 func (c *Client) minMatchScore() float64 {
 	if c.matchConfidencePercent == nil {
 		return float64(config.DefaultMatchConfidencePercent) / 100.0
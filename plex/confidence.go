@@ -5,6 +5,7 @@ import (
 	"math"
 	"strings"
 
+	"github.com/grrywlsn/plexify/normrules"
 	"github.com/grrywlsn/plexify/track"
 )
 
@@ -57,6 +58,15 @@ func (c *Client) artistSimilarityTitleArtistForPlexField(song track.Track, plexF
 	); v > artistSimilarity {
 		artistSimilarity = v
 	}
+	if v := c.calculateStringSimilarity(
+		strings.ToLower(c.transliterate(track.PrimaryListedArtist(song.Artist))),
+		strings.ToLower(c.transliterate(plexField)),
+	); v > artistSimilarity {
+		artistSimilarity = v
+	}
+	if v, ok := c.userRulesSimilarity(normrules.ScopeArtist, song.Artist, plexField); ok && v > artistSimilarity {
+		artistSimilarity = v
+	}
 	return artistSimilarity
 }
 
@@ -76,6 +86,9 @@ func (c *Client) bestArtistSimilarityTitleArtist(song track.Track, plex *PlexTra
 			best = v
 		}
 	}
+	if v := c.bestAliasArtistSimilarity(song.Artist, *plex); v > best {
+		best = v
+	}
 	return best
 }
 
@@ -86,75 +99,65 @@ func (c *Client) calculateConfidence(song track.Track, plexTrack *PlexTrack, mat
 	}
 
 	switch matchType {
-	case MatchTypeTitleArtist:
-		titleSimilarity := c.calculateStringSimilarity(strings.ToLower(song.Name), strings.ToLower(plexTrack.Title))
-		artistSimilarity := c.bestArtistSimilarityTitleArtist(song, plexTrack)
-
-		titleVariantPairs := []struct{ a, b string }{
-			{strings.ToLower(c.removeBrackets(song.Name)), strings.ToLower(c.removeBrackets(plexTrack.Title))},
-			{strings.ToLower(c.removeFeaturing(song.Name)), strings.ToLower(c.removeFeaturing(plexTrack.Title))},
-			{strings.ToLower(c.normalizeTitle(song.Name)), strings.ToLower(c.normalizeTitle(plexTrack.Title))},
-			{strings.ToLower(c.removeWith(song.Name)), strings.ToLower(c.removeWith(plexTrack.Title))},
-			{strings.ToLower(c.RemoveCommonSuffixes(song.Name)), strings.ToLower(c.RemoveCommonSuffixes(plexTrack.Title))},
-			{strings.ToLower(c.normalizeAccents(song.Name)), strings.ToLower(c.normalizeAccents(plexTrack.Title))},
-		}
-		for _, p := range titleVariantPairs {
-			sim := c.calculateStringSimilarity(p.a, p.b)
-			if sim > titleSimilarity {
-				titleSimilarity = sim
-			}
-		}
-
-		// Blend album only when both sides have album metadata (avoid punishing missing Plex parentTitle).
-		if strings.TrimSpace(song.Album) != "" && strings.TrimSpace(plexTrack.Album) != "" {
-			albumSim := c.bestAlbumSimilarity(song.Album, plexTrack.Album)
-			return (titleSimilarity * 0.55) + (artistSimilarity * 0.25) + (albumSim * 0.20)
-		}
-		return (titleSimilarity * 0.7) + (artistSimilarity * 0.3)
+	case MatchTypeTitleArtist, MatchTypeReview:
+		return c.confidenceScores(song, plexTrack).Total
+	case MatchTypeOverride:
+		// The user chose this track explicitly; similarity to the source metadata is irrelevant.
+		return 1.0
 	default:
 		return 0.0
 	}
 }
 
-// calculateStringSimilarity calculates similarity between two strings
-func (c *Client) calculateStringSimilarity(s1, s2 string) float64 {
-	if s1 == s2 {
-		return 1.0
-	}
-
-	if s1 == "" || s2 == "" {
-		return 0.0
-	}
-
-	if strings.Contains(s1, s2) || strings.Contains(s2, s1) {
-		longer := s1
-		shorter := s2
-		if len(s2) > len(s1) {
-			longer = s2
-			shorter = s1
+// confidenceScores breaks the title/artist confidence of plexTrack for song into its sub-scores. Total is
+// the blended score with the version penalty applied (floored at 0).
+func (c *Client) confidenceScores(song track.Track, plexTrack *PlexTrack) TraceScores {
+	titleSimilarity := c.calculateStringSimilarity(strings.ToLower(song.Name), strings.ToLower(plexTrack.Title))
+	artistSimilarity := c.bestArtistSimilarityTitleArtist(song, plexTrack)
+
+	titleVariantPairs := []struct{ a, b string }{
+		{strings.ToLower(c.removeBrackets(song.Name)), strings.ToLower(c.removeBrackets(plexTrack.Title))},
+		{strings.ToLower(c.removeFeaturing(song.Name)), strings.ToLower(c.removeFeaturing(plexTrack.Title))},
+		{strings.ToLower(c.normalizeTitle(song.Name)), strings.ToLower(c.normalizeTitle(plexTrack.Title))},
+		{strings.ToLower(c.removeWith(song.Name)), strings.ToLower(c.removeWith(plexTrack.Title))},
+		{strings.ToLower(c.RemoveCommonSuffixes(song.Name)), strings.ToLower(c.RemoveCommonSuffixes(plexTrack.Title))},
+		{strings.ToLower(c.normalizeAccents(song.Name)), strings.ToLower(c.normalizeAccents(plexTrack.Title))},
+		{strings.ToLower(c.transliterate(song.Name)), strings.ToLower(c.transliterate(plexTrack.Title))},
+	}
+	if c.hasUserRules() {
+		titleVariantPairs = append(titleVariantPairs, struct{ a, b string }{
+			strings.ToLower(c.applyUserRules(normrules.ScopeTitle, song.Name)), strings.ToLower(c.applyUserRules(normrules.ScopeTitle, plexTrack.Title)),
+		})
+	}
+	for _, p := range titleVariantPairs {
+		sim := c.calculateStringSimilarity(p.a, p.b)
+		if sim > titleSimilarity {
+			titleSimilarity = sim
 		}
-		return float64(len(shorter)) / float64(len(longer))
 	}
 
-	words1 := strings.Fields(s1)
-	words2 := strings.Fields(s2)
-
-	if len(words1) == 0 || len(words2) == 0 {
-		return 0.0
+	sc := TraceScores{
+		Title:          titleSimilarity,
+		Artist:         artistSimilarity,
+		Duration:       durationSimilarity(song.Duration, plexTrack.Duration),
+		VersionPenalty: c.versionMismatchPenalty(song.Name, song.Album, *plexTrack),
 	}
-
-	matchingWords := 0
-	for _, word1 := range words1 {
-		for _, word2 := range words2 {
-			if word1 == word2 {
-				matchingWords++
-				break
-			}
-		}
+	var score float64
+	// Blend album only when both sides have album metadata (avoid punishing missing Plex parentTitle).
+	if strings.TrimSpace(song.Album) != "" && strings.TrimSpace(plexTrack.Album) != "" {
+		sc.Album = c.bestAlbumSimilarity(song.Album, plexTrack.Album)
+		score = (titleSimilarity * 0.55) + (artistSimilarity * 0.25) + (sc.Album * 0.20)
+	} else {
+		score = (titleSimilarity * 0.7) + (artistSimilarity * 0.3)
 	}
+	sc.Total = math.Max(0, score-sc.VersionPenalty)
+	return sc
+}
 
-	wordSimilarity := float64(matchingWords) / float64(max(len(words1), len(words2)))
-	lengthSimilarity := 1.0 - float64(intAbs(len(s1)-len(s2)))/float64(max(len(s1), len(s2)))
-
-	return (wordSimilarity * 0.7) + (lengthSimilarity * 0.3)
+// calculateStringSimilarity calculates similarity between two strings using the configured Scorer.
+func (c *Client) calculateStringSimilarity(s1, s2 string) float64 {
+	if s1 == s2 {
+		return 1.0
+	}
+	return c.similarityScorer().Similarity(s1, s2)
 }
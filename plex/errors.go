@@ -15,6 +15,10 @@ import (
 
 const maxPlexHTTPErrorBody = 2048
 
+// errHTTPCallBudget is returned by httpDo when the current source track has used up its
+// PLEXIFY_MAX_HTTP_CALLS_PER_TRACK requests. The search pipeline treats it as "no match", not a failure.
+var errHTTPCallBudget = errors.New("per-track Plex HTTP call budget exhausted")
+
 // PlexHTTPError is returned when the Plex HTTP API responds with an unexpected status code.
 // Transient statuses (5xx, 408, 429) are recognized by [isTransientPlexErr] for retries and soft-degrades.
 type PlexHTTPError struct {
@@ -20,11 +20,20 @@ import (
 // [http.Client.Timeout] must be zero when using custom RoundTripper wrappers: if the
 // transport is not *http.Transport, net/http falls back to setRequestCancel's legacy
 // timer goroutine, which can fatally error on Go 1.26+ ("select on synctest…").
+//
+// Requests made while matching a source track count against PLEXIFY_MAX_HTTP_CALLS_PER_TRACK; once the
+// budget is spent httpDo fails with errHTTPCallBudget without sending anything.
 func (c *Client) httpDo(req *http.Request) (*http.Response, error) {
 	if req == nil {
 		return nil, fmt.Errorf("nil request")
 	}
 	baseCtx := req.Context()
+	if st := searchStateFrom(baseCtx); st != nil && st.httpCalls != nil {
+		if n := st.httpCalls.Add(1); c.maxHTTPCallsPerTrack > 0 && n > int64(c.maxHTTPCallsPerTrack) {
+			return nil, errHTTPCallBudget
+		}
+	}
+	tracerFrom(baseCtx).recordRequest(req)
 	sendCtx := baseCtx
 	var cancel context.CancelFunc
 	if _, hasDeadline := baseCtx.Deadline(); !hasDeadline {
@@ -4,16 +4,11 @@ import "regexp"
 
 // Precompiled patterns for title normalization (avoid MustCompile per call).
 var (
-	reStripParens       = regexp.MustCompile(`\([^)]*\)`)
-	reStripSquare       = regexp.MustCompile(`\[[^\]]*\]`)
-	reStripCurly        = regexp.MustCompile(`\{[^}]*\}`)
-	reCollapseSpace     = regexp.MustCompile(`\s+`)
-	reFeaturingInParens = []*regexp.Regexp{
-		regexp.MustCompile(`(?i)\s*\(feat\.?\s+[^)]+\)`),
-		regexp.MustCompile(`(?i)\s*\(featuring\s+[^)]+\)`),
-		regexp.MustCompile(`(?i)\s*\(ft\.?\s+[^)]+\)`),
-	}
-	reWithWord = regexp.MustCompile(`(?i)\bwith\b`)
+	reStripParens   = regexp.MustCompile(`\([^)]*\)`)
+	reStripSquare   = regexp.MustCompile(`\[[^\]]*\]`)
+	reStripCurly    = regexp.MustCompile(`\{[^}]*\}`)
+	reCollapseSpace = regexp.MustCompile(`\s+`)
+	reWithWord      = regexp.MustCompile(`(?i)\bwith\b`)
 
 	reYearRemastered = []*regexp.Regexp{
 		regexp.MustCompile(`(?i)\s*-\s*\d{4}\s+remastered\s*$`),
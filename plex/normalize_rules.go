@@ -0,0 +1,80 @@
+package plex
+
+import (
+	"strings"
+
+	"github.com/grrywlsn/plexify/normrules"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// userRulesStrategy names the search strategy that queries with PLEXIFY_NORMALIZATION_RULES applied.
+const userRulesStrategy = "user rules"
+
+// SetNormalizationRules installs user-defined normalization rules (nil disables). They add a "user rules"
+// search strategy and an extra title/artist/album variant to scoring, alongside the built-in cleanups.
+func (c *Client) SetNormalizationRules(set *normrules.Set) {
+	c.normRules = set
+}
+
+// hasUserRules reports whether any user normalization rules are loaded.
+func (c *Client) hasUserRules() bool {
+	return c.normRules.Len() > 0
+}
+
+// applyUserRules runs the user rules for scope over s; s is returned unchanged without rules.
+func (c *Client) applyUserRules(scope normrules.Scope, s string) string {
+	return c.normRules.Apply(scope, s)
+}
+
+// userRulesSimilarity compares a and b with the scope's user rules applied to both sides. ok is false when
+// no rules are loaded or neither side changed, so callers only log real variants.
+func (c *Client) userRulesSimilarity(scope normrules.Scope, a, b string) (sim float64, ok bool) {
+	if !c.hasUserRules() {
+		return 0, false
+	}
+	ra, rb := c.applyUserRules(scope, a), c.applyUserRules(scope, b)
+	if ra == a && rb == b {
+		return 0, false
+	}
+	return c.calculateStringSimilarity(strings.ToLower(strings.TrimSpace(ra)), strings.ToLower(strings.TrimSpace(rb))), true
+}
+
+// NormalizationStep is one built-in cleanup applied to a string, as shown by the normalize command.
+type NormalizationStep struct {
+	Name   string
+	Output string
+}
+
+// BuiltinNormalizations applies each built-in cleanup used for scope to s independently, in the order
+// the search pipeline tries them. Matching compares every variant, so they do not chain.
+func BuiltinNormalizations(scope normrules.Scope, s string) []NormalizationStep {
+	c := &Client{}
+	var out []NormalizationStep
+	add := func(name string, fn func(string) string) {
+		out = append(out, NormalizationStep{Name: name, Output: fn(s)})
+	}
+	switch scope {
+	case normrules.ScopeArtist:
+		add("punctuation normalized", c.normalizePunctuation)
+		add("featuring removed", c.removeFeaturing)
+		add("primary listed artist", track.PrimaryListedArtist)
+		add("accent normalization", c.normalizeAccents)
+		add("transliterated", c.transliterate)
+	case normrules.ScopeAlbum:
+		add("normalized title", c.normalizeTitle)
+		add("brackets removed", c.removeBrackets)
+		add("accent normalization", c.normalizeAccents)
+		add("transliterated", c.transliterate)
+	default:
+		add("punctuation normalized", c.normalizePunctuation)
+		add("brackets removed", c.removeBrackets)
+		add("featuring removed", c.removeFeaturing)
+		add("featuring removed + normalized", func(s string) string { return c.normalizeTitle(c.removeFeaturing(s)) })
+		add("normalized title", c.normalizeTitle)
+		add("with removed", c.removeWith)
+		add("suffixes removed", c.RemoveCommonSuffixes)
+		add("accent normalization", c.normalizeAccents)
+		add("transliterated", c.transliterate)
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/normrules"
+	"github.com/grrywlsn/plexify/track"
+)
+
+func loadTestRules(t *testing.T, doc string) *normrules.Set {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	set, err := normrules.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return set
+}
+
+const sessionRules = `{"rules":[{"name":"session","pattern":"(?i)\\s*~\\s*spotify session$"}]}`
+
+func TestSearchTrack_userRulesStrategy(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "Song" {
+			_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="7" title="Song" grandparentTitle="Band"/></MediaContainer>`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{
+		URL: ts.URL, Token: "tok", LibrarySectionID: 1, SkipFullLibrarySearch: true,
+		SearchPhases: []string{config.SearchPhaseTitleArtist},
+	}})
+	if err := c.SetSearchStrategies([]string{"exact title/artist", "user rules"}); err != nil {
+		t.Fatal(err)
+	}
+	song := track.Track{Name: "Song ~ Spotify Session", Artist: "Band"}
+
+	if tr, _, err := c.SearchTrack(context.Background(), song); err != nil || tr != nil {
+		t.Fatalf("without rules: SearchTrack = %v, %v; want no match", tr, err)
+	}
+
+	c.SetNormalizationRules(loadTestRules(t, sessionRules))
+	tr, kind, err := c.SearchTrack(context.Background(), song)
+	if err != nil || tr == nil || tr.ID != "7" || kind != MatchTypeTitleArtist {
+		t.Fatalf("with rules: SearchTrack = %v, %s, %v", tr, kind, err)
+	}
+	stats := c.SearchStats().Strategies
+	if last := stats[len(stats)-1]; last.Strategy != userRulesStrategy || last.Hits != 1 {
+		t.Errorf("stats = %+v, want a user rules hit", stats)
+	}
+}
+
+func TestConfidenceScores_userRules(t *testing.T) {
+	t.Parallel()
+	song := track.Track{Name: "Song ~ Spotify Session", Artist: "Band"}
+	plexTrack := &PlexTrack{Title: "Song", Artist: "Band"}
+
+	c := &Client{}
+	before := c.confidenceScores(song, plexTrack).Title
+	c.SetNormalizationRules(loadTestRules(t, sessionRules))
+	if after := c.confidenceScores(song, plexTrack).Title; after != 1 || after <= before {
+		t.Errorf("title similarity = %v with rules (was %v), want 1", after, before)
+	}
+	if got := c.FindBestMatch([]PlexTrack{*plexTrack}, song.Name, song.Artist, ""); got == nil {
+		t.Error("FindBestMatch should accept the rule-normalized title")
+	}
+}
+
+func TestBuiltinNormalizations(t *testing.T) {
+	t.Parallel()
+	steps := BuiltinNormalizations(normrules.ScopeTitle, "Song (feat. Guest)")
+	byName := map[string]string{}
+	for _, st := range steps {
+		byName[st.Name] = st.Output
+	}
+	if byName["featuring removed"] != "Song" {
+		t.Errorf("featuring removed = %q", byName["featuring removed"])
+	}
+	if len(BuiltinNormalizations(normrules.ScopeArtist, "A")) == 0 || len(BuiltinNormalizations(normrules.ScopeAlbum, "A")) == 0 {
+		t.Error("artist and album scopes should list their built-in cleanups")
+	}
+}
@@ -0,0 +1,106 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/grrywlsn/plexify/overrides"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// SetOverrides installs the manual match overrides consulted at the start of SearchTrack (nil disables).
+func (c *Client) SetOverrides(set *overrides.Set) {
+	c.overrides = set
+}
+
+// searchOverride applies a manual override for song, if one exists. ok is false when no override matched
+// and the regular search pipeline should run.
+func (c *Client) searchOverride(ctx context.Context, song track.Track) (tr *PlexTrack, kind MatchKind, ok bool, err error) {
+	entry, found := c.overrides.Find(song)
+	if !found {
+		return nil, "", false, nil
+	}
+	c.debugLog("📌 SearchTrack: override %s (%s) for '%s' by '%s'", entry.Key(), entry.Action(), song.Name, song.Artist)
+	tracerFrom(ctx).noteOverride(fmt.Sprintf("%s (%s)", entry.Key(), entry.Action()))
+
+	switch entry.Action() {
+	case overrides.ActionSkip:
+		return nil, MatchTypeSkipped, true, nil
+	case overrides.ActionRatingKey:
+		tr, err := c.GetTrackByRatingKey(ctx, entry.RatingKey)
+		if err != nil {
+			return nil, MatchTypeError, true, fmt.Errorf("override %s: %w", entry.Key(), err)
+		}
+		return tr, MatchTypeOverride, true, nil
+	case overrides.ActionLookup:
+		target := song
+		if s := strings.TrimSpace(entry.Plex.Title); s != "" {
+			target.Name = s
+		}
+		if s := strings.TrimSpace(entry.Plex.Artist); s != "" {
+			target.Artist = s
+			target.MusicBrainzArtistCredits = nil
+		}
+		if s := strings.TrimSpace(entry.Plex.Album); s != "" {
+			target.Album = s
+		}
+		lookupCtx := withSearchState(ctx, newSearchState(ctx, target))
+		for _, artist := range c.artistSearchCandidates(target) {
+			tr, err := c.searchTrackWithArtist(lookupCtx, target, artist)
+			if err != nil {
+				return nil, MatchTypeError, true, err
+			}
+			if tr != nil {
+				return tr, MatchTypeOverride, true, nil
+			}
+		}
+		slog.WarnContext(ctx, "override lookup found no Plex track",
+			"override", entry.Key(), "title", target.Name, "artist", target.Artist, "album", target.Album)
+		return nil, MatchTypeNone, true, nil
+	default:
+		return nil, "", false, nil
+	}
+}
+
+// GetTrackByRatingKey fetches one library track via GET /library/metadata/{ratingKey}.
+func (c *Client) GetTrackByRatingKey(ctx context.Context, ratingKey string) (*PlexTrack, error) {
+	key := strings.TrimSpace(ratingKey)
+	if key == "" {
+		return nil, fmt.Errorf("rating key is empty")
+	}
+	reqURL := fmt.Sprintf("%s/library/metadata/%s", strings.TrimSuffix(c.baseURL, "/"), url.PathEscape(key))
+	params := url.Values{}
+	params.Add("X-Plex-Token", c.token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create track metadata request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make track metadata request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, newPlexHTTPError(resp.StatusCode, "track metadata", b)
+	}
+
+	var mc PlexResponse
+	if err := decodePlexResponseXML(resp, &mc); err != nil {
+		return nil, fmt.Errorf("failed to decode track metadata response: %w", err)
+	}
+	if len(mc.Tracks) == 0 {
+		return nil, fmt.Errorf("rating key %s is not a track", key)
+	}
+	tr := mc.Tracks[0]
+	return &tr, nil
+}
@@ -0,0 +1,101 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/overrides"
+	"github.com/grrywlsn/plexify/track"
+)
+
+func loadTestOverrides(t *testing.T, doc string) *overrides.Set {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	set, err := overrides.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return set
+}
+
+func TestSearchTrack_overrideRatingKey(t *testing.T) {
+	t.Parallel()
+
+	var searches atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/library/metadata/777" {
+			_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="777" title="Pinned" grandparentTitle="Someone"/></MediaContainer>`)
+			return
+		}
+		searches.Add(1)
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 1}})
+	c.SetOverrides(loadTestOverrides(t, `{"overrides":[{"isrc":"USXXX1234567","rating_key":"777"}]}`))
+
+	song := track.Track{Name: "Wrong Title", Artist: "Wrong Artist", ISRC: "USXXX1234567"}
+	tr, kind, err := c.SearchTrack(context.Background(), song)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != MatchTypeOverride || tr == nil || tr.ID != "777" {
+		t.Fatalf("got %v %+v", kind, tr)
+	}
+	if n := searches.Load(); n != 0 {
+		t.Fatalf("override should bypass search, got %d search requests", n)
+	}
+	if got := c.calculateConfidence(song, tr, kind); got != 1.0 {
+		t.Fatalf("override confidence %v, want 1", got)
+	}
+}
+
+func TestSearchTrack_overrideSkip(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{}
+	c.SetOverrides(loadTestOverrides(t, `{"overrides":[{"track":"Artist – Song","skip":true}]}`))
+
+	tr, kind, err := c.SearchTrack(context.Background(), track.Track{Name: "Song", Artist: "ARTIST"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != MatchTypeSkipped || tr != nil {
+		t.Fatalf("got %v %+v", kind, tr)
+	}
+}
+
+func TestSearchTrack_overrideLookup(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "Real Title Real Artist" {
+			_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="5" title="Real Title" grandparentTitle="Real Artist"/></MediaContainer>`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 1, SkipFullLibrarySearch: true}})
+	c.SetOverrides(loadTestOverrides(t, `{"overrides":[{"source_id":"pl1:2","plex":{"artist":"Real Artist","title":"Real Title"}}]}`))
+
+	tr, kind, err := c.SearchTrack(context.Background(), track.Track{ID: "pl1:2", Name: "Typo Title", Artist: "Typo Artist"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != MatchTypeOverride || tr == nil || tr.ID != "5" {
+		t.Fatalf("got %v %+v", kind, tr)
+	}
+}
@@ -0,0 +1,222 @@
+package plex
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+// fullLibraryStrategy and fullLibraryPhase name the /all scan in traces and strategy statistics.
+const (
+	fullLibraryStrategy = "full library"
+	fullLibraryPhase    = "all"
+)
+
+// SearchStrategyNames lists the indexed search strategies in their default order.
+func SearchStrategyNames() []string {
+	strategies := (&Client{}).indexedTrackSearchStrategies()
+	names := make([]string, len(strategies))
+	for i, s := range strategies {
+		names[i] = s.name
+	}
+	return names
+}
+
+// strategyKey folds a strategy name to lowercase letters and digits so "featuring-removed",
+// "Featuring removed" and "featuring_removed" all name the same strategy.
+func strategyKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SetSearchStrategies selects and orders the indexed search strategies (PLEXIFY_SEARCH_STRATEGIES). Each
+// entry names a strategy from SearchStrategyNames. Entries prefixed with "!" instead remove strategies from
+// the default order; the two forms cannot be mixed. An empty list restores the default pipeline.
+func (c *Client) SetSearchStrategies(names []string) error {
+	if len(names) == 0 {
+		c.searchStrategies = nil
+		return nil
+	}
+	known := map[string]string{}
+	defaults := SearchStrategyNames()
+	for _, n := range defaults {
+		known[strategyKey(n)] = n
+	}
+	resolve := func(raw string) (string, error) {
+		name, ok := known[strategyKey(raw)]
+		if !ok {
+			return "", fmt.Errorf("unknown search strategy %q (known: %s)", raw, strings.Join(defaults, ", "))
+		}
+		return name, nil
+	}
+
+	negated := 0
+	for _, n := range names {
+		if strings.HasPrefix(strings.TrimSpace(n), "!") {
+			negated++
+		}
+	}
+	var out []string
+	switch negated {
+	case 0:
+		seen := map[string]bool{}
+		for _, raw := range names {
+			name, err := resolve(raw)
+			if err != nil {
+				return err
+			}
+			if !seen[name] {
+				seen[name] = true
+				out = append(out, name)
+			}
+		}
+	case len(names):
+		drop := map[string]bool{}
+		for _, raw := range names {
+			name, err := resolve(strings.TrimPrefix(strings.TrimSpace(raw), "!"))
+			if err != nil {
+				return err
+			}
+			drop[name] = true
+		}
+		for _, name := range defaults {
+			if !drop[name] {
+				out = append(out, name)
+			}
+		}
+	default:
+		return fmt.Errorf("search strategies: list the strategies to run, or prefix every entry with ! to disable it, not both")
+	}
+	if len(out) == 0 {
+		return fmt.Errorf("search strategies: at least one strategy must stay enabled")
+	}
+	c.searchStrategies = out
+	return nil
+}
+
+// activeTrackSearchStrategies returns the strategies searchTrackWithArtist runs, in order. ExactMatchesOnly
+// always means the raw title/artist strategy alone.
+func (c *Client) activeTrackSearchStrategies() []trackSearchStrategy {
+	all := c.indexedTrackSearchStrategies()
+	if c.exactMatchesOnly {
+		return all[:1]
+	}
+	if c.searchStrategies == nil {
+		return all
+	}
+	byName := make(map[string]trackSearchStrategy, len(all))
+	for _, s := range all {
+		byName[s.name] = s
+	}
+	out := make([]trackSearchStrategy, 0, len(c.searchStrategies))
+	for _, name := range c.searchStrategies {
+		if s, ok := byName[name]; ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// searchPhasesFromConfig maps PLEXIFY_SEARCH_PHASES values (validated by config) to phases; nil keeps the default.
+func searchPhasesFromConfig(names []string) []searchPhase {
+	if len(names) == 0 {
+		return nil
+	}
+	var out []searchPhase
+	for _, n := range names {
+		switch n {
+		case config.SearchPhaseCombined:
+			out = append(out, searchPhaseCombined)
+		case config.SearchPhaseTitleArtist:
+			out = append(out, searchPhaseTitleArtist)
+		}
+	}
+	return out
+}
+
+// activeSearchPhases returns the indexed /search phases to run, combined query first by default.
+func (c *Client) activeSearchPhases() []searchPhase {
+	if len(c.searchPhases) == 0 {
+		return []searchPhase{searchPhaseCombined, searchPhaseTitleArtist}
+	}
+	return c.searchPhases
+}
+
+// StrategyStat counts how often one strategy ran in one phase (sent at least one Plex request) and how
+// often it produced the match.
+type StrategyStat struct {
+	Strategy string
+	Phase    string
+	Runs     int
+	Hits     int
+}
+
+// SearchStats summarizes the search pipeline over every track matched by this client.
+type SearchStats struct {
+	Strategies []StrategyStat // pipeline order; strategies that never ran are omitted
+	// BudgetExhausted counts source tracks whose search was cut short by PLEXIFY_MAX_HTTP_CALLS_PER_TRACK.
+	BudgetExhausted int
+}
+
+type strategyStats struct {
+	mu              sync.Mutex
+	byKey           map[[2]string]*StrategyStat
+	budgetExhausted int
+}
+
+func (c *Client) recordStrategyRun(strategy, phase string, ran, hit bool) {
+	if !ran && !hit {
+		return
+	}
+	s := &c.strategyStats
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byKey == nil {
+		s.byKey = make(map[[2]string]*StrategyStat)
+	}
+	k := [2]string{strategy, phase}
+	st := s.byKey[k]
+	if st == nil {
+		st = &StrategyStat{Strategy: strategy, Phase: phase}
+		s.byKey[k] = st
+	}
+	st.Runs++
+	if hit {
+		st.Hits++
+	}
+}
+
+func (c *Client) recordBudgetExhausted() {
+	c.strategyStats.mu.Lock()
+	c.strategyStats.budgetExhausted++
+	c.strategyStats.mu.Unlock()
+}
+
+// SearchStats returns strategy hit statistics collected since the client was created.
+func (c *Client) SearchStats() SearchStats {
+	s := &c.strategyStats
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := SearchStats{BudgetExhausted: s.budgetExhausted}
+	add := func(strategy, phase string) {
+		if st := s.byKey[[2]string{strategy, phase}]; st != nil {
+			out.Strategies = append(out.Strategies, *st)
+		}
+	}
+	for _, phase := range c.activeSearchPhases() {
+		for _, strategy := range c.activeTrackSearchStrategies() {
+			add(strategy.name, phase.tierLabel())
+		}
+	}
+	add(albumFallbackStrategy, albumFallbackPhase)
+	add(fullLibraryStrategy, fullLibraryPhase)
+	return out
+}
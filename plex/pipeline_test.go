@@ -0,0 +1,134 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/track"
+)
+
+func strategyNames(s []trackSearchStrategy) []string {
+	out := make([]string, len(s))
+	for i, st := range s {
+		out[i] = st.name
+	}
+	return out
+}
+
+func TestSetSearchStrategies(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	if err := c.SetSearchStrategies([]string{"Accent-Normalization", "exact title/artist", "accent normalization"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strategyNames(c.activeTrackSearchStrategies()), []string{"accent normalization", "exact title/artist"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ordered list: %v, want %v", got, want)
+	}
+
+	if err := c.SetSearchStrategies([]string{"!transliterated", "! with removed"}); err != nil {
+		t.Fatal(err)
+	}
+	got := strategyNames(c.activeTrackSearchStrategies())
+	if len(got) != len(SearchStrategyNames())-2 || got[0] != "exact title/artist" {
+		t.Errorf("negated list: %v", got)
+	}
+	for _, name := range got {
+		if name == "transliterated" || name == "with removed" {
+			t.Errorf("%q should be disabled: %v", name, got)
+		}
+	}
+
+	for _, bad := range [][]string{{"soundex"}, {"exact title/artist", "!transliterated"}} {
+		if err := c.SetSearchStrategies(bad); err == nil {
+			t.Errorf("SetSearchStrategies(%q): expected error", bad)
+		}
+	}
+
+	c.SetExactMatchesOnly(true)
+	if got := strategyNames(c.activeTrackSearchStrategies()); !reflect.DeepEqual(got, []string{"exact title/artist"}) {
+		t.Errorf("exact-matches-only: %v", got)
+	}
+}
+
+func TestSearchTrack_searchPhasesAndStats(t *testing.T) {
+	t.Parallel()
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("query")
+		queries = append(queries, q)
+		if q == "Song" {
+			_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="1" title="Song" grandparentTitle="Band"/></MediaContainer>`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{
+		URL: ts.URL, Token: "tok", LibrarySectionID: 1, SkipFullLibrarySearch: true,
+		SearchPhases: []string{config.SearchPhaseTitleArtist},
+	}})
+	if err := c.SetSearchStrategies([]string{"exact title/artist"}); err != nil {
+		t.Fatal(err)
+	}
+	tr, kind, err := c.SearchTrack(context.Background(), track.Track{Name: "Song", Artist: "Band"})
+	if err != nil || tr == nil || kind != MatchTypeTitleArtist {
+		t.Fatalf("SearchTrack = %v, %s, %v", tr, kind, err)
+	}
+	if !reflect.DeepEqual(queries, []string{"Song"}) {
+		t.Errorf("queries = %q, want title search only (no combined query)", queries)
+	}
+	want := []StrategyStat{{Strategy: "exact title/artist", Phase: searchPhaseTitleArtist.tierLabel(), Runs: 1, Hits: 1}}
+	if got := c.SearchStats().Strategies; !reflect.DeepEqual(got, want) {
+		t.Errorf("stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestSearchTrack_httpCallBudget(t *testing.T) {
+	t.Parallel()
+	var requests atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 1, MaxHTTPCallsPerTrack: 3}})
+	songs := []track.Track{{Name: "Song (Live) [Remastered]", Artist: "Band feat. Guest"}, {Name: "Other", Artist: "Band"}}
+	for _, song := range songs {
+		tr, kind, err := c.SearchTrack(context.Background(), song)
+		if err != nil || tr != nil || kind != MatchTypeNone {
+			t.Fatalf("SearchTrack(%q) = %v, %s, %v; want a plain miss", song.Name, tr, kind, err)
+		}
+	}
+	if got := requests.Load(); got != 6 {
+		t.Errorf("Plex saw %d requests, want 3 per track", got)
+	}
+	if got := c.SearchStats().BudgetExhausted; got == 0 {
+		t.Error("expected budget exhaustion to be counted")
+	}
+}
+
+func TestSearchTrack_httpCallBudgetCountedOncePerTrack(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 1, MaxHTTPCallsPerTrack: 2}})
+	// Two artist candidates ("Band" and the full credit) would each hit the spent budget.
+	song := track.Track{Name: "Song", Artist: "Band, Guest"}
+	if _, _, err := c.SearchTrack(context.Background(), song); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.SearchStats().BudgetExhausted; got != 1 {
+		t.Errorf("BudgetExhausted = %d, want 1 for one track", got)
+	}
+}
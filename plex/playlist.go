@@ -61,7 +61,7 @@ func (c *Client) CreatePlaylist(ctx context.Context, title, description, trackUR
 		params.Add("summary", escapedDescription)
 	}
 
-	params.Add("X-Plex-Token", c.token)
+	params.Add("X-Plex-Token", c.playlistToken(ctx))
 
 	// Create request with empty body (matching Plex Web behavior)
 	req, err := http.NewRequestWithContext(ctx, "POST", reqURL+"?"+params.Encode(), nil)
@@ -70,7 +70,7 @@ func (c *Client) CreatePlaylist(ctx context.Context, title, description, trackUR
 	}
 
 	req.Header.Set("Accept", "application/json, text/plain, */*")
-	req.Header.Set("X-Plex-Token", c.token)
+	req.Header.Set("X-Plex-Token", c.playlistToken(ctx))
 
 	resp, err := c.httpDo(req)
 	if err != nil {
@@ -130,7 +130,7 @@ func (c *Client) GetPlaylists(ctx context.Context) ([]PlexPlaylist, error) {
 	for {
 		reqURL := fmt.Sprintf("%s/playlists", c.baseURL)
 		params := url.Values{}
-		params.Add("X-Plex-Token", c.token)
+		params.Add("X-Plex-Token", c.playlistToken(ctx))
 		params.Add("X-Plex-Container-Start", strconv.Itoa(offset))
 		params.Add("X-Plex-Container-Size", strconv.Itoa(pageSize))
 
@@ -139,7 +139,7 @@ func (c *Client) GetPlaylists(ctx context.Context) ([]PlexPlaylist, error) {
 			return nil, fmt.Errorf("failed to create playlists request: %w", err)
 		}
 		req.Header.Set("Accept", "application/xml")
-		req.Header.Set("X-Plex-Token", c.token)
+		req.Header.Set("X-Plex-Token", c.playlistToken(ctx))
 
 		resp, err := c.httpDo(req)
 		if err != nil {
@@ -195,7 +195,7 @@ func (c *Client) UpdatePlaylistMetadata(ctx context.Context, playlistID, title,
 		params.Add("summary", escapedDescription)
 	}
 
-	params.Add("X-Plex-Token", c.token)
+	params.Add("X-Plex-Token", c.playlistToken(ctx))
 
 	// Create request with PUT method for updates
 	req, err := http.NewRequestWithContext(ctx, "PUT", reqURL+"?"+params.Encode(), nil)
@@ -204,7 +204,7 @@ func (c *Client) UpdatePlaylistMetadata(ctx context.Context, playlistID, title,
 	}
 
 	req.Header.Set("Accept", "application/json, text/plain, */*")
-	req.Header.Set("X-Plex-Token", c.token)
+	req.Header.Set("X-Plex-Token", c.playlistToken(ctx))
 
 	resp, err := c.httpDo(req)
 	if err != nil {
@@ -228,7 +228,7 @@ func (c *Client) ClearPlaylist(ctx context.Context, playlistID string) error {
 	// Use the Plex API endpoint to clear playlist items
 	reqURL := fmt.Sprintf("%s/playlists/%s/items", c.baseURL, playlistID)
 	params := url.Values{}
-	params.Add("X-Plex-Token", c.token)
+	params.Add("X-Plex-Token", c.playlistToken(ctx))
 
 	req, err := http.NewRequestWithContext(ctx, "DELETE", reqURL+"?"+params.Encode(), nil)
 	if err != nil {
@@ -236,7 +236,7 @@ func (c *Client) ClearPlaylist(ctx context.Context, playlistID string) error {
 	}
 
 	req.Header.Set("Accept", "application/xml")
-	req.Header.Set("X-Plex-Token", c.token)
+	req.Header.Set("X-Plex-Token", c.playlistToken(ctx))
 
 	resp, err := c.httpDo(req)
 	if err != nil {
@@ -270,7 +270,7 @@ func (c *Client) GetPlaylistItems(ctx context.Context, playlistID string) ([]Ple
 	for {
 		reqURL := fmt.Sprintf("%s/playlists/%s/items", c.baseURL, playlistID)
 		params := url.Values{}
-		params.Add("X-Plex-Token", c.token)
+		params.Add("X-Plex-Token", c.playlistToken(ctx))
 		params.Add("X-Plex-Container-Start", strconv.Itoa(offset))
 		params.Add("X-Plex-Container-Size", strconv.Itoa(pageSize))
 
@@ -279,7 +279,7 @@ func (c *Client) GetPlaylistItems(ctx context.Context, playlistID string) ([]Ple
 			return nil, fmt.Errorf("create playlist items request: %w", err)
 		}
 		req.Header.Set("Accept", "application/xml")
-		req.Header.Set("X-Plex-Token", c.token)
+		req.Header.Set("X-Plex-Token", c.playlistToken(ctx))
 
 		resp, err := c.httpDo(req)
 		if err != nil {
@@ -330,7 +330,7 @@ func (c *Client) AddTracksToPlaylist(ctx context.Context, playlistID string, tra
 		// Build request URL - use the correct Plex API endpoint
 		reqURL := fmt.Sprintf("%s/playlists/%s/items", c.baseURL, playlistID)
 		params := url.Values{}
-		params.Add("X-Plex-Token", c.token)
+		params.Add("X-Plex-Token", c.playlistToken(ctx))
 		params.Add("uri", fmt.Sprintf("server://%s/com.plexapp.plugins.library/library/metadata/%s", c.serverID, trackID))
 
 		req, err := http.NewRequestWithContext(ctx, "PUT", reqURL+"?"+params.Encode(), nil)
@@ -385,23 +385,32 @@ func (c *Client) AddTracksToPlaylist(ctx context.Context, playlistID string, tra
 	return nil
 }
 
-// SetPlaylistPosterUsingPlexgo uses the plexgo SDK to set playlist poster
-func (c *Client) SetPlaylistPosterUsingPlexgo(ctx context.Context, playlistID, artworkURL string) error {
+// SetPlaylistPoster sets the playlist poster to the image at artworkURL, as the Home user from
+// WithPlaylistUser when set (Plex only lets a playlist's owner change it).
+func (c *Client) SetPlaylistPoster(ctx context.Context, playlistID, artworkURL string) error {
 	if artworkURL == "" {
 		return nil // No artwork to set
 	}
 
-	// Convert playlist ID to int64 (plexgo expects int64 for ratingKey)
-	ratingKey, err := strconv.ParseInt(playlistID, 10, 64)
+	params := url.Values{}
+	params.Add("url", artworkURL)
+	reqURL := fmt.Sprintf("%s/library/metadata/%s/posters?%s", c.baseURL, url.PathEscape(playlistID), params.Encode())
+	req, err := http.NewRequestWithContext(ctx, "POST", reqURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to convert playlist ID to int64: %w", err)
+		return fmt.Errorf("failed to create poster request: %w", err)
 	}
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("X-Plex-Token", c.playlistToken(ctx))
 
-	// Use plexgo SDK's PostMediaPoster function
-	_, err = c.plexgoClient.Library.PostMediaPoster(ctx, ratingKey, &artworkURL, nil)
+	resp, err := c.httpDo(req)
 	if err != nil {
-		return fmt.Errorf("plexgo SDK failed to set poster: %w", err)
+		return fmt.Errorf("failed to set playlist poster: %w", err)
 	}
+	defer resp.Body.Close()
 
+	if resp.StatusCode != StatusOK && resp.StatusCode != StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return newPlexHTTPError(resp.StatusCode, "set playlist poster", body)
+	}
 	return nil
 }
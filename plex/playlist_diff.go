@@ -44,6 +44,7 @@ type PlaylistDesiredEntry struct {
 // PlaylistDiffView bundles current and desired playlist rows plus diff ops for rendering.
 type PlaylistDiffView struct {
 	PlaylistTitle string
+	PlexUser      string // Plex Home user owning the playlist; empty for the token's own account
 	Old           []PlexTrack
 	Desired       []PlaylistDesiredEntry
 	Ops           []PlaylistDiffOp
@@ -200,13 +201,17 @@ func FprintPlaylistDiffEmbedded(w io.Writer, view PlaylistDiffView, useColor boo
 
 func fprintPlaylistDiff(w io.Writer, view PlaylistDiffView, useColor, outerBanner bool) {
 	eq := cliutil.RepeatChar("=", cliutil.SectionWidth)
+	title := view.PlaylistTitle
+	if view.PlexUser != "" {
+		title += fmt.Sprintf(" (Plex user %s)", view.PlexUser)
+	}
 	fmt.Fprintln(w, "")
 	if outerBanner {
 		fmt.Fprintln(w, eq)
-		fmt.Fprintf(w, "PLAYLIST CHANGES — %s\n", view.PlaylistTitle)
+		fmt.Fprintf(w, "PLAYLIST CHANGES — %s\n", title)
 		fmt.Fprintln(w, eq)
 	} else {
-		fmt.Fprintf(w, "PLAYLIST CHANGES — %s\n", view.PlaylistTitle)
+		fmt.Fprintf(w, "PLAYLIST CHANGES — %s\n", title)
 		fmt.Fprintln(w, cliutil.RepeatChar("-", cliutil.SectionWidth))
 	}
 
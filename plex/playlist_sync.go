@@ -111,7 +111,13 @@ func (c *Client) fillMatchResult(ctx context.Context, out *MatchResult, song tra
 		}
 	}()
 
-	plexTr, matchType, err := c.SearchTrack(ctx, song)
+	var tracer *matchTracer
+	if c.traceMatches {
+		tracer = newMatchTracer(song)
+		ctx = withSearchState(ctx, &searchState{source: song, tracer: tracer})
+	}
+
+	plexTr, matchType, score, err := c.searchTrack(ctx, song)
 	if err != nil {
 		slog.InfoContext(ctx, "search error", "artist", song.Artist, "title", song.Name, "err", err)
 		*out = MatchResult{
@@ -119,6 +125,7 @@ func (c *Client) fillMatchResult(ctx context.Context, out *MatchResult, song tra
 			PlexTrack:   nil,
 			MatchType:   MatchTypeError,
 			Confidence:  0.0,
+			Trace:       tracer.finish(TraceDecision{MatchType: MatchTypeError, Error: err.Error()}),
 		}
 		return
 	}
@@ -127,6 +134,19 @@ func (c *Client) fillMatchResult(ctx context.Context, out *MatchResult, song tra
 		PlexTrack:   plexTr,
 		MatchType:   matchType,
 		Confidence:  c.calculateConfidence(song, plexTr, matchType),
+		Score:       score,
+	}
+	if tracer != nil {
+		d := TraceDecision{MatchType: matchType, Confidence: out.Confidence}
+		if plexTr != nil {
+			d.RatingKey, d.Title, d.Artist, d.Album = plexTr.ID, plexTr.Title, plexTr.DisplayArtist(), plexTr.Album
+			d.Section = plexTr.LibrarySectionTitle
+			if matchType == MatchTypeTitleArtist || matchType == MatchTypeReview {
+				sc := c.confidenceScores(song, plexTr)
+				d.Scores = &sc
+			}
+		}
+		out.Trace = tracer.finish(d)
 	}
 }
 
@@ -186,7 +206,7 @@ func (c *Client) EnsurePlaylistAndSync(ctx context.Context, playlistName, descri
 
 	if artworkURL != "" {
 		slog.InfoContext(ctx, "setting playlist artwork")
-		if err := c.SetPlaylistPosterUsingPlexgo(ctx, playlist.ID, artworkURL); err != nil {
+		if err := c.SetPlaylistPoster(ctx, playlist.ID, artworkURL); err != nil {
 			slog.InfoContext(ctx, "set playlist artwork failed", "err", err)
 		} else {
 			slog.InfoContext(ctx, "set playlist artwork OK")
@@ -198,13 +218,20 @@ func (c *Client) EnsurePlaylistAndSync(ctx context.Context, playlistName, descri
 // MatchPlaylist matches source tracks to Plex tracks and syncs a playlist. Diff output is returned for the caller to print.
 func (c *Client) MatchPlaylist(ctx context.Context, songs []track.Track, playlistName, description string, sourcePlaylistURL string, artworkURL string) ([]MatchResult, *PlexPlaylist, PlaylistDiffView, error) {
 	results := c.MatchSourceTracks(ctx, songs)
+	playlist, view, err := c.SyncMatchedPlaylist(ctx, results, playlistName, description, sourcePlaylistURL, artworkURL)
+	return results, playlist, view, err
+}
+
+// SyncMatchedPlaylist syncs a Plex playlist to already matched (and possibly reviewed) results. The playlist
+// is looked up, diffed and written in the account chosen by WithPlaylistUser, if any.
+func (c *Client) SyncMatchedPlaylist(ctx context.Context, results []MatchResult, playlistName, description string, sourcePlaylistURL string, artworkURL string) (*PlexPlaylist, PlaylistDiffView, error) {
 	desired := DesiredPlaylistEntries(results)
 	trackIDs := MatchedTrackIDs(desired)
 	var view PlaylistDiffView
 
 	if len(trackIDs) == 0 {
 		slog.InfoContext(ctx, "no tracks matched, skipping playlist")
-		return results, nil, view, nil
+		return nil, view, nil
 	}
 
 	slog.InfoContext(ctx, "matched tracks", "count", len(trackIDs))
@@ -214,9 +241,9 @@ func (c *Client) MatchPlaylist(ctx context.Context, songs []track.Track, playlis
 		if isTransientPlexErr(err) && ctx.Err() == nil {
 			slog.WarnContext(ctx, "could not list Plex playlists; skipping playlist sync for this run",
 				"err", err, "playlist", playlistName)
-			return results, nil, view, nil
+			return nil, view, nil
 		}
-		return results, nil, view, err
+		return nil, view, err
 	}
 
 	var oldItems []PlexTrack
@@ -229,19 +256,20 @@ func (c *Client) MatchPlaylist(ctx context.Context, songs []track.Track, playlis
 				oldItems = nil
 			} else {
 				slog.InfoContext(ctx, "read playlist items for diff failed", "err", err)
-				return results, nil, view, fmt.Errorf("get playlist items for diff: %w", err)
+				return nil, view, fmt.Errorf("get playlist items for diff: %w", err)
 			}
 		}
 	}
 
 	view = NewPlaylistDiffView(playlistName, oldItems, desired)
+	view.PlexUser = PlaylistUser(ctx)
 
 	if c.dryRun {
 		slog.InfoContext(ctx, "dry-run: skipping Plex playlist mutations", "playlist", playlistName)
 		if existing != nil {
-			return results, existing, view, nil
+			return existing, view, nil
 		}
-		return results, nil, view, nil
+		return nil, view, nil
 	}
 
 	playlist, err := c.EnsurePlaylistAndSync(ctx, playlistName, description, sourcePlaylistURL, artworkURL, trackIDs, existing)
@@ -250,11 +278,11 @@ func (c *Client) MatchPlaylist(ctx context.Context, songs []track.Track, playlis
 			slog.WarnContext(ctx, "Plex playlist write failed after matching; matches are shown but playlist was not updated",
 				"err", err, "playlist", playlistName)
 			if existing != nil {
-				return results, existing, view, nil
+				return existing, view, nil
 			}
-			return results, nil, view, nil
+			return nil, view, nil
 		}
-		return results, playlist, view, err
+		return playlist, view, err
 	}
-	return results, playlist, view, nil
+	return playlist, view, nil
 }
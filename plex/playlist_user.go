@@ -0,0 +1,35 @@
+package plex
+
+import "context"
+
+type playlistUserKey struct{}
+
+// playlistUser is the Plex Home user whose account owns the playlists being read and written.
+type playlistUser struct {
+	name  string
+	token string // that user's access token for this server
+}
+
+// WithPlaylistUser routes playlist reads and writes made with ctx to a Plex Home user's account: token is
+// the user's access token for this server and name labels the playlist diff. Track matching keeps the
+// client's own token, so every user is matched against the same shared library.
+func WithPlaylistUser(ctx context.Context, name, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, playlistUserKey{}, playlistUser{name: name, token: token})
+}
+
+// PlaylistUser returns the Plex Home user set by WithPlaylistUser, or "" for the client's own account.
+func PlaylistUser(ctx context.Context) string {
+	u, _ := ctx.Value(playlistUserKey{}).(playlistUser)
+	return u.name
+}
+
+// playlistToken returns the token for playlist requests: the Home user's from ctx, else the client's.
+func (c *Client) playlistToken(ctx context.Context) string {
+	if u, ok := ctx.Value(playlistUserKey{}).(playlistUser); ok {
+		return u.token
+	}
+	return c.token
+}
@@ -0,0 +1,81 @@
+package plex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+func TestSyncMatchedPlaylist_homeUserToken(t *testing.T) {
+	t.Parallel()
+	// Only Emma's token sees her playlist; the admin token lists none.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		emma := r.Header.Get("X-Plex-Token") == "emma-token"
+		switch {
+		case r.URL.Path == "/playlists" && emma:
+			_, _ = fmt.Fprint(w, `<MediaContainer size="1" totalSize="1"><Playlist ratingKey="50" title="Mix" leafCount="1"/></MediaContainer>`)
+		case r.URL.Path == "/playlists/50/items" && emma:
+			_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="1" title="Old" grandparentTitle="Band"/></MediaContainer>`)
+		default:
+			_, _ = fmt.Fprint(w, `<MediaContainer size="0" totalSize="0"/>`)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "admin-token", DryRun: true}})
+	results := []MatchResult{{PlexTrack: &PlexTrack{ID: "2", Title: "New", Artist: "Band"}, MatchType: MatchTypeTitleArtist}}
+
+	pl, view, err := c.SyncMatchedPlaylist(context.Background(), results, "Mix", "", "", "")
+	if err != nil || pl != nil || len(view.Old) != 0 || view.PlexUser != "" {
+		t.Fatalf("admin: playlist %+v, view %+v, err %v", pl, view, err)
+	}
+
+	ctx := WithPlaylistUser(context.Background(), "Emma", "emma-token")
+	pl, view, err = c.SyncMatchedPlaylist(ctx, results, "Mix", "", "", "")
+	if err != nil || pl == nil || pl.ID != "50" {
+		t.Fatalf("Emma: playlist %+v, err %v", pl, err)
+	}
+	if view.PlexUser != "Emma" || len(view.Old) != 1 || view.Old[0].ID != "1" {
+		t.Fatalf("Emma: view %+v", view)
+	}
+	var buf bytes.Buffer
+	FprintPlaylistDiff(&buf, view, false)
+	if !strings.Contains(buf.String(), "PLAYLIST CHANGES — Mix (Plex user Emma)") {
+		t.Errorf("diff header missing Plex user:\n%s", buf.String())
+	}
+
+	if got := WithPlaylistUser(context.Background(), "Nobody", ""); PlaylistUser(got) != "" {
+		t.Error("empty token should leave the client's own account")
+	}
+}
+
+func TestSetPlaylistPoster_homeUserToken(t *testing.T) {
+	t.Parallel()
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/library/metadata/50/posters" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		tokens = append(tokens, r.Header.Get("X-Plex-Token"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "admin-token"}})
+	if err := c.SetPlaylistPoster(context.Background(), "50", "https://example.com/a.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithPlaylistUser(context.Background(), "Emma", "emma-token")
+	if err := c.SetPlaylistPoster(ctx, "50", "https://example.com/a.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"admin-token", "emma-token"}; strings.Join(tokens, ",") != strings.Join(want, ",") {
+		t.Errorf("poster tokens = %v, want %v", tokens, want)
+	}
+}
@@ -0,0 +1,210 @@
+package plex
+
+import (
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+// versionQualifiers are the version markers found in a title's decorations ("(Live)", "- 2011 Remaster",
+// "[Calvin Harris Remix]") plus album-level live markers. Title normalizations such as
+// RemoveCommonSuffixes strip these for similarity, so they are compared separately to keep "Song (Live)"
+// from scoring like the studio cut.
+type versionQualifiers struct {
+	live         bool
+	remix        bool
+	remixer      string // lowercased remixer name when the decoration names one ("calvin harris")
+	acoustic     bool
+	edit         bool
+	remaster     bool
+	instrumental bool
+}
+
+var (
+	reQualifierDecoration = regexp.MustCompile(`\(([^)]*)\)|\[([^\]]*)\]|\{([^}]*)\}`)
+	reQualifierDashSep    = regexp.MustCompile(`\s[-–—]\s`)
+
+	reQualLive         = regexp.MustCompile(`(?i)\blive\b`)
+	reQualRemix        = regexp.MustCompile(`(?i)\b(re-?mix|rmx|mix)\b`)
+	reQualRemixer      = regexp.MustCompile(`(?i)^\s*(.+?)\s+(?:re-?mix|rmx)\b`)
+	reQualNotRemix     = regexp.MustCompile(`(?i)\b(original|album|radio|single)\s+mix\b`)
+	reQualAcoustic     = regexp.MustCompile(`(?i)\b(acoustic|unplugged)\b`)
+	reQualEdit         = regexp.MustCompile(`(?i)\bedit\b`)
+	reQualRemaster     = regexp.MustCompile(`(?i)\bre-?master(ed)?\b`)
+	reQualInstrumental = regexp.MustCompile(`(?i)\binstrumental\b`)
+
+	reAlbumLive        = regexp.MustCompile(`(?i)(^live\b|\blive\s+(at|from|in|on)\b|[(\[]live[)\]]|\bunplugged\b|\s[-–—]\s+live$)`)
+	reAlbumCompilation = regexp.MustCompile(`(?i)\b(greatest hits|best of|the very best|the essential|essentials|anthology|compilation|collection|hits\s+\d{4}|now that'?s what i call)\b`)
+)
+
+// genericRemixWords are words that describe a mix type rather than naming a remixer.
+var genericRemixWords = map[string]bool{
+	"extended": true, "club": true, "dub": true, "radio": true, "vocal": true, "instrumental": true,
+	"original": true, "single": true, "album": true, "short": true, "long": true, "official": true,
+}
+
+// titleDecorations returns the bracketed and dash-separated tails of a title, where version markers live.
+// The bare title text is excluded so "Live Forever" is not mistaken for a live recording.
+func titleDecorations(title string) []string {
+	var out []string
+	for _, m := range reQualifierDecoration.FindAllStringSubmatch(title, -1) {
+		for _, g := range m[1:] {
+			if g != "" {
+				out = append(out, g)
+			}
+		}
+	}
+	rest := reQualifierDecoration.ReplaceAllString(title, "")
+	if parts := reQualifierDashSep.Split(rest, -1); len(parts) > 1 {
+		out = append(out, parts[1:]...)
+	}
+	return out
+}
+
+// parseVersionQualifiers extracts version markers from title decorations and from the album title (live only).
+func parseVersionQualifiers(title, album string) versionQualifiers {
+	var q versionQualifiers
+	for _, d := range titleDecorations(title) {
+		if reQualLive.MatchString(d) {
+			q.live = true
+		}
+		if reQualRemix.MatchString(d) && !reQualNotRemix.MatchString(d) {
+			q.remix = true
+			if m := reQualRemixer.FindStringSubmatch(d); m != nil {
+				name := strings.ToLower(strings.TrimSpace(m[1]))
+				words := strings.Fields(name)
+				if len(words) > 0 && !genericRemixWords[words[len(words)-1]] {
+					q.remixer = name
+				}
+			}
+		}
+		if reQualAcoustic.MatchString(d) {
+			q.acoustic = true
+		}
+		if reQualEdit.MatchString(d) {
+			q.edit = true
+		}
+		if reQualRemaster.MatchString(d) {
+			q.remaster = true
+		}
+		if reQualInstrumental.MatchString(d) {
+			q.instrumental = true
+		}
+	}
+	if reAlbumLive.MatchString(strings.TrimSpace(album)) {
+		q.live = true
+	}
+	return q
+}
+
+// mismatchWeight sums per-qualifier differences between source (q) and candidate (o). Live, remix,
+// acoustic and instrumental change what you hear and count 1 each; edit and remaster count 0.5 because
+// they are usually the same performance. Two named, different remixers count as a remix mismatch.
+func (q versionQualifiers) mismatchWeight(o versionQualifiers) float64 {
+	var w float64
+	if q.live != o.live {
+		w++
+	}
+	if q.remix != o.remix {
+		w++
+	} else if q.remix && q.remixer != "" && o.remixer != "" && q.remixer != o.remixer {
+		w++
+	}
+	if q.acoustic != o.acoustic {
+		w++
+	}
+	if q.instrumental != o.instrumental {
+		w++
+	}
+	if q.edit != o.edit {
+		w += 0.5
+	}
+	if q.remaster != o.remaster {
+		w += 0.5
+	}
+	return w
+}
+
+// versionMismatchPenaltyPerQualifier returns the score deduction per mismatched qualifier (0–1).
+func (c *Client) versionMismatchPenaltyPerQualifier() float64 {
+	if c.versionMismatchPenaltyPercent == nil {
+		return float64(config.DefaultVersionMismatchPenaltyPercent) / 100.0
+	}
+	p := *c.versionMismatchPenaltyPercent
+	if p < 0 {
+		return 0
+	}
+	if p > 100 {
+		return 1
+	}
+	return float64(p) / 100.0
+}
+
+// versionMismatchPenalty is the amount subtracted from a candidate's combined score for qualifiers that
+// differ between the source track and the Plex track.
+func (c *Client) versionMismatchPenalty(sourceTitle, sourceAlbum string, tr PlexTrack) float64 {
+	per := c.versionMismatchPenaltyPerQualifier()
+	if per == 0 {
+		return 0
+	}
+	src := parseVersionQualifiers(sourceTitle, sourceAlbum)
+	cand := parseVersionQualifiers(tr.Title, tr.Album)
+	return math.Min(1, per*src.mismatchWeight(cand))
+}
+
+// isCompilationTrack reports whether tr looks like it comes from a compilation rather than an original album.
+func isCompilationTrack(tr PlexTrack) bool {
+	if strings.EqualFold(strings.TrimSpace(tr.Artist), "various artists") {
+		return true
+	}
+	return reAlbumCompilation.MatchString(tr.Album)
+}
+
+// versionPreferences returns the configured tie-break preferences (config.DefaultVersionPreferences when unset).
+func (c *Client) versionPreferences() []string {
+	if c.versionPrefs == nil {
+		return config.DefaultVersionPreferences
+	}
+	return c.versionPrefs
+}
+
+// preferVersion reports whether candidate a should win over b when their scores tie, applying the
+// configured preferences in order. Preferences only apply to qualifiers the source does not specify.
+// Candidates still tied are ranked by the quality preferences (see preferQuality).
+func (c *Client) preferVersion(sourceTitle, sourceAlbum string, a, b PlexTrack) bool {
+	src := parseVersionQualifiers(sourceTitle, sourceAlbum)
+	for _, pref := range c.versionPreferences() {
+		switch pref {
+		case config.VersionPreferenceNonLive:
+			if src.live {
+				continue
+			}
+			al, bl := parseVersionQualifiers(a.Title, a.Album).live, parseVersionQualifiers(b.Title, b.Album).live
+			if al != bl {
+				return !al
+			}
+		case config.VersionPreferenceOriginalAlbum:
+			if reAlbumCompilation.MatchString(sourceAlbum) {
+				continue
+			}
+			ac, bc := isCompilationTrack(a), isCompilationTrack(b)
+			if ac != bc {
+				return !ac
+			}
+		}
+	}
+	return c.preferQuality(a, b)
+}
+
+// pickPreferredVersion returns the candidate the version preferences favour (first one on a full tie).
+func (c *Client) pickPreferredVersion(sourceTitle, sourceAlbum string, tracks []PlexTrack) PlexTrack {
+	best := tracks[0]
+	for _, tr := range tracks[1:] {
+		if c.preferVersion(sourceTitle, sourceAlbum, tr, best) {
+			best = tr
+		}
+	}
+	return best
+}
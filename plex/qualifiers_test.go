@@ -0,0 +1,105 @@
+package plex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+func TestParseVersionQualifiers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		title, album string
+		want         versionQualifiers
+	}{
+		{"Live Forever", "Definitely Maybe", versionQualifiers{}},
+		{"Song (Live)", "", versionQualifiers{live: true}},
+		{"Song", "Live at Wembley", versionQualifiers{live: true}},
+		{"Song - 2011 Remaster", "", versionQualifiers{remaster: true}},
+		{"Song - Remastered 2009", "", versionQualifiers{remaster: true}},
+		{"Song (Radio Edit)", "", versionQualifiers{edit: true}},
+		{"Song [Calvin Harris Remix]", "", versionQualifiers{remix: true, remixer: "calvin harris"}},
+		{"Song (Extended Mix)", "", versionQualifiers{remix: true}},
+		{"Song (Original Mix)", "", versionQualifiers{}},
+		{"Song (Acoustic Version)", "", versionQualifiers{acoustic: true}},
+		{"Song (Instrumental)", "", versionQualifiers{instrumental: true}},
+	}
+	for _, tt := range tests {
+		if got := parseVersionQualifiers(tt.title, tt.album); got != tt.want {
+			t.Errorf("parseVersionQualifiers(%q, %q) = %+v, want %+v", tt.title, tt.album, got, tt.want)
+		}
+	}
+}
+
+func TestFindBestMatch_prefersStudioOverLiveForPlainTitle(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	tracks := []PlexTrack{
+		{ID: "live", Title: "Song (Live)", Artist: "Band", Album: "Tour"},
+		{ID: "studio", Title: "Song", Artist: "Band", Album: "Record"},
+	}
+	if got := c.FindBestMatch(tracks, "Song", "Band", ""); got == nil || got.ID != "studio" {
+		t.Fatalf("expected studio version, got %v", got)
+	}
+	if got := c.FindBestMatch(tracks, "Song (Live)", "Band", ""); got == nil || got.ID != "live" {
+		t.Fatalf("expected live version, got %v", got)
+	}
+}
+
+func TestFindBestMatch_namedRemixer(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	tracks := []PlexTrack{
+		{ID: "tiesto", Title: "Song (Tiësto Remix)", Artist: "Singer"},
+		{ID: "calvin", Title: "Song (Calvin Harris Remix)", Artist: "Singer"},
+		{ID: "orig", Title: "Song", Artist: "Singer"},
+	}
+	if got := c.FindBestMatch(tracks, "Song (Calvin Harris Remix)", "Singer", ""); got == nil || got.ID != "calvin" {
+		t.Fatalf("expected calvin remix, got %v", got)
+	}
+}
+
+func TestFindBestMatch_exactDuplicatesUseVersionPreferences(t *testing.T) {
+	t.Parallel()
+	tracks := []PlexTrack{
+		{ID: "live", Title: "Song", Artist: "Band", Album: "Live at Wembley"},
+		{ID: "hits", Title: "Song", Artist: "Band", Album: "Greatest Hits"},
+		{ID: "album", Title: "Song", Artist: "Band", Album: "Second Record"},
+	}
+	c := &Client{}
+	if got := c.FindBestMatch(tracks, "Song", "Band", ""); got == nil || got.ID != "album" {
+		t.Fatalf("expected original album, got %v", got)
+	}
+
+	none := 0
+	c = &Client{versionMismatchPenaltyPercent: &none, versionPrefs: []string{}}
+	if got := c.FindBestMatch(tracks, "Song", "Band", ""); got == nil || got.ID != "live" {
+		t.Fatalf("with preferences disabled expected first candidate, got %v", got)
+	}
+}
+
+func TestCalculateConfidence_versionMismatchPenalty(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	song := track.Track{Name: "Song", Artist: "Band"}
+	studio := c.calculateConfidence(song, &PlexTrack{Title: "Song", Artist: "Band"}, MatchTypeTitleArtist)
+	live := c.calculateConfidence(song, &PlexTrack{Title: "Song (Live)", Artist: "Band"}, MatchTypeTitleArtist)
+	if live >= studio {
+		t.Fatalf("expected live confidence %g below studio %g", live, studio)
+	}
+}
+
+func TestFindBestMatchWithOptionalArtistSortRetry_usesSourceTitleQualifiers(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	tracks := []PlexTrack{
+		{ID: "studio", Title: "Song", Artist: "Band"},
+		{ID: "live", Title: "Song (Live)", Artist: "Band"},
+	}
+	// Strategies search with a stripped title; the original "(Live)" comes from the search state.
+	ctx := withSearchState(context.Background(), &searchState{source: track.Track{Name: "Song (Live)", Artist: "Band"}})
+	if got := c.findBestMatchWithOptionalArtistSortRetry(ctx, tracks, "Song", "Band", "", false); got == nil || got.ID != "live" {
+		t.Fatalf("expected live version, got %v", got)
+	}
+}
@@ -0,0 +1,152 @@
+package plex
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+// PlexMedia is one Media element of a track: a file (or set of parts) with its audio format.
+type PlexMedia struct {
+	Bitrate       int        `xml:"bitrate,attr"` // kbps
+	AudioChannels int        `xml:"audioChannels,attr"`
+	AudioCodec    string     `xml:"audioCodec,attr"`
+	Container     string     `xml:"container,attr"`
+	Parts         []PlexPart `xml:"Part"`
+}
+
+// PlexPart is one file of a Media element.
+type PlexPart struct {
+	File    string       `xml:"file,attr"`
+	Size    int64        `xml:"size,attr"`
+	Streams []PlexStream `xml:"Stream"`
+}
+
+// PlexStream is an audio stream of a part. Plex includes streams only in full metadata responses, so
+// BitDepth is often unknown for search results.
+type PlexStream struct {
+	StreamType   int `xml:"streamType,attr"` // 2 = audio
+	BitDepth     int `xml:"bitDepth,attr"`
+	SamplingRate int `xml:"samplingRate,attr"`
+}
+
+// losslessCodecs are Plex audioCodec values for lossless formats.
+var losslessCodecs = map[string]bool{
+	"flac": true, "alac": true, "wav": true, "pcm": true, "aiff": true, "ape": true,
+	"wavpack": true, "wv": true, "tta": true, "dsd": true, "dsf": true, "dff": true,
+}
+
+// TrackQuality summarizes the best Media element of a track.
+type TrackQuality struct {
+	Codec    string
+	Bitrate  int // kbps; 0 when unknown
+	BitDepth int // 0 when unknown
+	Channels int
+	Lossless bool
+	Files    []string // every part file of every Media element
+}
+
+// Quality returns the track's best media (lossless first, then bitrate and bit depth) and all its files.
+func (t PlexTrack) Quality() TrackQuality {
+	var best TrackQuality
+	var files []string
+	for i, m := range t.Media {
+		q := TrackQuality{
+			Codec:    strings.ToLower(m.AudioCodec),
+			Bitrate:  m.Bitrate,
+			Channels: m.AudioChannels,
+		}
+		q.Lossless = losslessCodecs[q.Codec]
+		for _, p := range m.Parts {
+			if p.File != "" {
+				files = append(files, p.File)
+			}
+			for _, s := range p.Streams {
+				if s.StreamType == 2 && s.BitDepth > q.BitDepth {
+					q.BitDepth = s.BitDepth
+				}
+			}
+		}
+		if i == 0 || q.betterThan(best) {
+			best = q
+		}
+	}
+	if len(files) == 0 && strings.TrimSpace(t.File) != "" {
+		files = []string{t.File}
+	}
+	best.Files = files
+	return best
+}
+
+// String renders the quality as "codec [N-bit] [N kbps]", or "" when the codec is unknown.
+func (q TrackQuality) String() string {
+	if q.Codec == "" {
+		return ""
+	}
+	parts := []string{q.Codec}
+	if q.BitDepth > 0 {
+		parts = append(parts, fmt.Sprintf("%d-bit", q.BitDepth))
+	}
+	if q.Bitrate > 0 {
+		parts = append(parts, fmt.Sprintf("%d kbps", q.Bitrate))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (q TrackQuality) betterThan(o TrackQuality) bool {
+	if q.Lossless != o.Lossless {
+		return q.Lossless
+	}
+	if q.Bitrate != o.Bitrate {
+		return q.Bitrate > o.Bitrate
+	}
+	return q.BitDepth > o.BitDepth
+}
+
+// inFolder reports whether any of the files lies under dir.
+func (q TrackQuality) inFolder(dir string) bool {
+	dir = strings.TrimSuffix(filepath.ToSlash(strings.TrimSpace(dir)), "/")
+	for _, f := range q.Files {
+		f = filepath.ToSlash(f)
+		if f == dir || strings.HasPrefix(f, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// preferQuality reports whether a should win over b on the quality preferences, applied in order. Unknown
+// quality (no Media on the track) never wins a comparison.
+func (c *Client) preferQuality(a, b PlexTrack) bool {
+	if len(c.qualityPrefs) == 0 {
+		return false
+	}
+	qa, qb := a.Quality(), b.Quality()
+	for _, pref := range c.qualityPrefs {
+		switch {
+		case pref == config.QualityPreferenceLossless:
+			if qa.Lossless != qb.Lossless {
+				return qa.Lossless
+			}
+		case pref == config.QualityPreferenceHighestBitrate:
+			if qa.Bitrate != qb.Bitrate {
+				return qa.Bitrate > qb.Bitrate
+			}
+			if qa.BitDepth != qb.BitDepth {
+				return qa.BitDepth > qb.BitDepth
+			}
+		case pref == config.QualityPreferenceNonCompilation:
+			if ac, bc := isCompilationTrack(a), isCompilationTrack(b); ac != bc {
+				return !ac
+			}
+		case strings.HasPrefix(pref, config.QualityPreferenceFolderPrefix):
+			dir := strings.TrimPrefix(pref, config.QualityPreferenceFolderPrefix)
+			if ia, ib := qa.inFolder(dir), qb.inFolder(dir); ia != ib {
+				return ia
+			}
+		}
+	}
+	return false
+}
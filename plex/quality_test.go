@@ -0,0 +1,88 @@
+package plex
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+const duplicateTracksXML = `<MediaContainer>
+	<Track ratingKey="1" title="Song" grandparentTitle="Various Artists" originalTitle="Band" parentTitle="Hits of the Year">
+		<Media bitrate="320" audioChannels="2" audioCodec="mp3" container="mp3">
+			<Part file="/music/Compilations/Hits/01 Song.mp3" size="7000000"/>
+		</Media>
+	</Track>
+	<Track ratingKey="2" title="Song" grandparentTitle="Band" parentTitle="Debut">
+		<Media bitrate="1011" audioChannels="2" audioCodec="flac" container="flac">
+			<Part file="/music/Albums/Band/Debut/03 Song.flac" size="30000000">
+				<Stream streamType="2" bitDepth="24" samplingRate="96000"/>
+			</Part>
+		</Media>
+	</Track>
+</MediaContainer>`
+
+func decodeDuplicateTracks(t *testing.T) []PlexTrack {
+	t.Helper()
+	var mc PlexResponse
+	if err := xml.Unmarshal([]byte(duplicateTracksXML), &mc); err != nil {
+		t.Fatal(err)
+	}
+	return mc.Tracks
+}
+
+func TestPlexTrack_Quality(t *testing.T) {
+	t.Parallel()
+	tracks := decodeDuplicateTracks(t)
+	mp3, flac := tracks[0].Quality(), tracks[1].Quality()
+	if mp3.Lossless || mp3.Bitrate != 320 || mp3.String() != "mp3 320 kbps" {
+		t.Errorf("mp3 quality = %+v (%q)", mp3, mp3.String())
+	}
+	if !flac.Lossless || flac.BitDepth != 24 || flac.String() != "flac 24-bit 1011 kbps" {
+		t.Errorf("flac quality = %+v (%q)", flac, flac.String())
+	}
+	if len(flac.Files) != 1 || !flac.inFolder("/music/Albums/") || flac.inFolder("/music/Album") {
+		t.Errorf("flac files = %q", flac.Files)
+	}
+	if q := (PlexTrack{}).Quality(); q.String() != "" || q.Lossless {
+		t.Errorf("track without media: %+v", q)
+	}
+}
+
+func TestPreferQuality(t *testing.T) {
+	t.Parallel()
+	tracks := decodeDuplicateTracks(t)
+	mp3, flac := tracks[0], tracks[1]
+	for _, prefs := range [][]string{
+		{config.QualityPreferenceLossless},
+		{config.QualityPreferenceHighestBitrate},
+		{config.QualityPreferenceNonCompilation},
+		{config.QualityPreferenceFolderPrefix + "/music/Albums"},
+	} {
+		c := &Client{qualityPrefs: prefs}
+		if !c.preferQuality(flac, mp3) || c.preferQuality(mp3, flac) {
+			t.Errorf("%v: want the FLAC album copy preferred", prefs)
+		}
+	}
+	c := &Client{qualityPrefs: []string{config.QualityPreferenceFolderPrefix + "/music/Compilations"}}
+	if !c.preferQuality(mp3, flac) {
+		t.Error("folder preference should pick the compilation copy")
+	}
+	if (&Client{}).preferQuality(flac, mp3) {
+		t.Error("no preferences should never prefer")
+	}
+}
+
+func TestFindBestMatch_qualityPreferenceBreaksExactTie(t *testing.T) {
+	t.Parallel()
+	tracks := decodeDuplicateTracks(t)
+	// No version preferences, so only the quality preference separates the two exact matches.
+	c := &Client{versionPrefs: []string{}}
+	if got := c.FindBestMatch(tracks, "Song", "Band", ""); got == nil || got.ID != "1" {
+		t.Fatalf("without quality preferences = %v, want first row", got)
+	}
+	c.qualityPrefs = []string{config.QualityPreferenceLossless}
+	if got := c.FindBestMatch(tracks, "Song", "Band", ""); got == nil || got.ID != "2" {
+		t.Fatalf("with lossless preference = %v, want the FLAC copy", got)
+	}
+}
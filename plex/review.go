@@ -0,0 +1,110 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+// ReviewCandidates returns up to n distinct Plex tracks scored while matching r, best first, with the
+// current match leading. It needs the trace (SetTraceMatches); without one only the current match is returned.
+func (r MatchResult) ReviewCandidates(n int) []TraceCandidate {
+	var out []TraceCandidate
+	seen := map[string]bool{}
+	if r.PlexTrack != nil {
+		cur := TraceCandidate{
+			RatingKey: r.PlexTrack.ID, Title: r.PlexTrack.Title, Artist: r.PlexTrack.DisplayArtist(),
+			Album: r.PlexTrack.Album, DurationMs: r.PlexTrack.Duration, Scores: TraceScores{Total: r.Confidence},
+		}
+		if r.Trace != nil && r.Trace.Decision.Scores != nil {
+			cur.Scores = *r.Trace.Decision.Scores
+		}
+		out = append(out, cur)
+		seen[cur.RatingKey] = true
+	}
+	if r.Trace == nil {
+		return out
+	}
+	var rest []TraceCandidate
+	for _, step := range r.Trace.Steps {
+		for _, c := range step.Candidates {
+			if c.RatingKey == "" || seen[c.RatingKey] {
+				continue
+			}
+			seen[c.RatingKey] = true
+			rest = append(rest, c)
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool { return rest[i].Scores.Total > rest[j].Scores.Total })
+	out = append(out, rest...)
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// PlexTrack converts a traced candidate back into the track fields playlists and diffs need.
+func (tc TraceCandidate) PlexTrack() *PlexTrack {
+	return &PlexTrack{ID: tc.RatingKey, Title: tc.Title, Artist: tc.Artist, Album: tc.Album, Duration: tc.DurationMs, LibrarySectionTitle: tc.Section}
+}
+
+// TitleArtistConfidence is the 0–1 confidence reported for matching song to tr by title/artist.
+func (c *Client) TitleArtistConfidence(song track.Track, tr *PlexTrack) float64 {
+	return c.calculateConfidence(song, tr, MatchTypeTitleArtist)
+}
+
+// SearchTracks runs a free-text track search in each library section (GET /library/sections/{id}/search),
+// returning the results of higher-priority sections first.
+func (c *Client) SearchTracks(ctx context.Context, query string) ([]PlexTrack, error) {
+	q := strings.TrimSpace(query)
+	if q == "" {
+		return nil, nil
+	}
+	var out []PlexTrack
+	for _, sec := range c.LibrarySections() {
+		tracks, err := c.searchSectionTracks(ctx, sec, q)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tracks...)
+	}
+	return out, nil
+}
+
+func (c *Client) searchSectionTracks(ctx context.Context, sec LibrarySection, query string) ([]PlexTrack, error) {
+	reqURL := fmt.Sprintf("%s/library/sections/%d/search", c.baseURL, sec.ID)
+	params := url.Values{}
+	params.Add("X-Plex-Token", c.token)
+	params.Add("query", query)
+	params.Add("type", PlexMusicTrackType)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make search request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, newPlexHTTPError(resp.StatusCode, "search tracks", b)
+	}
+
+	var searchResp PlexResponse
+	if err := decodePlexResponseXML(resp, &searchResp); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+	stampLibrarySection(sec, searchResp.Tracks)
+	return searchResp.Tracks, nil
+}
@@ -0,0 +1,138 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/track"
+)
+
+func TestMatchResult_ReviewCandidates(t *testing.T) {
+	t.Parallel()
+	r := MatchResult{
+		PlexTrack:  &PlexTrack{ID: "2", Title: "Song", Artist: "Band"},
+		Confidence: 0.7,
+		Trace: &MatchTrace{Steps: []TraceStep{
+			{Candidates: []TraceCandidate{{RatingKey: "3", Scores: TraceScores{Total: 0.5}}, {RatingKey: "2", Scores: TraceScores{Total: 0.7}}}},
+			{Candidates: []TraceCandidate{{RatingKey: "4", Scores: TraceScores{Total: 0.6}}, {RatingKey: "3", Scores: TraceScores{Total: 0.5}}}},
+		}},
+	}
+	var keys []string
+	for _, c := range r.ReviewCandidates(5) {
+		keys = append(keys, c.RatingKey)
+	}
+	if fmt.Sprint(keys) != "[2 4 3]" {
+		t.Fatalf("candidates = %v, want current match first then by score", keys)
+	}
+	if got := r.ReviewCandidates(2); len(got) != 2 {
+		t.Fatalf("limit not applied: %d", len(got))
+	}
+	if got := (MatchResult{PlexTrack: r.PlexTrack}).ReviewCandidates(5); len(got) != 1 || got[0].Scores.Total != 0 {
+		t.Fatalf("without trace: %+v", got)
+	}
+}
+
+func TestSearchTracks(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/library/sections/7/search" || r.URL.Query().Get("query") != "song band" || r.URL.Query().Get("type") != PlexMusicTrackType {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="5" title="Song" grandparentTitle="Band"/></MediaContainer>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 7}})
+	got, err := c.SearchTracks(context.Background(), " song band ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "5" {
+		t.Fatalf("tracks = %+v", got)
+	}
+}
+
+func TestNewClient_reviewBandKeepsMatchThreshold(t *testing.T) {
+	t.Parallel()
+	c := NewClient(&config.Config{Plex: config.PlexConfig{MatchConfidencePercent: 80, AutoAcceptPercent: 90, AutoRejectPercent: 60}})
+	if got := c.minMatchScore(); got != 0.8 {
+		t.Fatalf("minMatchScore = %v, want the match threshold 0.8", got)
+	}
+	if floor, ok := c.reviewFloor(); !ok || floor != 0.6 {
+		t.Fatalf("reviewFloor = %v, %v, want auto-reject floor 0.6", floor, ok)
+	}
+	c = NewClient(&config.Config{Plex: config.PlexConfig{MatchConfidencePercent: 80, AutoAcceptPercent: 80, AutoRejectPercent: 80}})
+	if _, ok := c.reviewFloor(); ok {
+		t.Fatal("reviewFloor set without a review band")
+	}
+}
+
+func TestSearchTrack_reviewBandCandidateDoesNotStopPipeline(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name      string
+		cleanHits bool // the brackets-removed query finds the exact track
+		want      string
+		wantKind  MatchKind
+	}{
+		{name: "later strategy wins", cleanHits: true, want: "2", wantKind: MatchTypeTitleArtist},
+		{name: "held candidate returned", cleanHits: false, want: "1", wantKind: MatchTypeReview},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/xml")
+				switch q := r.URL.Query().Get("query"); {
+				case strings.Contains(q, "Live"):
+					_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="1" title="Song Two" grandparentTitle="Band"/></MediaContainer>`)
+				case q == "Song" && tc.cleanHits:
+					_, _ = fmt.Fprint(w, `<MediaContainer><Track ratingKey="2" title="Song" grandparentTitle="Band"/></MediaContainer>`)
+				default:
+					_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+				}
+			}))
+			defer ts.Close()
+			c := NewClient(&config.Config{Plex: config.PlexConfig{
+				URL: ts.URL, Token: "tok", LibrarySectionID: 1,
+				MatchConfidencePercent: 95, AutoAcceptPercent: 95, AutoRejectPercent: 10,
+			}})
+			c.SetSkipFullLibrarySearch(true)
+			got, kind, score, err := c.searchTrack(context.Background(), track.Track{Name: "Song (Live)", Artist: "Band"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == nil || got.ID != tc.want || kind != tc.wantKind {
+				t.Fatalf("SearchTrack = %+v (%s), want rating key %s (%s)", got, kind, tc.want, tc.wantKind)
+			}
+			// The score the threshold was checked against comes back with the match.
+			if held := kind == MatchTypeReview; held != (score < c.minMatchScore()) || score < 0.1 {
+				t.Errorf("score = %v for %s", score, kind)
+			}
+		})
+	}
+}
+
+func TestFindBestMatchWithNormalizedPunctuation_holdsAndTraces(t *testing.T) {
+	t.Parallel()
+	c := NewClient(&config.Config{Plex: config.PlexConfig{MatchConfidencePercent: 95, AutoAcceptPercent: 95, AutoRejectPercent: 10}})
+	song := track.Track{Name: "Song \u2013 Live", Artist: "Band"}
+	tracer := newMatchTracer(song)
+	tracer.beginStep(song.Artist, "punctuation normalized", "combined")
+	st := &searchState{source: song, tracer: tracer}
+	tracks := []PlexTrack{{ID: "1", Title: "Song Two", Artist: "Band"}}
+	if got := c.findBestMatchWithNormalizedPunctuation(tracks, song.Name, song.Artist, "", st); got != nil {
+		t.Fatalf("expected no match above the threshold, got %+v", got)
+	}
+	if st.held == nil || st.held.ID != "1" || st.heldScore >= 0.95 {
+		t.Fatalf("held = %+v (%v), want rating key 1 below the threshold", st.held, st.heldScore)
+	}
+	if tr := tracer.finish(TraceDecision{}); len(tr.Steps) != 1 || len(tr.Steps[0].Candidates) != 1 {
+		t.Fatalf("trace = %+v, want the scored candidate recorded", tr)
+	}
+}
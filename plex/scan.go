@@ -0,0 +1,109 @@
+package plex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrScanTimeout is returned by WaitForScans when Plex is still scanning at the deadline.
+var ErrScanTimeout = errors.New("timed out waiting for Plex to finish scanning")
+
+// scanPollInterval is how often WaitForScans checks /library/sections.
+const scanPollInterval = 2 * time.Second
+
+// SectionForPath returns the searched library section whose root folder contains dir (a path on the
+// Plex server), preferring the longest root. When the section folders are unknown (sections given by ID
+// and not yet resolved) it returns the first section.
+func (c *Client) SectionForPath(dir string) (LibrarySection, bool) {
+	sections := c.LibrarySections()
+	var best LibrarySection
+	bestLen, known := -1, false
+	for _, sec := range sections {
+		for _, loc := range sec.Locations {
+			known = true
+			root := strings.TrimRight(loc.Path, "/")
+			if (dir == root || strings.HasPrefix(dir, root+"/")) && len(root) > bestLen {
+				best, bestLen = sec, len(root)
+			}
+		}
+	}
+	if !known {
+		return sections[0], true
+	}
+	return best, bestLen >= 0
+}
+
+// ScanPath asks Plex to scan one folder of a library section (GET /library/sections/{id}/refresh?path=)
+// instead of the whole library. The scan runs in the background; see WaitForScans.
+func (c *Client) ScanPath(ctx context.Context, sectionID int, dir string) error {
+	reqURL := fmt.Sprintf("%s/library/sections/%d/refresh", strings.TrimSuffix(c.baseURL, "/"), sectionID)
+	params := url.Values{}
+	params.Add("path", dir)
+	params.Add("X-Plex-Token", c.token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create scan request: %w", err)
+	}
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return fmt.Errorf("failed to make scan request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return newPlexHTTPError(resp.StatusCode, "scan library section", b)
+	}
+	return nil
+}
+
+// WaitForScans polls /library/sections until none of sectionIDs is refreshing, or returns ErrScanTimeout
+// after timeout. The first check waits one poll interval so a scan just requested has time to start.
+func (c *Client) WaitForScans(ctx context.Context, sectionIDs []int, timeout time.Duration) error {
+	return c.waitForScansEvery(ctx, sectionIDs, timeout, scanPollInterval)
+}
+
+func (c *Client) waitForScansEvery(ctx context.Context, sectionIDs []int, timeout, interval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		wait := interval
+		if left := time.Until(deadline); left < wait {
+			wait = left
+		}
+		if wait > 0 {
+			t := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return ctx.Err()
+			case <-t.C:
+			}
+		}
+
+		all, err := c.GetLibrarySections(ctx)
+		if err != nil {
+			return err
+		}
+		busy := false
+		for _, s := range all {
+			for _, id := range sectionIDs {
+				if s.ID == id && s.Refreshing {
+					busy = true
+				}
+			}
+		}
+		if !busy {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return ErrScanTimeout
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package plex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+func TestSectionForPath(t *testing.T) {
+	t.Parallel()
+	c := &Client{sections: []LibrarySection{
+		{ID: 3, Locations: []SectionLocation{{Path: "/data/music"}}},
+		{ID: 7, Locations: []SectionLocation{{Path: "/data/music/hires/"}, {Path: "/flac"}}},
+	}}
+	for dir, want := range map[string]int{
+		"/data/music/A/Album":       3,
+		"/data/music/hires/A/Album": 7,
+		"/flac/A":                   7,
+	} {
+		if sec, ok := c.SectionForPath(dir); !ok || sec.ID != want {
+			t.Errorf("SectionForPath(%q) = %d, %v; want %d", dir, sec.ID, ok, want)
+		}
+	}
+	if _, ok := c.SectionForPath("/data/musicals/A"); ok {
+		t.Error("path outside every library folder should not match")
+	}
+
+	unresolved := &Client{sectionID: 5}
+	if sec, ok := unresolved.SectionForPath("/anything"); !ok || sec.ID != 5 {
+		t.Errorf("unknown folders: got %d, %v; want the configured section", sec.ID, ok)
+	}
+}
+
+func TestScanPathAndWait(t *testing.T) {
+	t.Parallel()
+	var polls atomic.Int32
+	var scanned atomic.Value
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/library/sections/3/refresh":
+			scanned.Store(r.URL.Query().Get("path"))
+		case "/library/sections":
+			refreshing := 0
+			if polls.Add(1) < 3 {
+				refreshing = 1
+			}
+			_, _ = fmt.Fprintf(w, `<MediaContainer><Directory key="3" type="artist" title="Music" refreshing="%d"/></MediaContainer>`, refreshing)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySections: []string{"3"}}})
+	ctx := context.Background()
+	if err := c.ScanPath(ctx, 3, "/data/music/A/Album (2001)"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := scanned.Load().(string); got != "/data/music/A/Album (2001)" {
+		t.Errorf("scanned path = %q", got)
+	}
+
+	if err := c.waitForScansEvery(ctx, []int{3}, time.Second, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if n := polls.Load(); n != 3 {
+		t.Errorf("polled %d times, want 3", n)
+	}
+
+	polls.Store(-100)
+	if err := c.waitForScansEvery(ctx, []int{3}, 20*time.Millisecond, 5*time.Millisecond); !errors.Is(err, ErrScanTimeout) {
+		t.Errorf("err = %v, want ErrScanTimeout", err)
+	}
+}
@@ -2,6 +2,7 @@ package plex
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,6 +11,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/grrywlsn/plexify/normrules"
 	"github.com/grrywlsn/plexify/track"
 )
 
@@ -39,55 +41,97 @@ type trackSearchStrategy struct {
 
 // SearchTrack searches for a track in Plex using title/artist matching.
 // It uses a tiered pipeline: all strategies run with combined-query search first, then again with
-// title/artist search; only if still unmatched and SkipFullLibrarySearch is false, it scans /all.
+// title/artist search. If still unmatched, tracks with an album are looked up on that album in Plex
+// (unless SkipAlbumFallback), and only then, if SkipFullLibrarySearch is false, it scans /all.
 // With ExactMatchesOnly, only the first strategy (raw source title/artist) runs and full-library scan is skipped.
+// SetSearchStrategies and PLEXIFY_SEARCH_PHASES reorder or trim the indexed strategies and phases.
+// With several library sections (PLEX_LIBRARY_SECTION_ID), every step searches the sections in priority
+// order and the first section with a match wins that step.
 //
 // When the source artist field lists multiple names separated by commas (typical on music-social.com),
 // the primary (first) name is used first for Plex queries, then the full string is retried if needed.
 // When MusicBrainz artist_credits are present on the track, each distinct credit name is tried after that,
-// which often matches Plex display metadata without fetching Plex Artist titleSort.
+// which often matches Plex display metadata without fetching Plex Artist titleSort. Names from the artist
+// alias table (SetArtistAliases) are tried last.
+//
+// A matching entry in the manual overrides file (see SetOverrides) short-circuits the pipeline: the
+// pinned rating key or redirected lookup is returned as MatchTypeOverride, or MatchTypeSkipped for "skip".
+// With a review band below the match threshold, the best candidate that missed the threshold but reached
+// PLEXIFY_AUTO_REJECT_PERCENT is returned as MatchTypeReview when nothing better is found.
 func (c *Client) SearchTrack(ctx context.Context, song track.Track) (*PlexTrack, MatchKind, error) {
+	tr, kind, _, err := c.searchTrack(ctx, song)
+	return tr, kind, err
+}
+
+// searchTrack is SearchTrack that also returns the score a title/artist match was accepted or held with.
+func (c *Client) searchTrack(ctx context.Context, song track.Track) (*PlexTrack, MatchKind, float64, error) {
 	if err := ctx.Err(); err != nil {
-		return nil, MatchTypeError, fmt.Errorf("search cancelled: %w", err)
+		return nil, MatchTypeError, 0, fmt.Errorf("search cancelled: %w", err)
+	}
+
+	st := newSearchState(ctx, song)
+	ctx = withSearchState(ctx, st)
+	if tr, kind, ok, err := c.searchOverride(ctx, song); ok {
+		return tr, kind, 0, err
 	}
 
-	candidates := song.PlexSearchArtistCandidates()
+	candidates := c.artistSearchCandidates(song)
 	for i, searchArtist := range candidates {
 		if i > 0 {
-			c.debugLog("🔍 SearchTrack: no match with primary artist; retrying with full artist field %q", searchArtist)
+			c.debugLog("🔍 SearchTrack: no match with primary artist; retrying with artist candidate %q", searchArtist)
 		}
 		found, err := c.searchTrackWithArtist(ctx, song, searchArtist)
 		if err != nil {
-			return nil, MatchTypeError, err
+			return nil, MatchTypeError, 0, err
 		}
 		if found != nil {
-			return found, MatchTypeTitleArtist, nil
+			score, ok := st.acceptedScoreFor(found)
+			if !ok {
+				score = c.calculateConfidence(song, found, MatchTypeTitleArtist)
+			}
+			return found, MatchTypeTitleArtist, score, nil
+		}
+		if st.budgetSpent.Load() {
+			break
 		}
 	}
+	if st.held != nil {
+		c.debugLog("⏸️  SearchTrack: no confident match for '%s'; returning '%s' by '%s' for review", song.Name, st.held.Title, st.held.DisplayArtist())
+		return st.held, MatchTypeReview, st.heldScore, nil
+	}
 
-	return nil, MatchTypeNone, nil
+	return nil, MatchTypeNone, 0, nil
 }
 
 // searchTrackWithArtist runs the search pipeline for a single artist string (title still from song).
 func (c *Client) searchTrackWithArtist(ctx context.Context, song track.Track, artist string) (*PlexTrack, error) {
 	c.debugLog("🔍 SearchTrack: searching for '%s' by '%s' (source artist field: %q)", song.Name, artist, song.Artist)
 
-	indexedStrategies := c.indexedTrackSearchStrategies()
+	indexedStrategies := c.activeTrackSearchStrategies()
 	if c.exactMatchesOnly {
-		indexedStrategies = indexedStrategies[:1]
 		c.debugLog("🔍 SearchTrack: exact-matches-only (raw title/artist only, no /all)")
 	}
 
-	for _, phase := range []searchPhase{searchPhaseCombined, searchPhaseTitleArtist} {
+	for _, phase := range c.activeSearchPhases() {
 		for _, strategy := range indexedStrategies {
 			if err := ctx.Err(); err != nil {
 				return nil, fmt.Errorf("search cancelled: %w", err)
 			}
-			tr, err := strategy.fn(ctx, phase, song.Name, artist, song.Album)
+			tracer := tracerFrom(ctx)
+			tracer.beginStep(artist, strategy.name, phase.tierLabel())
+			calls := httpCallCount(ctx)
+			tr, err := c.eachLibrarySection(ctx, func(ctx context.Context) (*PlexTrack, error) {
+				return strategy.fn(ctx, phase, song.Name, artist, song.Album)
+			})
+			tracer.endStep()
+			c.recordStrategyRun(strategy.name, phase.tierLabel(), httpCallCount(ctx) > calls, tr != nil && err == nil)
 			if err != nil {
 				if ctx.Err() != nil {
 					return nil, fmt.Errorf("search cancelled: %w", ctx.Err())
 				}
+				if errors.Is(err, errHTTPCallBudget) {
+					return c.stopOnHTTPCallBudget(ctx, song, artist)
+				}
 				if isTransientPlexErr(err) {
 					slog.WarnContext(ctx, "plex search step failed; trying next strategy",
 						"err", err, "strategy", strategy.name, "phase", phase.tierLabel(),
@@ -103,16 +147,57 @@ func (c *Client) searchTrackWithArtist(ctx context.Context, song track.Track, ar
 		}
 	}
 
+	if !c.skipAlbumFallback && !c.exactMatchesOnly && strings.TrimSpace(song.Album) != "" {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("search cancelled: %w", err)
+		}
+		c.debugLog("🔍 SearchTrack: trying album fallback for '%s' by '%s' on '%s'", song.Name, artist, song.Album)
+		tracer := tracerFrom(ctx)
+		tracer.beginStep(artist, albumFallbackStrategy, albumFallbackPhase)
+		calls := httpCallCount(ctx)
+		tr, err := c.eachLibrarySection(ctx, func(ctx context.Context) (*PlexTrack, error) {
+			return c.searchAlbumFallback(ctx, song, artist)
+		})
+		tracer.endStep()
+		c.recordStrategyRun(albumFallbackStrategy, albumFallbackPhase, httpCallCount(ctx) > calls, tr != nil && err == nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("search cancelled: %w", ctx.Err())
+			}
+			if errors.Is(err, errHTTPCallBudget) {
+				return c.stopOnHTTPCallBudget(ctx, song, artist)
+			}
+			if !isTransientPlexErr(err) {
+				return nil, err
+			}
+			slog.WarnContext(ctx, "album fallback Plex search failed; trying full library search",
+				"err", err, "title", song.Name, "artist", artist, "album", song.Album)
+		} else if tr != nil {
+			slog.Debug(fmt.Sprintf("✅ SearchTrack: found match '%s' by '%s' using album fallback", tr.Title, tr.DisplayArtist()))
+			return tr, nil
+		}
+	}
+
 	if !c.skipFullLibrarySearch && !c.exactMatchesOnly {
 		if err := ctx.Err(); err != nil {
 			return nil, fmt.Errorf("search cancelled: %w", err)
 		}
 		c.debugLog("🔍 SearchTrack: trying full library search for '%s' by '%s'", song.Name, artist)
-		tr, err := c.searchEntireLibrary(ctx, song.Name, artist, song.Album)
+		tracer := tracerFrom(ctx)
+		tracer.beginStep(artist, fullLibraryStrategy, fullLibraryPhase)
+		calls := httpCallCount(ctx)
+		tr, err := c.eachLibrarySection(ctx, func(ctx context.Context) (*PlexTrack, error) {
+			return c.searchEntireLibrary(ctx, song.Name, artist, song.Album)
+		})
+		tracer.endStep()
+		c.recordStrategyRun(fullLibraryStrategy, fullLibraryPhase, httpCallCount(ctx) > calls, tr != nil && err == nil)
 		if err != nil {
 			if ctx.Err() != nil {
 				return nil, fmt.Errorf("search cancelled: %w", ctx.Err())
 			}
+			if errors.Is(err, errHTTPCallBudget) {
+				return c.stopOnHTTPCallBudget(ctx, song, artist)
+			}
 			if isTransientPlexErr(err) {
 				slog.WarnContext(ctx, "full library Plex scan failed; treating as no match for this track",
 					"err", err, "title", song.Name, "artist", artist)
@@ -130,6 +215,16 @@ func (c *Client) searchTrackWithArtist(ctx context.Context, song track.Track, ar
 	return nil, nil
 }
 
+// stopOnHTTPCallBudget ends the search for one artist candidate once the per-track request budget is
+// spent. The track is counted in SearchStats the first time only.
+func (c *Client) stopOnHTTPCallBudget(ctx context.Context, song track.Track, artist string) (*PlexTrack, error) {
+	if st := searchStateFrom(ctx); st == nil || st.budgetSpent == nil || st.budgetSpent.CompareAndSwap(false, true) {
+		c.recordBudgetExhausted()
+	}
+	c.debugLog("⏹️  SearchTrack: HTTP call budget (%d) exhausted for '%s' by '%s'; giving up", c.maxHTTPCallsPerTrack, song.Name, artist)
+	return nil, nil
+}
+
 func (c *Client) indexedTrackSearchStrategies() []trackSearchStrategy {
 	return []trackSearchStrategy{
 		{"exact title/artist", func(ctx context.Context, phase searchPhase, title, artist, sourceAlbum string) (*PlexTrack, error) {
@@ -207,6 +302,16 @@ func (c *Client) indexedTrackSearchStrategies() []trackSearchStrategy {
 			}
 			return nil, nil
 		}},
+		// PLEXIFY_NORMALIZATION_RULES: catalog quirks the built-in cleanups above don't know about.
+		{userRulesStrategy, func(ctx context.Context, phase searchPhase, title, artist, sourceAlbum string) (*PlexTrack, error) {
+			ruleTitle := c.applyUserRules(normrules.ScopeTitle, title)
+			ruleArtist := c.applyUserRules(normrules.ScopeArtist, artist)
+			if (ruleTitle == title && ruleArtist == artist) || ruleTitle == "" || ruleArtist == "" {
+				return nil, nil
+			}
+			c.debugLog("🔍 SearchTrack: trying user-rule normalized '%s' by '%s' for '%s' by '%s'", ruleTitle, ruleArtist, title, artist)
+			return c.trySearchVariationsPhase(ctx, ruleTitle, ruleArtist, sourceAlbum, phase)
+		}},
 		{"accent normalization", func(ctx context.Context, phase searchPhase, title, artist, sourceAlbum string) (*PlexTrack, error) {
 			accentTitle := c.normalizeAccents(title)
 			accentArtist := c.normalizeAccents(artist)
@@ -216,6 +321,18 @@ func (c *Client) indexedTrackSearchStrategies() []trackSearchStrategy {
 			}
 			return nil, nil
 		}},
+		// Romanize Cyrillic/Greek/kana/Hangul and fold fullwidth ASCII so a native-script source finds a
+		// romanized Plex tag. The reverse (romanized source, native Plex tag) cannot be queried; scoring
+		// transliterates both sides so the full-library scan can still pick it.
+		{"transliterated", func(ctx context.Context, phase searchPhase, title, artist, sourceAlbum string) (*PlexTrack, error) {
+			tTitle := c.transliterate(title)
+			tArtist := c.transliterate(artist)
+			if (tTitle != title || tArtist != artist) && (tTitle != c.normalizeAccents(title) || tArtist != c.normalizeAccents(artist)) {
+				c.debugLog("🔍 SearchTrack: trying transliterated '%s' by '%s' for '%s' by '%s'", tTitle, tArtist, title, artist)
+				return c.trySearchVariationsPhase(ctx, tTitle, tArtist, sourceAlbum, phase)
+			}
+			return nil, nil
+		}},
 	}
 }
 
@@ -240,7 +357,8 @@ func (c *Client) trySearchVariationsPhase(ctx context.Context, title, artist, so
 func (c *Client) searchByTitle(ctx context.Context, title, artist, sourceAlbum string) (*PlexTrack, error) {
 
 	// Use the library search endpoint
-	reqURL := fmt.Sprintf("%s/library/sections/%d/search", c.baseURL, c.sectionID)
+	section := c.currentLibrarySection(ctx)
+	reqURL := fmt.Sprintf("%s/library/sections/%d/search", c.baseURL, section.ID)
 	params := url.Values{}
 	params.Add("X-Plex-Token", c.token)
 	params.Add("query", title)
@@ -270,6 +388,7 @@ func (c *Client) searchByTitle(ctx context.Context, title, artist, sourceAlbum s
 		return nil, fmt.Errorf("failed to decode search response: %w", err)
 	}
 	_ = resp.Body.Close()
+	stampLibrarySection(section, searchResp.Tracks)
 
 	// Find best match among search results
 	slog.Debug(fmt.Sprintf("🔍 searchByTitle: searching for '%s' by '%s', found %d results", title, artist, len(searchResp.Tracks)))
@@ -291,7 +410,8 @@ func (c *Client) searchByTitle(ctx context.Context, title, artist, sourceAlbum s
 func (c *Client) searchByArtist(ctx context.Context, title, artist, sourceAlbum string) (*PlexTrack, error) {
 
 	// Use the library search endpoint with artist query
-	reqURL := fmt.Sprintf("%s/library/sections/%d/search", c.baseURL, c.sectionID)
+	section := c.currentLibrarySection(ctx)
+	reqURL := fmt.Sprintf("%s/library/sections/%d/search", c.baseURL, section.ID)
 	params := url.Values{}
 	params.Add("X-Plex-Token", c.token)
 	params.Add("query", artist)
@@ -321,6 +441,7 @@ func (c *Client) searchByArtist(ctx context.Context, title, artist, sourceAlbum
 		return nil, fmt.Errorf("failed to decode artist search response: %w", err)
 	}
 	_ = resp.Body.Close()
+	stampLibrarySection(section, searchResp.Tracks)
 
 	// Find best match among search results
 	result := c.findBestMatchWithOptionalArtistSortRetry(ctx, searchResp.Tracks, title, artist, sourceAlbum, false)
@@ -335,7 +456,8 @@ func (c *Client) searchByCombinedQuery(ctx context.Context, title, artist, sourc
 	// Try the most likely combination first
 	query := fmt.Sprintf("%s %s", title, artist)
 
-	reqURL := fmt.Sprintf("%s/library/sections/%d/search", c.baseURL, c.sectionID)
+	section := c.currentLibrarySection(ctx)
+	reqURL := fmt.Sprintf("%s/library/sections/%d/search", c.baseURL, section.ID)
 	params := url.Values{}
 	params.Add("X-Plex-Token", c.token)
 	params.Add("query", query)
@@ -367,6 +489,7 @@ func (c *Client) searchByCombinedQuery(ctx context.Context, title, artist, sourc
 		return nil, nil
 	}
 	_ = resp.Body.Close()
+	stampLibrarySection(section, searchResp.Tracks)
 	if track := c.findBestMatchWithOptionalArtistSortRetry(ctx, searchResp.Tracks, title, artist, sourceAlbum, false); track != nil {
 		slog.Debug(fmt.Sprintf("✅ searchByCombinedQuery: found match '%s' by '%s'", track.Title, track.DisplayArtist()))
 		return track, nil
@@ -426,7 +549,8 @@ func (c *Client) searchByTitleWithSingleQuoteVariations(ctx context.Context, tit
 // This is used when the regular search methods fail to find tracks that should exist
 func (c *Client) searchEntireLibrary(ctx context.Context, title, artist, sourceAlbum string) (*PlexTrack, error) {
 	// Get all tracks from the library
-	reqURL := fmt.Sprintf("%s/library/sections/%d/all", c.baseURL, c.sectionID)
+	section := c.currentLibrarySection(ctx)
+	reqURL := fmt.Sprintf("%s/library/sections/%d/all", c.baseURL, section.ID)
 	params := url.Values{}
 	params.Add("X-Plex-Token", c.token)
 	params.Add("type", PlexMusicTrackType) // Type 10 = music tracks
@@ -458,6 +582,7 @@ func (c *Client) searchEntireLibrary(ctx context.Context, title, artist, sourceA
 		return nil, fmt.Errorf("failed to decode library response: %w", err)
 	}
 	_ = resp.Body.Close()
+	stampLibrarySection(section, libraryResp.Tracks)
 
 	// Find best match among all tracks
 	c.debugLog("🔍 searchEntireLibrary: searching for '%s' by '%s' in entire library (%d tracks)", title, artist, len(libraryResp.Tracks))
@@ -502,6 +627,15 @@ func (c *Client) bestAlbumSimilarity(sourceAlbum, plexAlbum string) float64 {
 	); v > best {
 		best = v
 	}
+	if v := c.calculateStringSimilarity(
+		strings.ToLower(strings.TrimSpace(c.transliterate(sourceAlbum))),
+		strings.ToLower(strings.TrimSpace(c.transliterate(plexAlbum))),
+	); v > best {
+		best = v
+	}
+	if v, ok := c.userRulesSimilarity(normrules.ScopeAlbum, sourceAlbum, plexAlbum); ok && v > best {
+		best = v
+	}
 	return best
 }
 
@@ -525,11 +659,19 @@ func (c *Client) artistMatchSimilarity(artist, plexArtist string) float64 {
 	if v := c.calculateStringSimilarity(accentArtistLower, accentTrackArtistLower); v > artistSimilarity {
 		artistSimilarity = v
 	}
+	translitArtistLower := strings.ToLower(strings.TrimSpace(c.transliterate(artist)))
+	translitTrackArtistLower := strings.ToLower(strings.TrimSpace(c.transliterate(plexArtist)))
+	if v := c.calculateStringSimilarity(translitArtistLower, translitTrackArtistLower); v > artistSimilarity {
+		artistSimilarity = v
+	}
 	featuringArtistLower := strings.ToLower(strings.TrimSpace(c.removeFeaturing(artist)))
 	featuringTrackArtistLower := strings.ToLower(strings.TrimSpace(c.removeFeaturing(plexArtist)))
 	if v := c.calculateStringSimilarity(featuringArtistLower, featuringTrackArtistLower); v > artistSimilarity {
 		artistSimilarity = v
 	}
+	if v, ok := c.userRulesSimilarity(normrules.ScopeArtist, artist, plexArtist); ok && v > artistSimilarity {
+		artistSimilarity = v
+	}
 	return artistSimilarity
 }
 
@@ -547,6 +689,9 @@ func (c *Client) bestPlexTrackArtistSimilarity(artist string, tr PlexTrack) floa
 			best = v
 		}
 	}
+	if v := c.bestAliasArtistSimilarity(artist, tr); v > best {
+		best = v
+	}
 	return best
 }
 
@@ -595,10 +740,19 @@ func (c *Client) plexTrackNormPunctTitleArtistMatch(tr PlexTrack, titleLower, ar
 
 // FindBestMatch finds the best matching track from search results. When sourceAlbum is non-empty,
 // album similarity is blended into the score so duplicate title/artist releases can be disambiguated.
+// Version qualifiers (live, remix, edit, …) that differ between title and a candidate reduce its score.
 func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum string) *PlexTrack {
+	return c.findBestMatch(tracks, title, artist, sourceAlbum, title, nil)
+}
+
+// findBestMatch is FindBestMatch with the version qualifiers taken from versionTitle, the original source
+// title, which may differ from the (normalized) title being compared. With a search state, scored
+// candidates are recorded on its tracer and a best candidate inside the review band is held on it.
+func (c *Client) findBestMatch(tracks []PlexTrack, title, artist, sourceAlbum, versionTitle string, st *searchState) *PlexTrack {
 	if len(tracks) == 0 {
 		return nil
 	}
+	tracer := st.matchTracer()
 
 	titleLower := strings.ToLower(strings.TrimSpace(title))
 	artistLower := strings.ToLower(strings.TrimSpace(artist))
@@ -616,7 +770,15 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 	switch len(exactMatches) {
 	case 1:
 		t := exactMatches[0]
+		// A stripped search title can exactly match the wrong version ("Song" for "Song (Live)"); let
+		// scoring weigh it against the other candidates instead.
+		if len(tracks) > 1 && c.versionMismatchPenalty(versionTitle, sourceAlbum, t) > 0 {
+			c.debugLog("   FindBestMatch: exact match '%s' on '%s' differs in version from '%s'; scoring all candidates", t.Title, t.Album, versionTitle)
+			break
+		}
 		c.debugLog("✅ FindBestMatch: single exact match '%s' by '%s'", t.Title, t.DisplayArtist())
+		c.traceExactMatches(tracer, exactMatches, sourceAlbum, versionTitle, &t, "single exact title/artist match")
+		st.accept(&t, c.exactMatchScores(t, sourceAlbum, versionTitle).Total)
 		return &t
 	case 0:
 		// fall through to similarity scoring
@@ -626,22 +788,34 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 			var bestAl float64 = -1
 			for _, tr := range exactMatches {
 				al := c.bestAlbumSimilarity(sourceAlbum, tr.Album)
-				if bestAl < 0 || al > bestAl+scoreEqEps {
+				if bestAl < 0 || al > bestAl+scoreEqEps ||
+					(math.Abs(al-bestAl) <= scoreEqEps && c.preferVersion(versionTitle, sourceAlbum, tr, best)) {
 					bestAl = al
 					best = tr
 				}
 			}
 			t := best
 			c.debugLog("✅ FindBestMatch: multiple exact title/artist; picked by album (album similarity %s)", formatConfidencePercent(bestAl))
+			c.traceExactMatches(tracer, exactMatches, sourceAlbum, versionTitle, &t, "multiple exact title/artist matches; picked by album")
+			st.accept(&t, c.exactMatchScores(t, sourceAlbum, versionTitle).Total)
+			return &t
+		}
+		if len(c.versionPreferences()) > 0 || len(c.qualityPrefs) > 0 {
+			t := c.pickPreferredVersion(versionTitle, sourceAlbum, exactMatches)
+			c.debugLog("✅ FindBestMatch: multiple exact title/artist; picked '%s' on '%s' by version preference %v, quality preference %v",
+				t.Title, t.Album, c.versionPreferences(), c.qualityPrefs)
+			c.traceExactMatches(tracer, exactMatches, sourceAlbum, versionTitle, &t, "multiple exact title/artist matches; picked by version preference")
+			st.accept(&t, c.exactMatchScores(t, sourceAlbum, versionTitle).Total)
 			return &t
 		}
-		// Multiple exact matches and no source album: use full scoring below.
+		// Multiple exact matches, no source album and no preferences: use full scoring below.
 	}
 
 	var bestMatch *PlexTrack
 	var bestScore float64
 	var bestArtistSimilarity float64
 	var bestAlbumSimilarity float64 = -1
+	var traced []TraceCandidate
 
 	for _, track := range tracks {
 		trackTitle := strings.ToLower(strings.TrimSpace(track.Title))
@@ -719,7 +893,19 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 		accentTitleSimilarity := c.calculateStringSimilarity(accentTitleLower, accentTrackTitleLower)
 		c.debugLog("   Accent-normalized title similarity: %s ('%s' vs '%s')", formatConfidencePercent(accentTitleSimilarity), accentTitleLower, accentTrackTitleLower)
 
-		// Use the best of the eight title similarities
+		// Romanized native scripts (Cyrillic, Greek, kana, Hangul) and fullwidth folding
+		translitTitleLower := strings.ToLower(strings.TrimSpace(c.transliterate(title)))
+		translitTrackTitleLower := strings.ToLower(strings.TrimSpace(c.transliterate(track.Title)))
+		translitTitleSimilarity := c.calculateStringSimilarity(translitTitleLower, translitTrackTitleLower)
+		c.debugLog("   Transliterated title similarity: %s ('%s' vs '%s')", formatConfidencePercent(translitTitleSimilarity), translitTitleLower, translitTrackTitleLower)
+
+		userRulesTitleSimilarity, userRulesApplied := c.userRulesSimilarity(normrules.ScopeTitle, title, track.Title)
+		if userRulesApplied {
+			c.debugLog("   User-rule title similarity: %s ('%s' vs '%s')", formatConfidencePercent(userRulesTitleSimilarity),
+				c.applyUserRules(normrules.ScopeTitle, title), c.applyUserRules(normrules.ScopeTitle, track.Title))
+		}
+
+		// Use the best of the title similarities
 		if cleanTitleSimilarity > titleSimilarity {
 			c.debugLog("   Using clean title similarity: %s (was %s)", formatConfidencePercent(cleanTitleSimilarity), formatConfidencePercent(titleSimilarity))
 			titleSimilarity = cleanTitleSimilarity
@@ -752,6 +938,14 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 			c.debugLog("   Using accent-normalized title similarity: %s (was %s)", formatConfidencePercent(accentTitleSimilarity), formatConfidencePercent(titleSimilarity))
 			titleSimilarity = accentTitleSimilarity
 		}
+		if translitTitleSimilarity > titleSimilarity {
+			c.debugLog("   Using transliterated title similarity: %s (was %s)", formatConfidencePercent(translitTitleSimilarity), formatConfidencePercent(titleSimilarity))
+			titleSimilarity = translitTitleSimilarity
+		}
+		if userRulesTitleSimilarity > titleSimilarity {
+			c.debugLog("   Using user-rule title similarity: %s (was %s)", formatConfidencePercent(userRulesTitleSimilarity), formatConfidencePercent(titleSimilarity))
+			titleSimilarity = userRulesTitleSimilarity
+		}
 
 		albumSimilarity := 0.0
 		if useAlbumInScore {
@@ -764,6 +958,8 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 		} else {
 			score = (titleSimilarity * 0.7) + (artistSimilarity * 0.3)
 		}
+		versionPenalty := c.versionMismatchPenalty(versionTitle, sourceAlbum, track)
+		score -= versionPenalty
 
 		c.debugLog("   Final title similarity: %s", formatConfidencePercent(titleSimilarity))
 		c.debugLog("   Final artist similarity: %s", formatConfidencePercent(artistSimilarity))
@@ -773,6 +969,15 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 		} else {
 			c.debugLog("   Combined score: %s (%s * 0.7 + %s * 0.3)", formatConfidencePercent(score), formatConfidencePercent(titleSimilarity), formatConfidencePercent(artistSimilarity))
 		}
+		if versionPenalty > 0 {
+			c.debugLog("   Version qualifier mismatch: -%s ('%s' vs '%s' on '%s')", formatConfidencePercent(versionPenalty), versionTitle, track.Title, track.Album)
+		}
+		if tracer != nil {
+			traced = append(traced, newTraceCandidate(track, TraceScores{
+				Title: titleSimilarity, Artist: artistSimilarity, Album: albumSimilarity,
+				Duration: durationSimilarity(tracer.sourceDuration(), track.Duration), VersionPenalty: versionPenalty, Total: score,
+			}))
+		}
 
 		// Additional check: if title similarity is very high (>90%), require reasonable artist similarity
 		// Special case: be more lenient with "Various Artists" for compilation albums
@@ -822,6 +1027,11 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 			} else if artistSimilarity > bestArtistSimilarity+scoreEqEps {
 				pick = true
 			}
+			if !pick && math.Abs(albumSimilarity-bestAlbumSimilarity) <= scoreEqEps &&
+				math.Abs(artistSimilarity-bestArtistSimilarity) <= scoreEqEps &&
+				c.preferVersion(versionTitle, sourceAlbum, track, *bestMatch) {
+				pick = true
+			}
 		}
 
 		if pick {
@@ -843,10 +1053,13 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 				track.Title, track.DisplayArtist(), formatConfidencePercent(score), formatConfidencePercent(bestScore))
 		}
 
-		// Perfect title+artist: return immediately only when album is not used for disambiguation.
-		if !useAlbumInScore && titleSimilarity == 1.0 && artistSimilarity == 1.0 {
+		// Perfect title+artist: return immediately only when album is not used for disambiguation and
+		// no version qualifier differs (otherwise a later candidate may be the right version).
+		if !useAlbumInScore && titleSimilarity == 1.0 && artistSimilarity == 1.0 && versionPenalty == 0 {
 			c.debugLog("🎯 FindBestMatch: perfect match found '%s' by '%s'", track.Title, track.DisplayArtist())
 			trackCopy := track
+			tracer.recordCandidates(traced, &trackCopy, "perfect title/artist match")
+			st.accept(&trackCopy, score)
 			return &trackCopy
 		}
 	}
@@ -856,19 +1069,64 @@ func (c *Client) FindBestMatch(tracks []PlexTrack, title, artist, sourceAlbum st
 	if bestScore >= minScore {
 		slog.Debug(fmt.Sprintf("✅ FindBestMatch: FINAL RESULT - returning match '%s' by '%s' (score: %s >= %s) for search '%s' by '%s'",
 			bestMatch.Title, bestMatch.DisplayArtist(), formatConfidencePercent(bestScore), formatConfidencePercent(minScore), title, artist))
+		tracer.recordCandidates(traced, bestMatch, fmt.Sprintf("best score %s >= threshold %s", formatConfidencePercent(bestScore), formatConfidencePercent(minScore)))
+		st.accept(bestMatch, bestScore)
 		return bestMatch
 	}
 
 	c.debugLog("❌ FindBestMatch: FINAL RESULT - no match found (best score: %s < %s) for search '%s' by '%s'", formatConfidencePercent(bestScore), formatConfidencePercent(minScore), title, artist)
+	if floor, ok := c.reviewFloor(); ok && bestMatch != nil && bestScore >= floor {
+		c.debugLog("⏸️  FindBestMatch: holding '%s' by '%s' (score: %s) for review unless a later strategy does better", bestMatch.Title, bestMatch.DisplayArtist(), formatConfidencePercent(bestScore))
+		st.hold(bestMatch, bestScore)
+	}
+	if len(tracks) > 0 {
+		tracer.recordCandidates(traced, nil, fmt.Sprintf("no candidate reached threshold %s (best %s)", formatConfidencePercent(minScore), formatConfidencePercent(bestScore)))
+	}
 	return nil
 }
 
+// traceExactMatches records exact title/artist matches (which skip similarity scoring) on tracer.
+func (c *Client) traceExactMatches(tracer *matchTracer, exact []PlexTrack, sourceAlbum, versionTitle string, picked *PlexTrack, reason string) {
+	if tracer == nil {
+		return
+	}
+	cands := make([]TraceCandidate, 0, len(exact))
+	for _, tr := range exact {
+		sc := c.exactMatchScores(tr, sourceAlbum, versionTitle)
+		sc.Duration = durationSimilarity(tracer.sourceDuration(), tr.Duration)
+		cands = append(cands, newTraceCandidate(tr, sc))
+	}
+	tracer.recordCandidates(cands, picked, reason)
+}
+
+// exactMatchScores scores an exact title/artist match with the FindBestMatch weights: full title and
+// artist similarity, album similarity when sourceAlbum is set, less any version penalty.
+func (c *Client) exactMatchScores(tr PlexTrack, sourceAlbum, versionTitle string) TraceScores {
+	sc := TraceScores{Title: 1, Artist: 1}
+	if strings.TrimSpace(sourceAlbum) != "" {
+		sc.Album = c.bestAlbumSimilarity(sourceAlbum, tr.Album)
+		sc.Total = 0.55 + 0.25 + sc.Album*0.20
+	} else {
+		sc.Total = 1
+	}
+	sc.VersionPenalty = c.versionMismatchPenalty(versionTitle, sourceAlbum, tr)
+	sc.Total -= sc.VersionPenalty
+	return sc
+}
+
 // FindBestMatchWithNormalizedPunctuation finds the best matching track using normalized punctuation.
 // When sourceAlbum is non-empty, album similarity is blended into the score (same weights as FindBestMatch).
 func (c *Client) FindBestMatchWithNormalizedPunctuation(tracks []PlexTrack, title, artist, sourceAlbum string) *PlexTrack {
+	return c.findBestMatchWithNormalizedPunctuation(tracks, title, artist, sourceAlbum, nil)
+}
+
+// findBestMatchWithNormalizedPunctuation is FindBestMatchWithNormalizedPunctuation with findBestMatch's
+// search state handling: candidates are traced, and a best candidate inside the review band is held.
+func (c *Client) findBestMatchWithNormalizedPunctuation(tracks []PlexTrack, title, artist, sourceAlbum string, st *searchState) *PlexTrack {
 	if len(tracks) == 0 {
 		return nil
 	}
+	tracer := st.matchTracer()
 
 	normalizedTitle := c.normalizePunctuation(title)
 	normalizedArtist := c.normalizePunctuation(artist)
@@ -890,6 +1148,8 @@ func (c *Client) FindBestMatchWithNormalizedPunctuation(tracks []PlexTrack, titl
 	case 1:
 		t := exactMatches[0]
 		slog.Debug(fmt.Sprintf("✅ FindBestMatchWithNormalizedPunctuation: single exact match '%s' by '%s'", t.Title, t.DisplayArtist()))
+		c.traceExactMatches(tracer, exactMatches, sourceAlbum, title, &t, "single exact title/artist match (punctuation normalized)")
+		st.accept(&t, c.exactMatchScores(t, sourceAlbum, title).Total)
 		return &t
 	case 0:
 	default:
@@ -905,6 +1165,8 @@ func (c *Client) FindBestMatchWithNormalizedPunctuation(tracks []PlexTrack, titl
 			}
 			t := best
 			slog.Debug(fmt.Sprintf("✅ FindBestMatchWithNormalizedPunctuation: multiple exact; picked by album (similarity %s)", formatConfidencePercent(bestAl)))
+			c.traceExactMatches(tracer, exactMatches, sourceAlbum, title, &t, "multiple exact title/artist matches (punctuation normalized); picked by album")
+			st.accept(&t, c.exactMatchScores(t, sourceAlbum, title).Total)
 			return &t
 		}
 	}
@@ -913,6 +1175,7 @@ func (c *Client) FindBestMatchWithNormalizedPunctuation(tracks []PlexTrack, titl
 	var bestScore float64
 	var bestArtistSimilarity float64
 	var bestAlbumSimilarity float64 = -1
+	var traced []TraceCandidate
 
 	for _, track := range tracks {
 		normalizedTrackTitle := c.normalizePunctuation(track.Title)
@@ -932,6 +1195,14 @@ func (c *Client) FindBestMatchWithNormalizedPunctuation(tracks []PlexTrack, titl
 		} else {
 			score = (titleSimilarity * 0.7) + (artistSimilarity * 0.3)
 		}
+		versionPenalty := c.versionMismatchPenalty(title, sourceAlbum, track)
+		score -= versionPenalty
+		if tracer != nil {
+			traced = append(traced, newTraceCandidate(track, TraceScores{
+				Title: titleSimilarity, Artist: artistSimilarity, Album: albumSimilarity,
+				Duration: durationSimilarity(tracer.sourceDuration(), track.Duration), VersionPenalty: versionPenalty, Total: score,
+			}))
+		}
 
 		if titleSimilarity > 0.9 && artistSimilarity < 0.3 {
 			if strings.ToLower(strings.TrimSpace(track.Artist)) == "various artists" {
@@ -969,6 +1240,11 @@ func (c *Client) FindBestMatchWithNormalizedPunctuation(tracks []PlexTrack, titl
 			} else if artistSimilarity > bestArtistSimilarity+scoreEqEps {
 				pick = true
 			}
+			if !pick && math.Abs(albumSimilarity-bestAlbumSimilarity) <= scoreEqEps &&
+				math.Abs(artistSimilarity-bestArtistSimilarity) <= scoreEqEps &&
+				c.preferVersion(title, sourceAlbum, track, *bestMatch) {
+				pick = true
+			}
 		}
 
 		if pick {
@@ -981,9 +1257,11 @@ func (c *Client) FindBestMatchWithNormalizedPunctuation(tracks []PlexTrack, titl
 				track.Title, track.DisplayArtist(), formatConfidencePercent(score), formatConfidencePercent(titleSimilarity), formatConfidencePercent(artistSimilarity)))
 		}
 
-		if !useAlbumInScore && titleSimilarity == 1.0 && artistSimilarity == 1.0 {
+		if !useAlbumInScore && titleSimilarity == 1.0 && artistSimilarity == 1.0 && versionPenalty == 0 {
 			slog.Debug(fmt.Sprintf("🎯 FindBestMatchWithNormalizedPunctuation: perfect match found '%s' by '%s'", track.Title, track.DisplayArtist()))
 			trackCopy := track
+			tracer.recordCandidates(traced, &trackCopy, "perfect title/artist match (punctuation normalized)")
+			st.accept(&trackCopy, score)
 			return &trackCopy
 		}
 	}
@@ -992,9 +1270,16 @@ func (c *Client) FindBestMatchWithNormalizedPunctuation(tracks []PlexTrack, titl
 	if bestScore >= minScore {
 		slog.Debug(fmt.Sprintf("✅ FindBestMatchWithNormalizedPunctuation: returning match '%s' by '%s' (score: %s >= %s)",
 			bestMatch.Title, bestMatch.DisplayArtist(), formatConfidencePercent(bestScore), formatConfidencePercent(minScore)))
+		tracer.recordCandidates(traced, bestMatch, fmt.Sprintf("best score %s >= threshold %s", formatConfidencePercent(bestScore), formatConfidencePercent(minScore)))
+		st.accept(bestMatch, bestScore)
 		return bestMatch
 	}
 
 	slog.Debug(fmt.Sprintf("❌ FindBestMatchWithNormalizedPunctuation: no match found (best score: %s < %s)", formatConfidencePercent(bestScore), formatConfidencePercent(minScore)))
+	if floor, ok := c.reviewFloor(); ok && bestMatch != nil && bestScore >= floor {
+		slog.Debug(fmt.Sprintf("⏸️  FindBestMatchWithNormalizedPunctuation: holding '%s' by '%s' (score: %s) for review", bestMatch.Title, bestMatch.DisplayArtist(), formatConfidencePercent(bestScore)))
+		st.hold(bestMatch, bestScore)
+	}
+	tracer.recordCandidates(traced, nil, fmt.Sprintf("no candidate reached threshold %s (best %s)", formatConfidencePercent(minScore), formatConfidencePercent(bestScore)))
 	return nil
 }
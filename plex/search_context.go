@@ -0,0 +1,115 @@
+package plex
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/grrywlsn/plexify/track"
+)
+
+type searchStateKey struct{}
+
+// searchState carries per-track search context through the strategy pipeline. Strategies pass rewritten
+// titles (brackets removed, suffixes stripped) down to FindBestMatch; scoring that needs the untouched
+// source metadata reads it from here instead.
+type searchState struct {
+	source track.Track
+	tracer *matchTracer // nil when the caller did not ask for a MatchTrace
+	// httpCalls counts Plex requests made for this source track (see PLEXIFY_MAX_HTTP_CALLS_PER_TRACK).
+	// Nested states, such as an override lookup, share the parent's counter.
+	httpCalls *atomic.Int64
+	// budgetSpent is set once the request budget runs out, so SearchStats counts the track once however
+	// many artist candidates it had. Shared with nested states like httpCalls.
+	budgetSpent *atomic.Bool
+	// held is the best candidate seen so far that missed the match threshold but reached the review
+	// floor (see reviewFloorPercent). SearchTrack returns it only when no strategy finds a confident match.
+	held      *PlexTrack
+	heldScore float64
+	// acceptedID and acceptedScore are the last candidate FindBestMatch returned and the score it passed
+	// the threshold with, so the review band can use that same score.
+	acceptedID    string
+	acceptedScore float64
+}
+
+// accept records the score tr was returned with.
+func (st *searchState) accept(tr *PlexTrack, score float64) {
+	if st == nil || tr == nil {
+		return
+	}
+	st.acceptedID, st.acceptedScore = tr.ID, score
+}
+
+// acceptedScoreFor returns the score tr was accepted with, or ok false when tr was not the last
+// candidate a FindBestMatch call accepted.
+func (st *searchState) acceptedScoreFor(tr *PlexTrack) (score float64, ok bool) {
+	if st == nil || tr == nil || st.acceptedID == "" || st.acceptedID != tr.ID {
+		return 0, false
+	}
+	return st.acceptedScore, true
+}
+
+// hold keeps tr for review when it beats the candidate already held.
+func (st *searchState) hold(tr *PlexTrack, score float64) {
+	if st == nil || tr == nil || (st.held != nil && score <= st.heldScore) {
+		return
+	}
+	trackCopy := *tr
+	st.held, st.heldScore = &trackCopy, score
+}
+
+// matchTracer returns the state's tracer; nil-safe like the tracer itself.
+func (st *searchState) matchTracer() *matchTracer {
+	if st == nil {
+		return nil
+	}
+	return st.tracer
+}
+
+// newSearchState starts the state for one source track, keeping the tracer and request counter of any
+// enclosing state (MatchSourceTracks installs one before calling SearchTrack).
+func newSearchState(ctx context.Context, song track.Track) *searchState {
+	st := &searchState{source: song}
+	if parent := searchStateFrom(ctx); parent != nil {
+		st.tracer = parent.tracer
+		st.httpCalls = parent.httpCalls
+		st.budgetSpent = parent.budgetSpent
+	}
+	if st.httpCalls == nil {
+		st.httpCalls = new(atomic.Int64)
+	}
+	if st.budgetSpent == nil {
+		st.budgetSpent = new(atomic.Bool)
+	}
+	return st
+}
+
+func withSearchState(ctx context.Context, st *searchState) context.Context {
+	return context.WithValue(ctx, searchStateKey{}, st)
+}
+
+func searchStateFrom(ctx context.Context) *searchState {
+	st, _ := ctx.Value(searchStateKey{}).(*searchState)
+	return st
+}
+
+// tracerFrom returns the tracer for the current search, or nil (all matchTracer methods accept nil).
+func tracerFrom(ctx context.Context) *matchTracer {
+	return searchStateFrom(ctx).matchTracer()
+}
+
+// qualifierTitle returns the original source title for version-qualifier comparison, or fallback when
+// the search did not start from SearchTrack (direct FindBestMatch callers, tests).
+func qualifierTitle(ctx context.Context, fallback string) string {
+	if st := searchStateFrom(ctx); st != nil && st.source.Name != "" {
+		return st.source.Name
+	}
+	return fallback
+}
+
+// httpCallCount returns the Plex requests made so far for the current source track (0 outside SearchTrack).
+func httpCallCount(ctx context.Context) int64 {
+	if st := searchStateFrom(ctx); st != nil && st.httpCalls != nil {
+		return st.httpCalls.Load()
+	}
+	return 0
+}
@@ -0,0 +1,240 @@
+package plex
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PlexMusicSectionType is the /library/sections type of music libraries.
+const PlexMusicSectionType = "artist"
+
+// LibrarySection is a library section from GET /library/sections.
+type LibrarySection struct {
+	ID    int    `xml:"key,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"` // "artist" for music
+	// Locations are the section's root folders on the Plex server (see SectionForPath).
+	Locations []SectionLocation `xml:"Location"`
+	// Refreshing is set while Plex scans the section.
+	Refreshing bool `xml:"refreshing,attr"`
+}
+
+// SectionLocation is a library section root folder.
+type SectionLocation struct {
+	ID   int    `xml:"id,attr"`
+	Path string `xml:"path,attr"`
+}
+
+// Label returns the section title, or "section N" when the title is unknown.
+func (s LibrarySection) Label() string {
+	if t := strings.TrimSpace(s.Title); t != "" {
+		return t
+	}
+	return fmt.Sprintf("section %d", s.ID)
+}
+
+type plexSectionsResponse struct {
+	XMLName  xml.Name         `xml:"MediaContainer"`
+	Sections []LibrarySection `xml:"Directory"`
+}
+
+// GetLibrarySections lists the server's library sections of every type (GET /library/sections).
+func (c *Client) GetLibrarySections(ctx context.Context) ([]LibrarySection, error) {
+	reqURL := fmt.Sprintf("%s/library/sections", strings.TrimSuffix(c.baseURL, "/"))
+	params := url.Values{}
+	params.Add("X-Plex-Token", c.token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create library sections request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml")
+
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make library sections request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, newPlexHTTPError(resp.StatusCode, "library sections", b)
+	}
+
+	var mc plexSectionsResponse
+	if err := decodePlexResponseXML(resp, &mc); err != nil {
+		return nil, fmt.Errorf("failed to decode library sections response: %w", err)
+	}
+	return mc.Sections, nil
+}
+
+// numericLibrarySections returns the configured sections when every entry is a section ID, so a client
+// can search before ResolveLibrarySections has checked them. With section names among refs it returns nil
+// until then; with no refs it falls back to fallbackID.
+func numericLibrarySections(refs []string, fallbackID int) []LibrarySection {
+	var out []LibrarySection
+	for _, ref := range refs {
+		id, err := strconv.Atoi(strings.TrimSpace(ref))
+		if err != nil || id <= 0 {
+			return nil
+		}
+		out = append(out, LibrarySection{ID: id})
+	}
+	if len(out) == 0 && fallbackID > 0 {
+		out = []LibrarySection{{ID: fallbackID}}
+	}
+	return out
+}
+
+// ResolveLibrarySections checks the configured sections (PLEX_LIBRARY_SECTION_ID) against
+// /library/sections, keeping their order: each ID or name must exist and be a music library. With no
+// section configured it picks the server's only music library. Errors list the music sections available.
+func (c *Client) ResolveLibrarySections(ctx context.Context) error {
+	all, err := c.GetLibrarySections(ctx)
+	if err != nil {
+		return err
+	}
+	if len(c.sectionRefs) == 0 {
+		var music []LibrarySection
+		for _, s := range all {
+			if s.Type == PlexMusicSectionType {
+				music = append(music, s)
+			}
+		}
+		switch len(music) {
+		case 0:
+			return errors.New("this Plex server has no music library")
+		case 1:
+			c.sections = music
+			c.sectionID = music[0].ID
+			return nil
+		default:
+			return fmt.Errorf("this Plex server has %d music libraries; set PLEX_LIBRARY_SECTION_ID to one or more of: %s",
+				len(music), describeMusicSections(all))
+		}
+	}
+	var out []LibrarySection
+	seen := make(map[int]bool)
+	for _, ref := range c.sectionRefs {
+		sec, ok := findLibrarySection(all, ref)
+		if !ok {
+			return fmt.Errorf("library section %q not found; music libraries on this server: %s", ref, describeMusicSections(all))
+		}
+		if sec.Type != PlexMusicSectionType {
+			return fmt.Errorf("library section %q (%s) is a %s library, not a music library; music libraries on this server: %s",
+				ref, sec.Label(), sec.Type, describeMusicSections(all))
+		}
+		if !seen[sec.ID] {
+			seen[sec.ID] = true
+			out = append(out, sec)
+		}
+	}
+	c.sections = out
+	c.sectionID = out[0].ID
+	return nil
+}
+
+// findLibrarySection finds ref among sections by ID, or by title ignoring case.
+func findLibrarySection(sections []LibrarySection, ref string) (LibrarySection, bool) {
+	ref = strings.TrimSpace(ref)
+	if id, err := strconv.Atoi(ref); err == nil {
+		for _, s := range sections {
+			if s.ID == id {
+				return s, true
+			}
+		}
+		return LibrarySection{}, false
+	}
+	for _, s := range sections {
+		if strings.EqualFold(strings.TrimSpace(s.Title), ref) {
+			return s, true
+		}
+	}
+	return LibrarySection{}, false
+}
+
+func describeMusicSections(sections []LibrarySection) string {
+	var parts []string
+	for _, s := range sections {
+		if s.Type == PlexMusicSectionType {
+			parts = append(parts, fmt.Sprintf("%d (%s)", s.ID, s.Label()))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// LibrarySections returns the music library sections searched, in priority order.
+func (c *Client) LibrarySections() []LibrarySection {
+	if len(c.sections) > 0 {
+		return c.sections
+	}
+	return []LibrarySection{{ID: c.sectionID}}
+}
+
+// eachLibrarySection runs search against each library section in priority order and returns the first
+// match. A transient failure in one section does not stop the others; it is returned only when no section
+// matched.
+func (c *Client) eachLibrarySection(ctx context.Context, search func(ctx context.Context) (*PlexTrack, error)) (*PlexTrack, error) {
+	var firstErr error
+	for _, sec := range c.LibrarySections() {
+		tr, err := search(withLibrarySection(ctx, sec))
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, errHTTPCallBudget) || !isTransientPlexErr(err) {
+				return nil, err
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if tr != nil {
+			return tr, nil
+		}
+	}
+	return nil, firstErr
+}
+
+// currentLibrarySection is the section the current search step queries: the one set by
+// eachLibrarySection, else the first configured section.
+func (c *Client) currentLibrarySection(ctx context.Context) LibrarySection {
+	if sec, ok := ctx.Value(librarySectionKey{}).(LibrarySection); ok {
+		return sec
+	}
+	return c.LibrarySections()[0]
+}
+
+type librarySectionKey struct{}
+
+func withLibrarySection(ctx context.Context, sec LibrarySection) context.Context {
+	return context.WithValue(ctx, librarySectionKey{}, sec)
+}
+
+// stampLibrarySection records the queried section on tracks that Plex returned without one.
+func stampLibrarySection(sec LibrarySection, tracks []PlexTrack) {
+	for i := range tracks {
+		if tracks[i].LibrarySectionID == 0 {
+			tracks[i].LibrarySectionID = sec.ID
+		}
+		if tracks[i].LibrarySectionTitle == "" && tracks[i].LibrarySectionID == sec.ID {
+			tracks[i].LibrarySectionTitle = sec.Title
+		}
+	}
+}
+
+// SectionLabel names the library section the track was found in, or "" when unknown.
+func (t PlexTrack) SectionLabel() string {
+	if t.LibrarySectionID == 0 && strings.TrimSpace(t.LibrarySectionTitle) == "" {
+		return ""
+	}
+	return LibrarySection{ID: t.LibrarySectionID, Title: t.LibrarySectionTitle}.Label()
+}
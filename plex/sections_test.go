@@ -0,0 +1,164 @@
+package plex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/track"
+)
+
+const librarySectionsXML = `<MediaContainer>
+	<Directory key="1" type="movie" title="Films"/>
+	<Directory key="3" type="artist" title="Music"/>
+	<Directory key="7" type="artist" title="Music (Hi-Res)"/>
+	<Directory key="9" type="artist" title="Soundtracks"/>
+</MediaContainer>`
+
+// multiSectionServer serves the section list and finds "Song" by Band only in section 7.
+func multiSectionServer(t *testing.T, paths *[]string) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*paths = append(*paths, r.URL.Path)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/library/sections":
+			_, _ = fmt.Fprint(w, librarySectionsXML)
+		case "/library/sections/7/search":
+			_, _ = fmt.Fprint(w, `<MediaContainer>
+				<Track ratingKey="70" title="Song" grandparentTitle="Band" parentTitle="Debut"/>
+			</MediaContainer>`)
+		default:
+			_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+		}
+	}))
+}
+
+func TestResolveLibrarySections(t *testing.T) {
+	t.Parallel()
+	var paths []string
+	ts := multiSectionServer(t, &paths)
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{
+		URL: ts.URL, Token: "tok", LibrarySections: []string{"music (hi-res)", "3", "Music (Hi-Res)"},
+	}})
+	if err := c.ResolveLibrarySections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got := c.LibrarySections()
+	if len(got) != 2 || got[0].ID != 7 || got[0].Title != "Music (Hi-Res)" || got[1].ID != 3 {
+		t.Fatalf("sections = %+v", got)
+	}
+	if c.sectionID != 7 {
+		t.Errorf("sectionID = %d, want the first section", c.sectionID)
+	}
+
+	for ref, want := range map[string]string{"Podcasts": "not found", "Films": "not a music library"} {
+		c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySections: []string{ref}}})
+		if err := c.ResolveLibrarySections(context.Background()); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", ref, err, want)
+		}
+	}
+}
+
+func TestResolveLibrarySections_validatesSingleID(t *testing.T) {
+	t.Parallel()
+	var paths []string
+	ts := multiSectionServer(t, &paths)
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 3, LibrarySections: []string{"3"}}})
+	if err := c.ResolveLibrarySections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.LibrarySections(); len(got) != 1 || got[0].Title != "Music" {
+		t.Errorf("sections = %+v", got)
+	}
+
+	// A stale ID and a movie section both fail with the music sections listed.
+	for _, id := range []string{"4", "1"} {
+		c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySections: []string{id}}})
+		err := c.ResolveLibrarySections(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "3 (Music), 7 (Music (Hi-Res)), 9 (Soundtracks)") {
+			t.Errorf("section %s: err = %v", id, err)
+		}
+	}
+}
+
+func TestResolveLibrarySections_autoSelect(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name, sections, wantErr string
+		wantID                  int
+	}{
+		{"one music library", `<Directory key="1" type="movie" title="Films"/><Directory key="4" type="artist" title="Music"/>`, "", 4},
+		{"none", `<Directory key="1" type="movie" title="Films"/>`, "no music library", 0},
+		{"several", `<Directory key="3" type="artist" title="Music"/><Directory key="7" type="artist" title="Music (Hi-Res)"/>`, "set PLEX_LIBRARY_SECTION_ID", 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintf(w, "<MediaContainer>%s</MediaContainer>", tc.sections)
+			}))
+			defer ts.Close()
+			c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok"}})
+			err := c.ResolveLibrarySections(context.Background())
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil || c.sectionID != tc.wantID {
+				t.Fatalf("sectionID = %d, err = %v; want %d", c.sectionID, err, tc.wantID)
+			}
+		})
+	}
+}
+
+func TestSearchTrack_searchesSectionsInPriorityOrder(t *testing.T) {
+	t.Parallel()
+	var paths []string
+	ts := multiSectionServer(t, &paths)
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{
+		URL: ts.URL, Token: "tok", LibrarySectionID: 3, LibrarySections: []string{"3", "Music (Hi-Res)"}, SkipFullLibrarySearch: true,
+	}})
+	if err := c.ResolveLibrarySections(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	tr, _, err := c.SearchTrack(context.Background(), track.Track{Name: "Song", Artist: "Band"})
+	if err != nil || tr == nil || tr.ID != "70" {
+		t.Fatalf("SearchTrack = %v, %v; want rating key 70", tr, err)
+	}
+	if tr.SectionLabel() != "Music (Hi-Res)" || tr.LibrarySectionID != 7 {
+		t.Errorf("section = %d %q, want 7 Music (Hi-Res)", tr.LibrarySectionID, tr.SectionLabel())
+	}
+	// The first strategy asks section 3 before section 7 and stops there.
+	want := []string{"/library/sections", "/library/sections/3/search", "/library/sections/7/search"}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("requests = %v, want %v", paths, want)
+	}
+}
+
+func TestNumericLibrarySections(t *testing.T) {
+	t.Parallel()
+	if got := numericLibrarySections([]string{"3", "7"}, 3); len(got) != 2 || got[1].ID != 7 {
+		t.Errorf("ids = %+v", got)
+	}
+	if got := numericLibrarySections([]string{"3", "Soundtracks"}, 3); got != nil {
+		t.Errorf("with a name = %+v, want nil until resolved", got)
+	}
+	if got := numericLibrarySections(nil, 5); len(got) != 1 || got[0].ID != 5 {
+		t.Errorf("fallback = %+v", got)
+	}
+}
@@ -0,0 +1,242 @@
+package plex
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+// Scorer compares two strings and returns a similarity in [0, 1], where 1 means identical. Callers pass
+// strings that are already lowercased and normalized; a Scorer must be safe for concurrent use.
+type Scorer interface {
+	// Name is the PLEXIFY_SIMILARITY_ALGORITHM value that selects this scorer.
+	Name() string
+	Similarity(a, b string) float64
+}
+
+// NewScorer returns the Scorer for a PLEXIFY_SIMILARITY_ALGORITHM value (empty selects the default heuristic).
+func NewScorer(name string) (Scorer, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", config.SimilarityHeuristic:
+		return heuristicScorer{}, nil
+	case config.SimilarityJaroWinkler:
+		return jaroWinklerScorer{}, nil
+	case config.SimilarityTokenSet:
+		return tokenSetScorer{}, nil
+	case config.SimilarityLevenshtein:
+		return levenshteinScorer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown similarity algorithm %q", name)
+	}
+}
+
+// SetScorer replaces the string similarity used for title, artist and album scoring (nil restores the default).
+func (c *Client) SetScorer(s Scorer) {
+	c.scorer = s
+}
+
+func (c *Client) similarityScorer() Scorer {
+	if c.scorer == nil {
+		return heuristicScorer{}
+	}
+	return c.scorer
+}
+
+// heuristicScorer is the original plexify similarity: substring containment scores by length ratio,
+// otherwise a blend of whole-word overlap (70%) and length similarity (30%).
+type heuristicScorer struct{}
+
+func (heuristicScorer) Name() string { return config.SimilarityHeuristic }
+
+func (heuristicScorer) Similarity(s1, s2 string) float64 {
+	if s1 == s2 {
+		return 1.0
+	}
+
+	if s1 == "" || s2 == "" {
+		return 0.0
+	}
+
+	if strings.Contains(s1, s2) || strings.Contains(s2, s1) {
+		longer := s1
+		shorter := s2
+		if len(s2) > len(s1) {
+			longer = s2
+			shorter = s1
+		}
+		return float64(len(shorter)) / float64(len(longer))
+	}
+
+	words1 := strings.Fields(s1)
+	words2 := strings.Fields(s2)
+
+	if len(words1) == 0 || len(words2) == 0 {
+		return 0.0
+	}
+
+	matchingWords := 0
+	for _, word1 := range words1 {
+		for _, word2 := range words2 {
+			if word1 == word2 {
+				matchingWords++
+				break
+			}
+		}
+	}
+
+	wordSimilarity := float64(matchingWords) / float64(max(len(words1), len(words2)))
+	lengthSimilarity := 1.0 - float64(intAbs(len(s1)-len(s2)))/float64(max(len(s1), len(s2)))
+
+	return (wordSimilarity * 0.7) + (lengthSimilarity * 0.3)
+}
+
+// levenshteinScorer is 1 − edit distance / longer length, over runes. Tolerant of typos; strict about word order.
+type levenshteinScorer struct{}
+
+func (levenshteinScorer) Name() string { return config.SimilarityLevenshtein }
+
+func (levenshteinScorer) Similarity(a, b string) float64 {
+	return levenshteinRatio([]rune(a), []rune(b))
+}
+
+func levenshteinRatio(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1.0
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0.0
+	}
+	return 1.0 - float64(levenshteinDistance(a, b))/float64(max(len(a), len(b)))
+}
+
+func levenshteinDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// jaroWinklerScorer is Jaro similarity with the Winkler common-prefix boost (scale 0.1, up to 4 runes).
+// Good at short strings and typos; rewards shared beginnings, so truncated titles still score well.
+type jaroWinklerScorer struct{}
+
+func (jaroWinklerScorer) Name() string { return config.SimilarityJaroWinkler }
+
+func (jaroWinklerScorer) Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	j := jaro(ra, rb)
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return j + float64(prefix)*0.1*(1-j)
+}
+
+func jaro(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1.0
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0.0
+	}
+	window := max(len(a), len(b))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	aMatched := make([]bool, len(a))
+	bMatched := make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo, hi := max(0, i-window), min(len(b)-1, i+window)
+		for j := lo; j <= hi; j++ {
+			if !bMatched[j] && a[i] == b[j] {
+				aMatched[i], bMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0.0
+	}
+	transpositions := 0
+	k := 0
+	for i := range a {
+		if !aMatched[i] {
+			continue
+		}
+		for !bMatched[k] {
+			k++
+		}
+		if a[i] != b[k] {
+			transpositions++
+		}
+		k++
+	}
+	m := float64(matches)
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions/2))/m) / 3
+}
+
+// tokenSetScorer is a token-set ratio: words shared by both sides are sorted first, each side's remaining
+// words follow, and the two strings are compared with Levenshtein ratio. Word order does not matter, but
+// every word only one side has (including a repeat) lowers the score, so "love" vs "love love love" or
+// "halo" vs "halo remix" are not treated as identical.
+type tokenSetScorer struct{}
+
+func (tokenSetScorer) Name() string { return config.SimilarityTokenSet }
+
+func (tokenSetScorer) Similarity(a, b string) float64 {
+	ta, tb := tokenCounts(a), tokenCounts(b)
+	if len(ta) == 0 && len(tb) == 0 {
+		return 1.0
+	}
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0.0
+	}
+	var common, onlyA, onlyB []string
+	for w, n := range ta {
+		shared := min(n, tb[w])
+		for range shared {
+			common = append(common, w)
+		}
+		for range n - shared {
+			onlyA = append(onlyA, w)
+		}
+	}
+	for w, n := range tb {
+		for range n - min(n, ta[w]) {
+			onlyB = append(onlyB, w)
+		}
+	}
+	sort.Strings(common)
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+
+	base := strings.Join(common, " ")
+	withA := strings.TrimSpace(base + " " + strings.Join(onlyA, " "))
+	withB := strings.TrimSpace(base + " " + strings.Join(onlyB, " "))
+	return levenshteinRatio([]rune(withA), []rune(withB))
+}
+
+func tokenCounts(s string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range strings.Fields(s) {
+		counts[w]++
+	}
+	return counts
+}
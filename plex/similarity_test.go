@@ -0,0 +1,149 @@
+package plex
+
+import (
+	"math"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+)
+
+// labeledSimilarityPairs are lowercased source/Plex strings hand-labeled as the same song (match) or not.
+// TestScorers_labeledPairs reports each scorer's accuracy on them; extend the table with real mismatches
+// before changing the default algorithm.
+var labeledSimilarityPairs = []struct {
+	a, b  string
+	match bool
+}{
+	{"don't stop believin'", "don't stop believing", true},
+	{"dont stop believin", "dont stop beleivin", true},
+	{"mr. brightside", "mr brightside", true},
+	{"smells like teen spirit", "smells like teen spirt", true},
+	{"love love love", "love", false},
+	{"love", "love me do", false},
+	{"hey jude", "hey jude - remastered 2015", true},
+	{"bohemian rhapsody", "bohemian rhapsody (live aid)", true},
+	{"the beatles", "beatles", true},
+	{"sigur rós", "sigur ros", true},
+	{"one", "one more time", false},
+	{"yesterday", "yesterday once more", false},
+	{"hello", "hallo", false},
+	{"paranoid android", "karma police", false},
+	{"blue monday", "monday monday", false},
+	{"kid a", "kid a mnesia", true},
+	{"everything in its right place", "everything in it's right place", true},
+}
+
+func allScorers(t *testing.T) []Scorer {
+	t.Helper()
+	var out []Scorer
+	for _, name := range []string{config.SimilarityHeuristic, config.SimilarityJaroWinkler, config.SimilarityTokenSet, config.SimilarityLevenshtein} {
+		s, err := NewScorer(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Name() != name {
+			t.Fatalf("NewScorer(%q).Name() = %q", name, s.Name())
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestNewScorer_unknown(t *testing.T) {
+	t.Parallel()
+	if _, err := NewScorer("cosine"); err == nil {
+		t.Fatal("expected error for unknown algorithm")
+	}
+	if s, err := NewScorer(""); err != nil || s.Name() != config.SimilarityHeuristic {
+		t.Fatalf("empty name: %v %v", s, err)
+	}
+}
+
+func TestScorers_properties(t *testing.T) {
+	t.Parallel()
+	for _, s := range allScorers(t) {
+		if got := s.Similarity("hey jude", "hey jude"); got != 1 {
+			t.Errorf("%s: identical = %g, want 1", s.Name(), got)
+		}
+		if got := s.Similarity("hey jude", ""); got != 0 {
+			t.Errorf("%s: empty = %g, want 0", s.Name(), got)
+		}
+		for _, p := range labeledSimilarityPairs {
+			ab, ba := s.Similarity(p.a, p.b), s.Similarity(p.b, p.a)
+			if ab < 0 || ab > 1 || math.IsNaN(ab) {
+				t.Errorf("%s(%q, %q) = %g, out of range", s.Name(), p.a, p.b, ab)
+			}
+			// The legacy heuristic counts repeated words per side, so it is not symmetric; kept as-is for compatibility.
+			if s.Name() != config.SimilarityHeuristic && math.Abs(ab-ba) > 1e-9 {
+				t.Errorf("%s not symmetric for %q/%q: %g vs %g", s.Name(), p.a, p.b, ab, ba)
+			}
+		}
+	}
+}
+
+func TestScorers_knownValues(t *testing.T) {
+	t.Parallel()
+	// Classic Jaro-Winkler reference pair.
+	if got := (jaroWinklerScorer{}).Similarity("martha", "marhta"); math.Abs(got-0.9611) > 0.001 {
+		t.Errorf("jaro-winkler martha/marhta = %g, want ~0.9611", got)
+	}
+	if got := (levenshteinScorer{}).Similarity("kitten", "sitting"); math.Abs(got-(1-3.0/7)) > 1e-9 {
+		t.Errorf("levenshtein kitten/sitting = %g", got)
+	}
+	for _, pair := range [][2]string{{"love love love", "love"}, {"halo remix", "halo"}} {
+		if got := (tokenSetScorer{}).Similarity(pair[0], pair[1]); got >= 0.5 {
+			t.Errorf("token-set %q/%q = %g, want words only one side has to lower the score", pair[0], pair[1], got)
+		}
+	}
+	if got := (tokenSetScorer{}).Similarity("jude hey", "hey jude"); got != 1 {
+		t.Errorf("token-set ignores order: got %g", got)
+	}
+	// A one-letter typo should score higher under edit-distance scorers than under the word-overlap heuristic.
+	h := (heuristicScorer{}).Similarity("smells like teen spirit", "smells like teen spirt")
+	if l := (levenshteinScorer{}).Similarity("smells like teen spirit", "smells like teen spirt"); l <= h {
+		t.Errorf("levenshtein typo %g not above heuristic %g", l, h)
+	}
+}
+
+func TestScorers_labeledPairs(t *testing.T) {
+	t.Parallel()
+	const threshold = 0.8
+	for _, s := range allScorers(t) {
+		correct := 0
+		for _, p := range labeledSimilarityPairs {
+			if (s.Similarity(p.a, p.b) >= threshold) == p.match {
+				correct++
+			}
+		}
+		t.Logf("%-12s accuracy %d/%d at %.2f", s.Name(), correct, len(labeledSimilarityPairs), threshold)
+	}
+}
+
+func TestCalculateStringSimilarity_usesConfiguredScorer(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	if got := c.calculateStringSimilarity("love love love", "love"); got == 1 {
+		t.Fatal("default heuristic should not treat repetition as identical")
+	}
+	s, err := NewScorer(config.SimilarityTokenSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetScorer(s)
+	if got := c.calculateStringSimilarity("jude hey", "hey jude"); got != 1 {
+		t.Fatalf("token-set scorer: got %g, want 1", got)
+	}
+}
+
+func BenchmarkScorers(b *testing.B) {
+	for _, name := range []string{config.SimilarityHeuristic, config.SimilarityJaroWinkler, config.SimilarityTokenSet, config.SimilarityLevenshtein} {
+		s, _ := NewScorer(name)
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				for _, p := range labeledSimilarityPairs {
+					s.Similarity(p.a, p.b)
+				}
+			}
+		})
+	}
+}
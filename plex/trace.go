@@ -0,0 +1,299 @@
+package plex
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/grrywlsn/plexify/internal/cliutil"
+	"github.com/grrywlsn/plexify/track"
+)
+
+// traceTopCandidates caps how many scored candidates each trace step keeps.
+const traceTopCandidates = 5
+
+// MatchTrace is a structured record of how SearchTrack reached its decision for one source track. Unlike
+// debug logging it is collected per track, so it stays readable with PLEX_MATCH_CONCURRENCY > 1.
+type MatchTrace struct {
+	Override string        `json:"override,omitempty"` // overrides file key that short-circuited the search
+	Steps    []TraceStep   `json:"steps"`
+	Decision TraceDecision `json:"decision"`
+}
+
+// TraceStep is one strategy run for one artist candidate in one search phase (or the full-library scan).
+type TraceStep struct {
+	Artist     string           `json:"artist"`
+	Strategy   string           `json:"strategy"`
+	Phase      string           `json:"phase"`
+	Queries    []string         `json:"queries,omitempty"`    // Plex requests sent (path and query, token removed)
+	Candidates []TraceCandidate `json:"candidates,omitempty"` // best-scoring candidates, highest first
+	Picked     string           `json:"picked,omitempty"`     // rating key accepted by this step
+	Reason     string           `json:"reason,omitempty"`
+}
+
+// TraceScores are the 0–1 sub-scores behind a candidate's combined score. Duration is informational
+// (not weighted); it is 0 when either side has no duration.
+type TraceScores struct {
+	Title          float64 `json:"title"`
+	Artist         float64 `json:"artist"`
+	Album          float64 `json:"album"`
+	Duration       float64 `json:"duration"`
+	VersionPenalty float64 `json:"version_penalty,omitempty"`
+	Total          float64 `json:"total"`
+}
+
+// TraceCandidate is a Plex track that was scored during a step.
+type TraceCandidate struct {
+	RatingKey  string      `json:"rating_key"`
+	Title      string      `json:"title"`
+	Artist     string      `json:"artist"`
+	Album      string      `json:"album,omitempty"`
+	DurationMs int         `json:"duration_ms,omitempty"`
+	Quality    string      `json:"quality,omitempty"` // e.g. "flac 24-bit 1411 kbps"; empty when Plex sent no Media
+	Section    string      `json:"section,omitempty"` // library section title, when known
+	Scores     TraceScores `json:"scores"`
+}
+
+// TraceDecision is the final outcome recorded on MatchResult.
+type TraceDecision struct {
+	MatchType  MatchKind    `json:"match_type"`
+	RatingKey  string       `json:"rating_key,omitempty"`
+	Title      string       `json:"title,omitempty"`
+	Artist     string       `json:"artist,omitempty"`
+	Album      string       `json:"album,omitempty"`
+	Section    string       `json:"section,omitempty"`
+	Confidence float64      `json:"confidence"`
+	Scores     *TraceScores `json:"scores,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// SetTraceMatches enables recording a MatchTrace on each MatchResult from MatchSourceTracks. Off by
+// default: full-library scans score every track, and keeping their sub-scores is not free.
+func (c *Client) SetTraceMatches(on bool) {
+	c.traceMatches = on
+}
+
+// matchTracer collects a MatchTrace. Methods are nil-safe so callers need not check whether tracing is on.
+type matchTracer struct {
+	mu               sync.Mutex
+	trace            MatchTrace
+	cur              int // index of the open step in trace.Steps, or -1
+	sourceDurationMs int
+}
+
+func newMatchTracer(song track.Track) *matchTracer {
+	return &matchTracer{cur: -1, sourceDurationMs: song.Duration}
+}
+
+func (t *matchTracer) sourceDuration() int {
+	if t == nil {
+		return 0
+	}
+	return t.sourceDurationMs
+}
+
+// beginStep opens a new step; queries and candidates recorded afterwards attach to it.
+func (t *matchTracer) beginStep(artist, strategy, phase string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trace.Steps = append(t.trace.Steps, TraceStep{Artist: artist, Strategy: strategy, Phase: phase})
+	t.cur = len(t.trace.Steps) - 1
+}
+
+// endStep closes the open step, dropping it when the strategy did not apply (no request, nothing scored).
+func (t *matchTracer) endStep() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cur < 0 {
+		return
+	}
+	if st := t.trace.Steps[t.cur]; len(st.Queries) == 0 && len(st.Candidates) == 0 && st.Picked == "" {
+		t.trace.Steps = t.trace.Steps[:t.cur]
+	}
+	t.cur = -1
+}
+
+func (t *matchTracer) noteOverride(key string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trace.Override = key
+}
+
+func (t *matchTracer) recordRequest(req *http.Request) {
+	if t == nil || req == nil || req.URL == nil {
+		return
+	}
+	q := req.URL.Query()
+	q.Del("X-Plex-Token")
+	s := req.URL.Path
+	if enc := q.Encode(); enc != "" {
+		s += "?" + enc
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cur >= 0 {
+		t.trace.Steps[t.cur].Queries = append(t.trace.Steps[t.cur].Queries, s)
+	}
+}
+
+// recordCandidates merges scored candidates into the open step (keeping each rating key's best score and
+// the top traceTopCandidates overall) and records the pick, if any.
+func (t *matchTracer) recordCandidates(cands []TraceCandidate, picked *PlexTrack, reason string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cur < 0 {
+		return
+	}
+	st := &t.trace.Steps[t.cur]
+	merged := append([]TraceCandidate(nil), st.Candidates...)
+	for _, c := range cands {
+		replaced := false
+		for i := range merged {
+			if merged[i].RatingKey == c.RatingKey && c.RatingKey != "" {
+				if c.Scores.Total > merged[i].Scores.Total {
+					merged[i] = c
+				}
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, c)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Scores.Total > merged[j].Scores.Total })
+	if len(merged) > traceTopCandidates {
+		merged = merged[:traceTopCandidates]
+	}
+	st.Candidates = merged
+	if picked != nil {
+		st.Picked = picked.ID
+	}
+	if reason != "" {
+		st.Reason = reason
+	}
+}
+
+func (t *matchTracer) finish(d TraceDecision) *MatchTrace {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.trace.Decision = d
+	out := t.trace
+	return &out
+}
+
+func newTraceCandidate(tr PlexTrack, scores TraceScores) TraceCandidate {
+	return TraceCandidate{
+		RatingKey:  tr.ID,
+		Title:      tr.Title,
+		Artist:     tr.DisplayArtist(),
+		Album:      tr.Album,
+		DurationMs: tr.Duration,
+		Quality:    tr.Quality().String(),
+		Section:    tr.LibrarySectionTitle,
+		Scores:     scores,
+	}
+}
+
+// durationSimilarity is 1 − |a−b| / max(a, b) for two millisecond durations, or 0 when either is unknown.
+func durationSimilarity(a, b int) float64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	return 1 - math.Abs(float64(a-b))/float64(max(a, b))
+}
+
+// FprintMatchTrace writes a human-readable explanation of one match result.
+func FprintMatchTrace(w io.Writer, r MatchResult) {
+	st := r.SourceTrack
+	fmt.Fprintln(w, "")
+	fmt.Fprintf(w, "EXPLAIN — %s - %s", st.Artist, st.Name)
+	if st.Album != "" {
+		fmt.Fprintf(w, " (%s)", st.Album)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, cliutil.RepeatChar("-", cliutil.SectionWidth))
+	tr := r.Trace
+	if tr == nil {
+		fmt.Fprintln(w, "(no trace recorded)")
+		return
+	}
+	if tr.Override != "" {
+		fmt.Fprintf(w, "Override: %s\n", tr.Override)
+	}
+	for i, step := range tr.Steps {
+		fmt.Fprintf(w, "%2d. %s [%s] artist %q\n", i+1, step.Strategy, step.Phase, step.Artist)
+		for _, q := range step.Queries {
+			if u, err := url.QueryUnescape(q); err == nil {
+				q = u
+			}
+			fmt.Fprintf(w, "      GET %s\n", q)
+		}
+		for _, c := range step.Candidates {
+			mark := " "
+			if c.RatingKey != "" && c.RatingKey == step.Picked {
+				mark = "✔"
+			}
+			fmt.Fprintf(w, "    %s %s  %s - %s", mark, formatConfidencePercent(c.Scores.Total), c.Artist, c.Title)
+			if c.Album != "" {
+				fmt.Fprintf(w, " (%s)", c.Album)
+			}
+			if c.Quality != "" {
+				fmt.Fprintf(w, " {%s}", c.Quality)
+			}
+			if c.Section != "" {
+				fmt.Fprintf(w, " in %q", c.Section)
+			}
+			fmt.Fprintf(w, " [key %s]\n", c.RatingKey)
+			fmt.Fprintf(w, "        title %s, artist %s, album %s, duration %s",
+				formatConfidencePercent(c.Scores.Title), formatConfidencePercent(c.Scores.Artist),
+				formatConfidencePercent(c.Scores.Album), formatConfidencePercent(c.Scores.Duration))
+			if c.Scores.VersionPenalty > 0 {
+				fmt.Fprintf(w, ", version penalty -%s", formatConfidencePercent(c.Scores.VersionPenalty))
+			}
+			fmt.Fprintln(w)
+		}
+		if step.Reason != "" {
+			fmt.Fprintf(w, "    → %s\n", step.Reason)
+		}
+	}
+	d := tr.Decision
+	fmt.Fprintf(w, "Decision: %s", d.MatchType)
+	if d.RatingKey != "" {
+		fmt.Fprintf(w, " → %s - %s", d.Artist, d.Title)
+		if d.Album != "" {
+			fmt.Fprintf(w, " (%s)", d.Album)
+		}
+		if d.Section != "" {
+			fmt.Fprintf(w, " in %q", d.Section)
+		}
+		fmt.Fprintf(w, " [key %s], confidence %s", d.RatingKey, formatConfidencePercent(d.Confidence))
+	}
+	if d.Error != "" {
+		fmt.Fprintf(w, " (error: %s)", d.Error)
+	}
+	fmt.Fprintln(w)
+	if strings.TrimSpace(d.Error) == "" && d.RatingKey == "" && len(tr.Steps) == 0 && tr.Override == "" {
+		fmt.Fprintln(w, "(no search steps ran)")
+	}
+}
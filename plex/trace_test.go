@@ -0,0 +1,83 @@
+package plex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grrywlsn/plexify/config"
+	"github.com/grrywlsn/plexify/track"
+)
+
+func TestMatchSourceTracks_trace(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") == "Song (Live) Band" {
+			_, _ = fmt.Fprint(w, `<MediaContainer>`+
+				`<Track ratingKey="1" title="Song" grandparentTitle="Band" parentTitle="Record" duration="200000"/>`+
+				`<Track ratingKey="2" title="Song (Live)" grandparentTitle="Band" parentTitle="Tour" duration="230000"/>`+
+				`</MediaContainer>`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "secret", LibrarySectionID: 1, SkipFullLibrarySearch: true}})
+	c.SetTraceMatches(true)
+
+	song := track.Track{Name: "Song (Live)", Artist: "Band", Duration: 231000}
+	results := c.MatchSourceTracks(context.Background(), []track.Track{song})
+	r := results[0]
+	if r.PlexTrack == nil || r.PlexTrack.ID != "2" {
+		t.Fatalf("expected live version, got %+v", r.PlexTrack)
+	}
+	tr := r.Trace
+	if tr == nil || len(tr.Steps) == 0 {
+		t.Fatalf("expected trace steps, got %+v", tr)
+	}
+	step := tr.Steps[0]
+	if step.Strategy != "exact title/artist" || step.Phase != searchPhaseCombined.tierLabel() || step.Artist != "Band" {
+		t.Errorf("step = %+v", step)
+	}
+	if len(step.Queries) != 1 || strings.Contains(step.Queries[0], "secret") || !strings.Contains(step.Queries[0], "/library/sections/1/search") {
+		t.Errorf("queries = %v", step.Queries)
+	}
+	if len(step.Candidates) == 0 || step.Candidates[0].RatingKey != "2" || step.Picked != "2" || !strings.Contains(step.Reason, "exact") {
+		t.Fatalf("candidates = %+v picked %q reason %q", step.Candidates, step.Picked, step.Reason)
+	}
+	if sc := step.Candidates[0].Scores; sc.Duration < 0.99 || sc.VersionPenalty != 0 {
+		t.Errorf("live candidate scores = %+v", sc)
+	}
+	d := tr.Decision
+	if d.MatchType != MatchTypeTitleArtist || d.RatingKey != "2" || d.Scores == nil || d.Confidence != r.Confidence {
+		t.Errorf("decision = %+v", d)
+	}
+
+	var buf bytes.Buffer
+	FprintMatchTrace(&buf, r)
+	out := buf.String()
+	for _, want := range []string{"EXPLAIN — Band - Song (Live)", "exact title/artist", "✔", "query=Song (Live) Band", "Decision: title_artist"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("explain output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMatchSourceTracks_noTraceByDefault(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `<MediaContainer/>`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(&config.Config{Plex: config.PlexConfig{URL: ts.URL, Token: "tok", LibrarySectionID: 1, SkipFullLibrarySearch: true}})
+	results := c.MatchSourceTracks(context.Background(), []track.Track{{Name: "Song", Artist: "Band"}})
+	if results[0].Trace != nil {
+		t.Fatalf("expected no trace, got %+v", results[0].Trace)
+	}
+}
@@ -0,0 +1,10 @@
+package plex
+
+import "github.com/grrywlsn/plexify/textnorm"
+
+// transliterate folds fullwidth ASCII and romanizes Cyrillic, Greek, Japanese kana and Hangul so a
+// native-script Plex tag can be compared with a romanized streaming title (and vice versa). Latin
+// diacritics are folded too. See textnorm.Transliterate.
+func (c *Client) transliterate(s string) string {
+	return textnorm.Transliterate(s)
+}
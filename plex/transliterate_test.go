@@ -0,0 +1,64 @@
+package plex
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	tests := []struct{ in, want string }{
+		{"Hello", "Hello"},
+		{"Кино", "Kino"},
+		{"Группа крови", "Gruppa krovi"},
+		{"Земфира", "Zemfira"},
+		{"Океан Ельзи", "Okean Elzi"},
+		{"Μουσική", "Mousiki"},
+		{"Άλκηστις Πρωτοψάλτη", "Alkistis Protopsalti"},
+		{"ヨルシカ", "yorushika"},
+		{"きゃりーぱみゅぱみゅ", "kyariipamyupamyu"},
+		{"ちょっと", "chotto"},
+		{"マッチ", "matchi"},
+		{"ロックンロール", "rokkunrooru"},
+		{"ファンタジー", "fantajii"},
+		{"방탄소년단", "bangtansonyeondan"},
+		{"한국어", "hangugeo"},
+		{"ＹＯＡＳＯＢＩ", "YOASOBI"},
+		{"夜に駆ける", "夜ni駆keru"},
+		{"Beyoncé", "Beyonce"},
+	}
+	for _, tt := range tests {
+		if got := c.transliterate(tt.in); got != tt.want {
+			t.Errorf("transliterate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFindBestMatch_transliteratedScripts(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	native := []PlexTrack{
+		{ID: "1", Title: "Группа крови", Artist: "Кино"},
+		{ID: "2", Title: "Звезда по имени Солнце", Artist: "Кино"},
+	}
+	if got := c.FindBestMatch(native, "Gruppa Krovi", "Kino", ""); got == nil || got.ID != "1" {
+		t.Fatalf("romanized source vs Cyrillic Plex: got %v", got)
+	}
+
+	romanized := []PlexTrack{{ID: "3", Title: "Fake Love", Artist: "Bangtansonyeondan"}}
+	if got := c.FindBestMatch(romanized, "Fake Love", "방탄소년단", ""); got == nil || got.ID != "3" {
+		t.Fatalf("Hangul source vs romanized Plex: got %v", got)
+	}
+
+	fullwidth := []PlexTrack{{ID: "4", Title: "Idol", Artist: "YOASOBI"}}
+	if got := c.FindBestMatch(fullwidth, "Idol", "ＹＯＡＳＯＢＩ", ""); got == nil || got.ID != "4" {
+		t.Fatalf("fullwidth source vs ASCII Plex: got %v", got)
+	}
+}
+
+func TestIndexedTrackSearchStrategies_transliteratedLast(t *testing.T) {
+	t.Parallel()
+	c := &Client{}
+	strategies := c.indexedTrackSearchStrategies()
+	if got := strategies[len(strategies)-1].name; got != "transliterated" {
+		t.Fatalf("last strategy = %q, want transliterated", got)
+	}
+}
@@ -0,0 +1,88 @@
+// Package plextv calls the plex.tv account API: the PIN sign-in flow that yields an account token, and the
+// resources (servers) that account can reach. The base URL is configurable (PLEX_TV_URL) so the flow can
+// run against a local fake.
+package plextv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Product is sent as X-Plex-Product and is the device name shown under Authorized Devices on plex.tv.
+const Product = "plexify"
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// Client calls plex.tv. clientID (X-Plex-Client-Identifier) should be stable across runs so repeated
+// logins reuse one authorized device.
+type Client struct {
+	base     string
+	clientID string
+	http     *http.Client
+}
+
+// NewClient returns a client for the plex.tv API at baseURL (e.g. https://plex.tv).
+func NewClient(baseURL, clientID string) (*Client, error) {
+	base := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("parse plex.tv URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("plex.tv URL must be an absolute http(s) URL")
+	}
+	if strings.TrimSpace(clientID) == "" {
+		return nil, fmt.Errorf("client identifier is required")
+	}
+	return &Client{
+		base:     base,
+		clientID: strings.TrimSpace(clientID),
+		http:     &http.Client{Timeout: defaultHTTPTimeout},
+	}, nil
+}
+
+// LinkURL is the page where a PIN code is entered (plex.tv/link).
+func (c *Client) LinkURL() string {
+	return c.base + "/link"
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, token string) (*http.Request, error) {
+	u := c.base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Plex-Product", Product)
+	req.Header.Set("X-Plex-Client-Identifier", c.clientID)
+	if token != "" {
+		req.Header.Set("X-Plex-Token", token)
+	}
+	return req, nil
+}
+
+// doJSON sends req and decodes a 2xx JSON response into out. what names the call in errors.
+func (c *Client) doJSON(req *http.Request, what string, out any) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s: %s", what, resp.Status, strings.TrimSpace(string(b)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package plextv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HomeUser is a member of the account's Plex Home (GET /api/v2/home/users).
+type HomeUser struct {
+	ID         int    `json:"id"`
+	UUID       string `json:"uuid"`
+	Title      string `json:"title"`    // display name shown in the Plex user switcher
+	Username   string `json:"username"` // empty for managed users
+	Admin      bool   `json:"admin"`
+	Restricted bool   `json:"restricted"` // managed user
+	Protected  bool   `json:"protected"`  // switching requires the user's PIN
+}
+
+// Matches reports whether name is the user's title, username or UUID (title and username ignore case).
+func (u HomeUser) Matches(name string) bool {
+	name = strings.TrimSpace(name)
+	return name != "" && (strings.EqualFold(u.Title, name) || strings.EqualFold(u.Username, name) || u.UUID == name)
+}
+
+// HomeUsers lists the Plex Home users of the account behind the admin token.
+func (c *Client) HomeUsers(ctx context.Context, token string) ([]HomeUser, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/v2/home/users", nil, token)
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Users []HomeUser `json:"users"`
+	}
+	if err := c.doJSON(req, "list home users", &doc); err != nil {
+		return nil, err
+	}
+	return doc.Users, nil
+}
+
+// SwitchHomeUser signs in as a Plex Home user (POST /api/v2/home/users/{uuid}/switch) and returns that
+// user's account token. pin is required for protected users.
+func (c *Client) SwitchHomeUser(ctx context.Context, token string, user HomeUser, pin string) (string, error) {
+	var q url.Values
+	if pin != "" {
+		q = url.Values{"pin": {pin}}
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/v2/home/users/"+url.PathEscape(user.UUID)+"/switch", q, token)
+	if err != nil {
+		return "", err
+	}
+	var doc struct {
+		AuthToken string `json:"authToken"`
+	}
+	if err := c.doJSON(req, fmt.Sprintf("switch to home user %q", user.Title), &doc); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(doc.AuthToken) == "" {
+		return "", fmt.Errorf("switch to home user %q: no token returned", user.Title)
+	}
+	return doc.AuthToken, nil
+}
+
+// ServerAccessToken returns the token the account behind token must use with the server whose machine
+// identifier is serverID. Servers shared with a home user hand out their own access token.
+func (c *Client) ServerAccessToken(ctx context.Context, token, serverID string) (string, error) {
+	servers, err := c.Servers(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	for _, s := range servers {
+		if s.ClientIdentifier == serverID {
+			if s.AccessToken != "" {
+				return s.AccessToken, nil
+			}
+			return token, nil
+		}
+	}
+	return "", fmt.Errorf("server %s is not available to this user", serverID)
+}
@@ -0,0 +1,65 @@
+package plextv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHomeUserSwitch(t *testing.T) {
+	t.Parallel()
+	tv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tok := r.Header.Get("X-Plex-Token")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/home/users" && tok == "admin":
+			_, _ = fmt.Fprint(w, `{"users": [
+				{"id": 1, "uuid": "u-admin", "title": "Dad", "username": "dad", "admin": true},
+				{"id": 2, "uuid": "u-emma", "title": "Emma", "restricted": true, "protected": true}
+			]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/home/users/u-emma/switch" && tok == "admin":
+			if r.URL.Query().Get("pin") != "1234" {
+				http.Error(w, "bad pin", http.StatusForbidden)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"authToken": "emma-account"}`)
+		case r.URL.Path == "/api/v2/resources" && tok == "emma-account":
+			_, _ = fmt.Fprint(w, `[{"name": "Home", "provides": "server", "clientIdentifier": "m1", "accessToken": "emma-server"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer tv.Close()
+
+	c, err := NewClient(tv.URL, "cid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	users, err := c.HomeUsers(ctx, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || !users[0].Admin || !users[1].Protected {
+		t.Fatalf("users = %+v", users)
+	}
+	if !users[1].Matches(" emma ") || !users[1].Matches("u-emma") || users[1].Matches("U-EMMA") || users[0].Matches("") {
+		t.Error("unexpected Matches result")
+	}
+
+	if _, err := c.SwitchHomeUser(ctx, "admin", users[1], "0000"); err == nil {
+		t.Error("expected error for wrong PIN")
+	}
+	account, err := c.SwitchHomeUser(ctx, "admin", users[1], "1234")
+	if err != nil || account != "emma-account" {
+		t.Fatalf("SwitchHomeUser = %q, %v", account, err)
+	}
+	server, err := c.ServerAccessToken(ctx, account, "m1")
+	if err != nil || server != "emma-server" {
+		t.Fatalf("ServerAccessToken = %q, %v", server, err)
+	}
+	if _, err := c.ServerAccessToken(ctx, account, "other"); err == nil {
+		t.Error("expected error for a server the user cannot access")
+	}
+}
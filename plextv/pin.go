@@ -0,0 +1,82 @@
+package plextv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPollInterval is how often WaitForToken checks whether a PIN was claimed.
+const DefaultPollInterval = 2 * time.Second
+
+// ErrPINExpired is returned by WaitForToken when the PIN expires before anyone signs in with it.
+var ErrPINExpired = errors.New("PIN expired before it was claimed")
+
+// PIN is a sign-in code from POST /api/v2/pins. AuthToken is empty until the code is claimed.
+type PIN struct {
+	ID        int       `json:"id"`
+	Code      string    `json:"code"`
+	AuthToken string    `json:"authToken"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CreatePIN requests a short code the user enters at LinkURL while signed in to Plex.
+func (c *Client) CreatePIN(ctx context.Context) (*PIN, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/v2/pins", url.Values{"strong": {"false"}}, "")
+	if err != nil {
+		return nil, err
+	}
+	var pin PIN
+	if err := c.doJSON(req, "create PIN", &pin); err != nil {
+		return nil, err
+	}
+	if pin.ID == 0 || strings.TrimSpace(pin.Code) == "" {
+		return nil, fmt.Errorf("create PIN: response has no PIN id or code")
+	}
+	return &pin, nil
+}
+
+// CheckPIN fetches the current state of a PIN (GET /api/v2/pins/{id}).
+func (c *Client) CheckPIN(ctx context.Context, id int) (*PIN, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/v2/pins/"+strconv.Itoa(id), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var pin PIN
+	if err := c.doJSON(req, "check PIN", &pin); err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// WaitForToken polls pin every interval until it is claimed and returns the account token. It stops with
+// ErrPINExpired at the PIN's expiry, or with the context's error.
+func (c *Client) WaitForToken(ctx context.Context, pin *PIN, interval time.Duration) (string, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		cur, err := c.CheckPIN(ctx, pin.ID)
+		if err != nil {
+			return "", err
+		}
+		if tok := strings.TrimSpace(cur.AuthToken); tok != "" {
+			return tok, nil
+		}
+		if !pin.ExpiresAt.IsZero() && time.Now().After(pin.ExpiresAt) {
+			return "", ErrPINExpired
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
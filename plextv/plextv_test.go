@@ -0,0 +1,112 @@
+package plextv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPINFlowAndServers(t *testing.T) {
+	t.Parallel()
+	pms := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/identity" || r.Header.Get("X-Plex-Token") != "server-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprint(w, `<MediaContainer machineIdentifier="m1"/>`)
+	}))
+	defer pms.Close()
+
+	var checks atomic.Int32
+	tv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Plex-Client-Identifier") != "cid" || r.Header.Get("X-Plex-Product") != Product {
+			http.Error(w, "missing client headers", http.StatusBadRequest)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/pins":
+			_, _ = fmt.Fprint(w, `{"id": 42, "code": "ABCD", "authToken": null, "expiresAt": "2099-01-01T00:00:00Z"}`)
+		case r.URL.Path == "/api/v2/pins/42":
+			if checks.Add(1) < 3 {
+				_, _ = fmt.Fprint(w, `{"id": 42, "code": "ABCD", "authToken": null}`)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"id": 42, "code": "ABCD", "authToken": "account-token"}`)
+		case r.URL.Path == "/api/v2/resources" && r.Header.Get("X-Plex-Token") == "account-token":
+			_, _ = fmt.Fprintf(w, `[
+				{"name": "Phone", "provides": "client,player", "clientIdentifier": "p1"},
+				{"name": "Home", "provides": "server", "clientIdentifier": "m1", "owned": true, "accessToken": "server-token",
+				 "connections": [
+					{"protocol": "https", "uri": "https://relay.invalid:8443", "relay": true},
+					{"protocol": "http", "uri": "http://127.0.0.1:1", "local": true},
+					{"protocol": "http", "uri": %q, "local": true}
+				 ]}
+			]`, pms.URL)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer tv.Close()
+
+	c, err := NewClient(tv.URL+"/", "cid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.LinkURL() != tv.URL+"/link" {
+		t.Errorf("LinkURL = %q", c.LinkURL())
+	}
+	ctx := context.Background()
+	pin, err := c.CreatePIN(ctx)
+	if err != nil || pin.Code != "ABCD" {
+		t.Fatalf("CreatePIN = %+v, %v", pin, err)
+	}
+	token, err := c.WaitForToken(ctx, pin, time.Millisecond)
+	if err != nil || token != "account-token" {
+		t.Fatalf("WaitForToken = %q, %v", token, err)
+	}
+
+	servers, err := c.Servers(ctx, token)
+	if err != nil || len(servers) != 1 || servers[0].ClientIdentifier != "m1" {
+		t.Fatalf("Servers = %+v, %v", servers, err)
+	}
+	// The unreachable local address is skipped; the relay is never tried once a direct one answers.
+	conn, err := c.BestConnection(ctx, servers[0], servers[0].AccessToken)
+	if err != nil || conn.URI != pms.URL {
+		t.Fatalf("BestConnection = %+v, %v; want %s", conn, err, pms.URL)
+	}
+}
+
+func TestWaitForToken_expired(t *testing.T) {
+	t.Parallel()
+	tv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": 1, "code": "WXYZ"}`)
+	}))
+	defer tv.Close()
+	c, _ := NewClient(tv.URL, "cid")
+	_, err := c.WaitForToken(context.Background(), &PIN{ID: 1, ExpiresAt: time.Now().Add(-time.Second)}, time.Millisecond)
+	if !errors.Is(err, ErrPINExpired) {
+		t.Fatalf("err = %v, want ErrPINExpired", err)
+	}
+}
+
+func TestRankConnections(t *testing.T) {
+	t.Parallel()
+	got := RankConnections([]Connection{
+		{URI: "relay", Protocol: "https", Relay: true},
+		{URI: "remote", Protocol: "https"},
+		{URI: "local-v6", Protocol: "https", Local: true, IPv6: true},
+		{URI: "local-http", Protocol: "http", Local: true},
+		{URI: "local", Protocol: "https", Local: true},
+	})
+	want := []string{"local", "local-v6", "local-http", "remote", "relay"}
+	for i, w := range want {
+		if got[i].URI != w {
+			t.Fatalf("order = %+v, want %v", got, want)
+		}
+	}
+}
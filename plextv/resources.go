@@ -0,0 +1,130 @@
+package plextv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// probeTimeout bounds each connection check in BestConnection.
+const probeTimeout = 5 * time.Second
+
+// Resource is a device on the account from GET /api/v2/resources. For servers, ClientIdentifier is the
+// machine identifier (PLEX_SERVER_ID) and AccessToken the token to use with that server.
+type Resource struct {
+	Name             string       `json:"name"`
+	Product          string       `json:"product"`
+	Provides         string       `json:"provides"` // comma-separated roles, e.g. "server"
+	ClientIdentifier string       `json:"clientIdentifier"`
+	Owned            bool         `json:"owned"`
+	AccessToken      string       `json:"accessToken"`
+	Connections      []Connection `json:"connections"`
+}
+
+// Connection is one address a server can be reached at.
+type Connection struct {
+	Protocol string `json:"protocol"`
+	Address  string `json:"address"`
+	Port     int    `json:"port"`
+	URI      string `json:"uri"`
+	Local    bool   `json:"local"`
+	Relay    bool   `json:"relay"`
+	IPv6     bool   `json:"IPv6"`
+}
+
+// IsServer reports whether the resource is a Plex Media Server.
+func (r Resource) IsServer() bool {
+	for _, p := range strings.Split(r.Provides, ",") {
+		if strings.TrimSpace(p) == "server" {
+			return true
+		}
+	}
+	return false
+}
+
+// Servers lists the Plex Media Servers the account behind token can reach.
+func (c *Client) Servers(ctx context.Context, token string) ([]Resource, error) {
+	q := url.Values{"includeHttps": {"1"}, "includeRelay": {"1"}}
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/v2/resources", q, token)
+	if err != nil {
+		return nil, err
+	}
+	var all []Resource
+	if err := c.doJSON(req, "list resources", &all); err != nil {
+		return nil, err
+	}
+	var servers []Resource
+	for _, r := range all {
+		if r.IsServer() {
+			servers = append(servers, r)
+		}
+	}
+	return servers, nil
+}
+
+// RankConnections orders connections from most to least preferred: direct local addresses, then direct
+// remote ones, then relays; HTTPS before HTTP and IPv4 before IPv6 within each group.
+func RankConnections(conns []Connection) []Connection {
+	out := append([]Connection(nil), conns...)
+	rank := func(c Connection) int {
+		r := 0
+		switch {
+		case c.Relay:
+			r += 200
+		case !c.Local:
+			r += 100
+		}
+		if c.Protocol != "https" {
+			r += 10
+		}
+		if c.IPv6 {
+			r++
+		}
+		return r
+	}
+	sort.SliceStable(out, func(i, j int) bool { return rank(out[i]) < rank(out[j]) })
+	return out
+}
+
+// BestConnection returns the first connection of server, in RankConnections order, that answers
+// GET /identity with token.
+func (c *Client) BestConnection(ctx context.Context, server Resource, token string) (Connection, error) {
+	var lastErr error
+	for _, conn := range RankConnections(server.Connections) {
+		if strings.TrimSpace(conn.URI) == "" {
+			continue
+		}
+		if err := c.probe(ctx, conn.URI, token); err != nil {
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+	if lastErr == nil {
+		return Connection{}, fmt.Errorf("server %q has no connections", server.Name)
+	}
+	return Connection{}, fmt.Errorf("no connection to server %q answered: %w", server.Name, lastErr)
+}
+
+func (c *Client) probe(ctx context.Context, uri, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(uri, "/")+"/identity", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Plex-Token", token)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", uri, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Package textnorm holds the text folding shared by the Plex matcher, the overrides file and Lidarr tag
+// names: typographic punctuation, Latin diacritics and "feat." credits.
+package textnorm
+
+import (
+	"regexp"
+	"strings"
+)
+
+var reFeaturingInParens = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\s*\(feat\.?\s+[^)]+\)`),
+	regexp.MustCompile(`(?i)\s*\(featuring\s+[^)]+\)`),
+	regexp.MustCompile(`(?i)\s*\(ft\.?\s+[^)]+\)`),
+}
+
+// RemoveFeaturing removes "featuring" and any text after it from a string
+func RemoveFeaturing(s string) string {
+	// First, remove featuring inside parentheses like "(feat. X)" or "(featuring X)"
+	// This handles cases like "Timeless (feat. Playboi Carti & Doechii) - Remix"
+	for _, re := range reFeaturingInParens {
+		if re.MatchString(s) {
+			s = re.ReplaceAllString(s, "")
+			s = strings.TrimSpace(s)
+		}
+	}
+
+	// Handle various "featuring" formats outside parentheses (case insensitive)
+	lowerS := strings.ToLower(s)
+
+	// Check for "featuring" patterns
+	patterns := []string{
+		" featuring ",
+		" feat. ",
+		" feat ",
+		" ft. ",
+		" ft ",
+	}
+
+	for _, pattern := range patterns {
+		lastIndex := strings.LastIndex(lowerS, pattern)
+		if lastIndex != -1 {
+			// Return the original string up to the pattern (preserving original case)
+			return strings.TrimSpace(s[:lastIndex])
+		}
+	}
+
+	return s
+}
+
+// Punctuation normalizes various punctuation marks to standard forms
+func Punctuation(s string) string {
+	// Normalize various types of dashes to standard ASCII hyphen-minus
+	s = strings.ReplaceAll(s, "\u2010", "-") // Unicode hyphen
+	s = strings.ReplaceAll(s, "\u2013", "-") // En dash
+	s = strings.ReplaceAll(s, "\u2014", "-") // Em dash
+	s = strings.ReplaceAll(s, "\u2015", "-") // Horizontal bar
+
+	// Normalize multiplication symbol to 'x' for artist names like "Chloe × Halle"
+	s = strings.ReplaceAll(s, "\u00D7", "x") // Multiplication symbol to 'x'
+
+	// Normalize various types of apostrophes to standard apostrophes
+	s = strings.ReplaceAll(s, "\u2019", "'") // Right single quotation mark to apostrophe
+	s = strings.ReplaceAll(s, "\u2018", "'") // Left single quotation mark to apostrophe
+	s = strings.ReplaceAll(s, "\u0060", "'") // Grave accent to apostrophe
+	s = strings.ReplaceAll(s, "\u2032", "'") // Prime symbol to apostrophe
+
+	// Normalize various types of quotes to standard quotes
+	s = strings.ReplaceAll(s, "\u201C", "\"") // Left double quotation mark to straight quote
+	s = strings.ReplaceAll(s, "\u201D", "\"") // Right double quotation mark to straight quote
+	s = strings.ReplaceAll(s, "\u2018", "'")  // Left single quotation mark to straight quote
+	s = strings.ReplaceAll(s, "\u2019", "'")  // Right single quotation mark to straight quote
+
+	// Normalize ellipsis character to three periods
+	s = strings.ReplaceAll(s, "\u2026", "...") // Horizontal ellipsis to three periods
+
+	return s
+}
+
+// FoldAccents removes or normalizes accented Latin characters to their base form (and ligatures to
+// ASCII digraphs).
+func FoldAccents(s string) string {
+	// Latin typographic ligatures: streaming/MusicBrainz often use ASCII digraphs ("Coeur")
+	// while Plex or store tags use single codepoints ("Cœur"). Map to ASCII before per-rune accents.
+	s = strings.ReplaceAll(s, "\u0152", "OE") // Œ
+	s = strings.ReplaceAll(s, "\u0153", "oe") // œ
+	s = strings.ReplaceAll(s, "\u00C6", "AE") // Æ
+	s = strings.ReplaceAll(s, "\u00E6", "ae") // æ
+
+	// Common accent mappings for music-related terms
+	accentMap := map[rune]rune{
+		// Spanish/Portuguese accents - lowercase
+		'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a', 'ā': 'a', 'ă': 'a', 'ą': 'a',
+		'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e', 'ē': 'e', 'ĕ': 'e', 'ė': 'e', 'ę': 'e',
+		'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i', 'ī': 'i', 'ĭ': 'i', 'į': 'i',
+		'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o', 'ō': 'o', 'ŏ': 'o', 'ő': 'o',
+		'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u', 'ū': 'u', 'ŭ': 'u', 'ů': 'u', 'ű': 'u',
+		'ý': 'y', 'ÿ': 'y', 'ŷ': 'y',
+		'ñ': 'n', 'ń': 'n', 'ņ': 'n', 'ň': 'n',
+		'ç': 'c', 'ć': 'c', 'ĉ': 'c', 'ċ': 'c', 'č': 'c',
+		'ś': 's', 'ŝ': 's', 'ş': 's', 'š': 's',
+		'ź': 'z', 'ż': 'z', 'ž': 'z',
+		'ł': 'l', 'ĺ': 'l', 'ļ': 'l', 'ľ': 'l',
+		'ř': 'r', 'ŕ': 'r', 'ŗ': 'r',
+		'ğ': 'g', 'ģ': 'g', 'ġ': 'g',
+		'ḫ': 'h', 'ĥ': 'h', 'ħ': 'h',
+		'ḏ': 'd', 'ď': 'd', 'đ': 'd',
+		'ṯ': 't', 'ť': 't', 'ţ': 't',
+		'ḅ': 'b', 'ḃ': 'b',
+		'ṗ': 'p', 'ṕ': 'p',
+		'ḳ': 'k', 'ḵ': 'k',
+		'ḷ': 'l', 'ḹ': 'l',
+		'ṁ': 'm', 'ṃ': 'm',
+		'ṅ': 'n', 'ṇ': 'n',
+		'ṡ': 's', 'ṣ': 's',
+		'ṫ': 't', 'ṭ': 't',
+		'ṻ': 'u', 'ṳ': 'u',
+		'ṽ': 'v', 'ṿ': 'v',
+		'ẁ': 'w', 'ẃ': 'w', 'ẅ': 'w', 'ẇ': 'w', 'ẉ': 'w',
+		'ẋ': 'x', 'ẍ': 'x',
+		'ỳ': 'y', 'ỹ': 'y', 'ỷ': 'y',
+		'ẑ': 'z', 'ẓ': 'z', 'ẕ': 'z',
+
+		// Spanish/Portuguese accents - uppercase
+		'Á': 'A', 'À': 'A', 'Â': 'A', 'Ã': 'A', 'Ä': 'A', 'Å': 'A', 'Ā': 'A', 'Ă': 'A', 'Ą': 'A',
+		'É': 'E', 'È': 'E', 'Ê': 'E', 'Ë': 'E', 'Ē': 'E', 'Ĕ': 'E', 'Ė': 'E', 'Ę': 'E',
+		'Í': 'I', 'Ì': 'I', 'Î': 'I', 'Ï': 'I', 'Ī': 'I', 'Ĭ': 'I', 'Į': 'I',
+		'Ó': 'O', 'Ò': 'O', 'Ô': 'O', 'Õ': 'O', 'Ö': 'O', 'Ø': 'O', 'Ō': 'O', 'Ŏ': 'O', 'Ő': 'O',
+		'Ú': 'U', 'Ù': 'U', 'Û': 'U', 'Ü': 'U', 'Ū': 'U', 'Ŭ': 'U', 'Ů': 'U', 'Ű': 'U',
+		'Ý': 'Y', 'Ÿ': 'Y', 'Ŷ': 'Y',
+		'Ñ': 'N', 'Ń': 'N', 'Ņ': 'N', 'Ň': 'N',
+		'Ç': 'C', 'Ć': 'C', 'Ĉ': 'C', 'Ċ': 'C', 'Č': 'C',
+		'Ś': 'S', 'Ŝ': 'S', 'Ş': 'S', 'Š': 'S',
+		'Ź': 'Z', 'Ż': 'Z', 'Ž': 'Z',
+		'Ł': 'L', 'Ĺ': 'L', 'Ļ': 'L', 'Ľ': 'L',
+		'Ř': 'R', 'Ŕ': 'R', 'Ŗ': 'R',
+		'Ğ': 'G', 'Ģ': 'G', 'Ġ': 'G',
+		'Ḫ': 'H', 'Ĥ': 'H', 'Ħ': 'H',
+		'Ḏ': 'D', 'Ď': 'D', 'Đ': 'D',
+		'Ṯ': 'T', 'Ť': 'T', 'Ţ': 'T',
+		'Ḅ': 'B', 'Ḃ': 'B',
+		'Ṗ': 'P', 'Ṕ': 'P',
+		'Ḳ': 'K', 'Ḵ': 'K',
+		'Ḷ': 'L', 'Ḹ': 'L',
+		'Ṁ': 'M', 'Ṃ': 'M',
+		'Ṅ': 'N', 'Ṇ': 'N',
+		'Ṡ': 'S', 'Ṣ': 'S',
+		'Ṫ': 'T', 'Ṭ': 'T',
+		'Ṻ': 'U', 'Ṳ': 'U',
+		'Ṽ': 'V', 'Ṿ': 'V',
+		'Ẁ': 'W', 'Ẃ': 'W', 'Ẅ': 'W', 'Ẇ': 'W', 'Ẉ': 'W',
+		'Ẋ': 'X', 'Ẍ': 'X',
+		'Ỳ': 'Y', 'Ỹ': 'Y', 'Ỷ': 'Y',
+		'Ẑ': 'Z', 'Ẓ': 'Z', 'Ẕ': 'Z',
+	}
+
+	result := make([]rune, 0, len(s))
+	for _, r := range s {
+		if replacement, exists := accentMap[r]; exists {
+			result = append(result, replacement)
+		} else {
+			result = append(result, r)
+		}
+	}
+
+	return string(result)
+}
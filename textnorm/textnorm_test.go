@@ -0,0 +1,18 @@
+package textnorm
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	t.Parallel()
+	tests := []struct{ in, want string }{
+		{"Música Latina", "Musica Latina"},
+		{"Кино", "Kino"},
+		{"ＹＯＡＳＯＢＩ", "YOASOBI"},
+		{"Æther Œuvre", "AEther OEuvre"},
+	}
+	for _, tt := range tests {
+		if got := Transliterate(tt.in); got != tt.want {
+			t.Errorf("Transliterate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
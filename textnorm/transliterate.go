@@ -0,0 +1,243 @@
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Transliterate folds fullwidth ASCII and romanizes Cyrillic, Greek, Japanese kana and Hangul so a
+// native-script name can be compared with a romanized one (and vice versa), then folds Latin diacritics:
+// "Música Latina" → "Musica Latina", "Кино" → "Kino". Tables are compiled in; no network or ICU data is
+// needed. Han characters (kanji/hanzi) have no reading without a dictionary and pass through unchanged.
+func Transliterate(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	s = foldFullwidth(s)
+	var b strings.Builder
+	rs := []rune(s)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case isKana(r):
+			n := romanizeKana(&b, rs[i:])
+			i += n
+			continue
+		case r >= hangulBase && r <= hangulLast:
+			var next rune
+			if i+1 < len(rs) {
+				next = rs[i+1]
+			}
+			b.WriteString(romanizeHangulSyllable(r, next))
+		case unicode.Is(unicode.Cyrillic, r):
+			writeCased(&b, r, cyrillicLatin)
+		case unicode.Is(unicode.Greek, r):
+			if lr := unicode.ToLower(r); (lr == 'ο' || lr == 'ό') && i+1 < len(rs) && (unicode.ToLower(rs[i+1]) == 'υ' || unicode.ToLower(rs[i+1]) == 'ύ') {
+				// ου is one vowel in Greek romanization ("Μουσική" → "Mousiki").
+				writeCasedString(&b, r, "ou")
+				i += 2
+				continue
+			}
+			writeCased(&b, r, greekLatin)
+		default:
+			b.WriteRune(r)
+		}
+		i++
+	}
+	return FoldAccents(b.String())
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// foldFullwidth maps fullwidth ASCII (U+FF01–U+FF5E) and the ideographic space to their ASCII forms,
+// so "ＹＯＡＳＯＢＩ" compares equal to "YOASOBI".
+func foldFullwidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0xFF01 && r <= 0xFF5E:
+			return r - 0xFEE0
+		case r == 0x3000:
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// writeCased writes table[lower(r)], capitalizing the first letter when r is upper case. Unknown runes are kept.
+func writeCased(b *strings.Builder, r rune, table map[rune]string) {
+	lat, ok := table[unicode.ToLower(r)]
+	if !ok {
+		b.WriteRune(r)
+		return
+	}
+	writeCasedString(b, r, lat)
+}
+
+func writeCasedString(b *strings.Builder, r rune, lat string) {
+	if lat == "" || !unicode.IsUpper(r) {
+		b.WriteString(lat)
+		return
+	}
+	b.WriteString(strings.ToUpper(lat[:1]))
+	b.WriteString(lat[1:])
+}
+
+// cyrillicLatin is a practical (BGN/PCGN-style) romanization covering Russian, Ukrainian, Belarusian,
+// Serbian and Macedonian letters. Hard and soft signs are dropped.
+var cyrillicLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r",
+	'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	// Ukrainian / Belarusian
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u",
+	// Serbian / Macedonian
+	'ђ': "dj", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+}
+
+// greekLatin is an ELOT 743-style romanization of modern Greek (tonos and dialytika fold to the bare vowel).
+var greekLatin = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+	'ά': "a", 'έ': "e", 'ή': "i", 'ί': "i", 'ό': "o", 'ύ': "y", 'ώ': "o",
+	'ϊ': "i", 'ϋ': "y", 'ΐ': "i", 'ΰ': "y",
+}
+
+const (
+	hiraganaFirst = 0x3041
+	hiraganaLast  = 0x3096
+	katakanaFirst = 0x30A1
+	katakanaLast  = 0x30FA
+	kanaLongVowel = 'ー'
+	kanaOffset    = katakanaFirst - hiraganaFirst
+)
+
+func isKana(r rune) bool {
+	return (r >= hiraganaFirst && r <= hiraganaLast) || (r >= katakanaFirst && r <= katakanaLast) || r == kanaLongVowel
+}
+
+// toHiragana maps katakana onto the hiragana block so one table serves both scripts.
+func toHiragana(r rune) rune {
+	if r >= katakanaFirst && r <= 0x30F6 {
+		return r - kanaOffset
+	}
+	return r
+}
+
+// kanaRomaji is modified Hepburn for single kana (in hiragana form).
+var kanaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa", 'ゔ': "vu",
+	'ゕ': "ka", 'ゖ': "ke",
+	// Katakana-only letters (no hiragana counterpart in toHiragana).
+	'ヷ': "va", 'ヸ': "vi", 'ヹ': "ve", 'ヺ': "vo",
+}
+
+// kanaDigraphs are two-kana combinations (in hiragana form) romanized as one syllable: yōon (きゃ → kya)
+// and the small-vowel spellings used for loanwords in katakana (ファ → fa, ティ → ti).
+var kanaDigraphs = map[string]string{
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo", "ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "じゃ": "ja", "じゅ": "ju", "じょ": "jo",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "ぢゃ": "ja", "ぢゅ": "ju", "ぢょ": "jo",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo", "ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo", "ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo", "りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"しぇ": "she", "じぇ": "je", "ちぇ": "che", "つぁ": "tsa", "つぃ": "tsi", "つぇ": "tse", "つぉ": "tso",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du", "てゅ": "tyu", "でゅ": "dyu",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo", "ふゅ": "fyu",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo", "ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+	"いぇ": "ye", "くぁ": "kwa", "ぐぁ": "gwa",
+}
+
+// romanizeKana writes the romaji for the kana run starting at rs[0] and returns how many runes it consumed.
+// Sokuon (っ) doubles the next consonant and the prolonged sound mark (ー) repeats the previous vowel.
+func romanizeKana(b *strings.Builder, rs []rune) int {
+	r := toHiragana(rs[0])
+	switch r {
+	case 'っ':
+		if len(rs) > 1 && isKana(rs[1]) {
+			var next strings.Builder
+			romanizeKana(&next, rs[1:])
+			if s := next.String(); s != "" && !strings.ContainsRune("aeiou", rune(s[0])) {
+				if strings.HasPrefix(s, "ch") {
+					b.WriteByte('t')
+				} else {
+					b.WriteByte(s[0])
+				}
+			}
+		}
+		return 1
+	case kanaLongVowel:
+		if out := b.String(); out != "" && strings.ContainsRune("aeiou", rune(out[len(out)-1])) {
+			b.WriteByte(out[len(out)-1])
+		}
+		return 1
+	}
+	if len(rs) > 1 {
+		if s, ok := kanaDigraphs[string([]rune{r, toHiragana(rs[1])})]; ok {
+			b.WriteString(s)
+			return 2
+		}
+	}
+	if s, ok := kanaRomaji[r]; ok {
+		b.WriteString(s)
+	} else {
+		b.WriteRune(rs[0])
+	}
+	return 1
+}
+
+const (
+	hangulBase = 0xAC00
+	hangulLast = 0xD7A3
+)
+
+// Revised Romanization of Korean jamo, indexed by position in a precomposed syllable.
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	// hangulFinals is the final consonant at the end of a word or before another consonant.
+	hangulFinals = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+	// hangulFinalsLinked is the final consonant carried into a following vowel-initial (ㅇ) syllable,
+	// e.g. 한국어 → hangugeo rather than hangukeo.
+	hangulFinalsLinked = []string{"", "g", "kk", "gs", "n", "nj", "n", "d", "r", "lg", "lm", "lb", "ls", "lt", "lp", "r", "m", "b", "bs", "s", "ss", "ng", "j", "ch", "k", "t", "p", ""}
+)
+
+// romanizeHangulSyllable romanizes one precomposed syllable; next is the following rune (0 at the end),
+// used to link a final consonant into a following silent-ㅇ syllable.
+func romanizeHangulSyllable(r, next rune) string {
+	idx := int(r - hangulBase)
+	initial, medial, final := idx/(21*28), (idx%(21*28))/28, idx%28
+	out := hangulInitials[initial] + hangulMedials[medial]
+	if final == 0 {
+		return out
+	}
+	if next >= hangulBase && next <= hangulLast && int(next-hangulBase)/(21*28) == 11 {
+		return out + hangulFinalsLinked[final]
+	}
+	return out + hangulFinals[final]
+}
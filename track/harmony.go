@@ -35,17 +35,23 @@ func HarmonyAddToMusicBrainzURL(spotifyAlbumURI, appleMusicAlbumID string) strin
 }
 
 func spotifyAlbumHTTPSURL(spotifyAlbumURI string) string {
+	id := SpotifyAlbumID(spotifyAlbumURI)
+	if id == "" {
+		return ""
+	}
+	return "https://open.spotify.com/album/" + url.PathEscape(id)
+}
+
+// SpotifyAlbumID returns the album id from a spotify:album:{id} URI or an open.spotify.com album URL,
+// or "" when spotifyAlbumURI is neither.
+func SpotifyAlbumID(spotifyAlbumURI string) string {
 	s := strings.TrimSpace(spotifyAlbumURI)
 	if s == "" {
 		return ""
 	}
 	const prefix = "spotify:album:"
 	if strings.HasPrefix(s, prefix) {
-		id := strings.TrimSpace(strings.TrimPrefix(s, prefix))
-		if id == "" {
-			return ""
-		}
-		return "https://open.spotify.com/album/" + url.PathEscape(id)
+		return strings.TrimSpace(strings.TrimPrefix(s, prefix))
 	}
 	u, err := url.Parse(s)
 	if err != nil || u.Scheme == "" || u.Host == "" {
@@ -59,7 +65,7 @@ func spotifyAlbumHTTPSURL(spotifyAlbumURI string) string {
 	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
 	for i := 0; i < len(parts)-1; i++ {
 		if parts[i] == "album" && parts[i+1] != "" {
-			return "https://open.spotify.com/album/" + url.PathEscape(parts[i+1])
+			return parts[i+1]
 		}
 	}
 	return ""
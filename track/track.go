@@ -1,5 +1,7 @@
 package track
 
+import "slices"
+
 // Track is a normalized track from a source playlist (e.g. music-social.com) or any future source.
 type Track struct {
 	ID                        string // Optional stable id for logging (e.g. position-based key)
@@ -7,6 +9,8 @@ type Track struct {
 	Artist                    string
 	Album                     string
 	Duration                  int // milliseconds
+	TrackNumber               int // position on the source album; 0 when unknown
+	DiscNumber                int // disc of TrackNumber; 0 when unknown
 	ISRC                      string
 	MusicBrainzID             string // Recording MBID when known
 	MusicBrainzReleaseGroupID string // When set (from API), missing-track summary links to release group instead of recording
@@ -14,8 +18,36 @@ type Track struct {
 	// PlexSearchArtistCandidates tries each distinct name after the main Artist field so catalog aliases
 	// match Plex without an extra Plex Artist metadata fetch when possible.
 	MusicBrainzArtistCredits []string
+	// MusicBrainzArtists are the same credits with their artist MBIDs, in credit order, so each id stays
+	// paired with its name. Lidarr can track the artist when a missing track has no release group id.
+	MusicBrainzArtists []ArtistCredit
 
 	// Streaming album identifiers from music-social.com JSON (optional).
 	SpotifyAlbumURI   string // e.g. spotify:album:{id} or https://open.spotify.com/album/...
 	AppleMusicAlbumID string // Apple Music catalog album id (storefront fixed to us for Harmony)
 }
+
+// ArtistCredit is one MusicBrainz artist credit of a track.
+type ArtistCredit struct {
+	Name string
+	ID   string // artist MBID; empty when the source credit has none
+}
+
+// PrimaryMusicBrainzArtist returns the first credit, the track's primary artist, when it has an artist MBID.
+func (t Track) PrimaryMusicBrainzArtist() (ArtistCredit, bool) {
+	if len(t.MusicBrainzArtists) == 0 || t.MusicBrainzArtists[0].ID == "" {
+		return ArtistCredit{}, false
+	}
+	return t.MusicBrainzArtists[0], true
+}
+
+// MusicBrainzArtistIDs returns the distinct artist MBIDs of MusicBrainzArtists, in credit order.
+func (t Track) MusicBrainzArtistIDs() []string {
+	var out []string
+	for _, a := range t.MusicBrainzArtists {
+		if a.ID != "" && !slices.Contains(out, a.ID) {
+			out = append(out, a.ID)
+		}
+	}
+	return out
+}